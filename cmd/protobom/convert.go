@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newConvertCommand() *cobra.Command {
+	oo := &outputOptions{}
+	var inputMods []string
+
+	cmd := &cobra.Command{
+		Use:   "convert FILE",
+		Short: "Convert an SBOM to another format",
+		Long: `Convert reads an SBOM in any of the supported formats and writes it in
+the format set with --format. Pass - as FILE to read from stdin.
+
+Serializer drivers can be tuned with --format-option key=value. The keys are
+the fields of the driver options (eg GenerateSerialNumber for CycloneDX,
+LicenseExpressionOperator for SPDX).`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ro, err := readOptions(inputMods)
+			if err != nil {
+				return err
+			}
+
+			doc, err := readDocument(args[0], ro, cmd.InOrStdin())
+			if err != nil {
+				return fmt.Errorf("reading %s: %w", args[0], err)
+			}
+
+			if err := oo.writeDocument(doc, cmd.OutOrStdout()); err != nil {
+				return fmt.Errorf("writing document: %w", err)
+			}
+			return nil
+		},
+	}
+
+	oo.addFlags(cmd)
+	cmd.Flags().StringSliceVar(&inputMods, "input-mod", nil, "mod to enable when reading the input (repeatable)")
+	_ = cmd.MarkFlagRequired("format") //nolint:errcheck
+	return cmd
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+func newDiffCommand() *cobra.Command {
+	var inputMods []string
+
+	cmd := &cobra.Command{
+		Use:   "diff FILE1 FILE2",
+		Short: "Compare the nodes and relationships of two SBOMs",
+		Long: `Diff compares two SBOMs, which can be in different formats. Nodes are
+matched by their ID. The output lists the nodes added (+), removed (-) and
+changed (~) in FILE2, followed by the relationships added and removed.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ro, err := readOptions(inputMods)
+			if err != nil {
+				return err
+			}
+
+			docs := make([]*sbom.Document, 0, len(args))
+			for _, path := range args {
+				doc, err := readDocument(path, ro, cmd.InOrStdin())
+				if err != nil {
+					return fmt.Errorf("reading %s: %w", path, err)
+				}
+				docs = append(docs, doc)
+			}
+
+			printDiff(cmd.OutOrStdout(), docs[0].GetNodeList(), docs[1].GetNodeList())
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&inputMods, "input-mod", nil, "mod to enable when reading the documents (repeatable)")
+	return cmd
+}
+
+// printDiff writes the differences between two node lists to w
+func printDiff(w io.Writer, nl1, nl2 *sbom.NodeList) {
+	d := nl1.Diff(nl2)
+	for _, n := range d.AddedNodes {
+		fmt.Fprintf(w, "+ node %s (%s)\n", n.GetId(), nodeLabel(n))
+	}
+	for _, n := range d.RemovedNodes {
+		fmt.Fprintf(w, "- node %s (%s)\n", n.GetId(), nodeLabel(n))
+	}
+	changed := make([]string, 0, len(d.ChangedNodes))
+	for id := range d.ChangedNodes {
+		changed = append(changed, id)
+	}
+	slices.Sort(changed)
+	for _, id := range changed {
+		fmt.Fprintf(w, "~ node %s (%s): %d changes\n", id, nodeLabel(nl2.GetNodeByID(id)), d.ChangedNodes[id].DiffCount)
+	}
+	for _, e := range d.AddedEdges {
+		fmt.Fprintf(w, "+ edge %s -%s-> %s\n", e.GetFrom(), e.GetType(), e.GetTo()[0])
+	}
+	for _, e := range d.RemovedEdges {
+		fmt.Fprintf(w, "- edge %s -%s-> %s\n", e.GetFrom(), e.GetType(), e.GetTo()[0])
+	}
+
+	fmt.Fprintf(w, "nodes: %d added, %d removed, %d changed; edges: %d added, %d removed\n",
+		len(d.AddedNodes), len(d.RemovedNodes), len(d.ChangedNodes), len(d.AddedEdges), len(d.RemovedEdges))
+}
+
+func nodeLabel(n *sbom.Node) string {
+	if n.GetVersion() == "" {
+		return n.GetName()
+	}
+	return n.GetName() + "@" + n.GetVersion()
+}
@@ -0,0 +1,42 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2026 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+// Command protobom converts, inspects and stores SBOMs using the protobom
+// libraries. It is built only on the public packages of the module.
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func main() {
+	if err := newRootCommand().Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func newRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "protobom",
+		Short:         "Convert, inspect and store SBOMs",
+		SilenceUsage:  true,
+		SilenceErrors: false,
+	}
+
+	cmd.AddCommand(
+		newConvertCommand(),
+		newSniffCommand(),
+		newDiffCommand(),
+		newMergeCommand(),
+		newQueryCommand(),
+		newStoreCommand(),
+		newRetrieveCommand(),
+		newServeCommand(),
+	)
+	return cmd
+}
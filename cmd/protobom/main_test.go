@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/formats"
+	"github.com/protobom/protobom/pkg/native/serializers"
+)
+
+const testSBOM = "../../test/conformance/testdata/cyclonedx/1.5/json/syft-0.96.0_plone-5.2.cdx.json"
+
+func runCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	var out bytes.Buffer
+	cmd := newRootCommand()
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestConvertAndSniff(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "sbom.spdx.json.gz")
+	_, err := runCommand(t, "convert", testSBOM, "--format", "spdx-2.3", "-o", path,
+		"--format-option", "LicenseExpressionOperator=AND")
+	require.NoError(t, err)
+
+	out, err := runCommand(t, "sniff", path)
+	require.NoError(t, err)
+	require.Contains(t, out, string(formats.SPDX23JSON))
+
+	_, err = runCommand(t, "convert", testSBOM, "--format", "spdx-2.3", "--format-option", "Bogus=1")
+	require.Error(t, err)
+	_, err = runCommand(t, "convert", testSBOM, "--format", "nope")
+	require.Error(t, err)
+	_, err = runCommand(t, "convert", testSBOM, "--format", "cyclonedx-1.6", "--mod", "nope")
+	require.Error(t, err)
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+	out, err := runCommand(t, "diff", testSBOM, testSBOM)
+	require.NoError(t, err)
+	require.Equal(t, "nodes: 0 added, 0 removed, 0 changed; edges: 0 added, 0 removed\n", out)
+}
+
+func TestQuery(t *testing.T) {
+	t.Parallel()
+	out, err := runCommand(t, "query", testSBOM, "--name", "Acquisition")
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 1)
+	require.Contains(t, lines[0], "pkg:pypi/Acquisition@4.13")
+
+	out, err = runCommand(t, "query", testSBOM, "--name", "Acquisition", "--purl-type", "npm")
+	require.NoError(t, err)
+	require.Empty(t, out)
+}
+
+func TestStoreRetrieve(t *testing.T) {
+	t.Parallel()
+	for _, backend := range []string{backendFileSystem, backendContent, backendOCI, backendSQLite} {
+		t.Run(backend, func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), "store")
+			out, err := runCommand(t, "store", "-b", backend, "-p", path, testSBOM)
+			require.NoError(t, err)
+			fields := strings.Fields(out)
+			require.Len(t, fields, 2)
+
+			out, err = runCommand(t, "retrieve", "-b", backend, "-p", path, fields[1], "-f", "cyclonedx-1.6")
+			require.NoError(t, err)
+			require.Contains(t, out, `"specVersion": "1.6"`)
+		})
+	}
+}
+
+func TestApplyFormatOptions(t *testing.T) {
+	t.Parallel()
+	opts, err := applyFormatOptions(serializers.NewCDX("1.6", formats.JSON), []string{"generateserialnumber=false"})
+	require.NoError(t, err)
+	require.Equal(t, serializers.CDXOptions{GenerateSerialNumber: false}, opts)
+
+	_, err = applyFormatOptions(serializers.NewCDX("1.6", formats.JSON), []string{"GenerateSerialNumber"})
+	require.Error(t, err)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+
+	"github.com/protobom/protobom/pkg/reader"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+func newMergeCommand() *cobra.Command {
+	oo := &outputOptions{}
+	var inputMods []string
+
+	cmd := &cobra.Command{
+		Use:   "merge PATH...",
+		Short: "Merge several SBOMs into one document",
+		Long: `Merge reads the SBOMs found in the paths passed and writes a single
+document with all their nodes. Paths can be files, directories, tar or zip
+archives and JSON lines files. Nodes are tagged with the file they were read
+from in the protobom:source property.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ro, err := readOptions(inputMods)
+			if err != nil {
+				return err
+			}
+
+			r := reader.New()
+			docs := []*sbom.Document{}
+			for _, path := range args {
+				pathDocs, err := r.ParseMultiWithOptions(path, ro)
+				if err != nil {
+					return fmt.Errorf("reading %s: %w", path, err)
+				}
+				docs = append(docs, pathDocs...)
+			}
+			if len(docs) == 0 {
+				return fmt.Errorf("no SBOMs found")
+			}
+
+			merged := sbom.MergeDocuments(docs...)
+			if merged.GetMetadata().GetId() == "" {
+				merged.Metadata.Id = "urn:uuid:" + uuid.NewString()
+			}
+
+			if err := oo.writeDocument(merged, cmd.OutOrStdout()); err != nil {
+				return fmt.Errorf("writing document: %w", err)
+			}
+			return nil
+		},
+	}
+
+	oo.addFlags(cmd)
+	cmd.Flags().StringSliceVar(&inputMods, "input-mod", nil, "mod to enable when reading the documents (repeatable)")
+	_ = cmd.MarkFlagRequired("format") //nolint:errcheck
+	return cmd
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/protobom/protobom/pkg/formats"
+	"github.com/protobom/protobom/pkg/mod"
+	"github.com/protobom/protobom/pkg/native"
+	"github.com/protobom/protobom/pkg/native/serializers"
+	"github.com/protobom/protobom/pkg/reader"
+	"github.com/protobom/protobom/pkg/sbom"
+	"github.com/protobom/protobom/pkg/writer"
+)
+
+// knownMods lists the mods that can be enabled from the command line
+var knownMods = []mod.Mod{
+	mod.SPDX_RENDER_PROPERTIES_IN_ANNOTATIONS,
+	mod.SPDX_READ_ANNOTATIONS_TO_PROPERTIES,
+	mod.CYCLONEDX_MULTIROOT_HEADLESS,
+}
+
+// formatOptionDefaults maps the type of each serializer driver to its
+// default options. The values set with --format-option are applied on
+// a copy of them.
+var formatOptionDefaults = map[string]any{
+	fmt.Sprintf("%T", &serializers.CDX{}):    serializers.DefaultCDXOptions,
+	fmt.Sprintf("%T", &serializers.SPDX23{}): serializers.DefaultSPDX23Options,
+}
+
+// parseFormat returns the format identified by name. It accepts the full
+// format string (eg text/spdx+json;version=2.3) or a short name made of
+// the type, version and, optionally, encoding (eg spdx-2.3, cyclonedx-1.6-json).
+func parseFormat(name string) (formats.Format, error) {
+	if name == "" {
+		return "", errors.New("no format specified")
+	}
+	for _, f := range knownFormats() {
+		if string(f) == name || slices.Contains(shortFormatNames(f), strings.ToLower(name)) {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unknown format %q", name)
+}
+
+// knownFormats returns the formats that have a serializer registered
+func knownFormats() []formats.Format {
+	list := []formats.Format{}
+	for _, f := range []formats.Format{
+		formats.CDX10JSON, formats.CDX11JSON, formats.CDX12JSON,
+		formats.CDX13JSON, formats.CDX14JSON, formats.CDX15JSON,
+		formats.CDX16JSON, formats.CDX17JSON,
+		formats.SPDX22JSON, formats.SPDX23JSON,
+		formats.SPDX22TV, formats.SPDX23TV,
+	} {
+		if _, err := writer.GetFormatSerializer(f); err == nil {
+			list = append(list, f)
+		}
+	}
+	return list
+}
+
+func shortFormatNames(f formats.Format) []string {
+	base := fmt.Sprintf("%s-%s", f.Type(), f.Version())
+	names := []string{base + "-" + f.Encoding()}
+	if f.Encoding() == formats.JSON {
+		names = append(names, base)
+	}
+	return names
+}
+
+// parseMods converts the mod names passed in the command line
+func parseMods(names []string) (map[mod.Mod]struct{}, error) {
+	mods := map[mod.Mod]struct{}{}
+	for _, name := range names {
+		m := mod.Mod(strings.ToUpper(name))
+		if !slices.Contains(knownMods, m) {
+			return nil, fmt.Errorf("unknown mod %q", name)
+		}
+		mods[m] = struct{}{}
+	}
+	return mods, nil
+}
+
+// applyFormatOptions builds the options struct of the serializer driver
+// from its defaults and the key=value pairs in opts. Keys are the names
+// of the fields in the options struct, matched case insensitively.
+func applyFormatOptions(serializer any, opts []string) (any, error) {
+	key := fmt.Sprintf("%T", serializer)
+	defaults, ok := formatOptionDefaults[key]
+	if !ok {
+		if len(opts) > 0 {
+			return nil, fmt.Errorf("driver %s does not support format options", key)
+		}
+		return nil, nil //nolint:nilnil // No options to set
+	}
+
+	v := reflect.New(reflect.TypeOf(defaults)).Elem()
+	v.Set(reflect.ValueOf(defaults))
+
+	for _, opt := range opts {
+		name, value, found := strings.Cut(opt, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid format option %q, must be key=value", opt)
+		}
+
+		field := v.FieldByNameFunc(func(s string) bool {
+			return strings.EqualFold(s, strings.TrimSpace(name))
+		})
+		if !field.IsValid() || !field.CanSet() {
+			return nil, fmt.Errorf("unknown option %q for driver %s", name, key)
+		}
+
+		switch field.Kind() { //nolint:exhaustive // Only these types are used in options
+		case reflect.Bool:
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("parsing option %s: %w", name, err)
+			}
+			field.SetBool(b)
+		case reflect.String:
+			field.SetString(value)
+		case reflect.Int, reflect.Int64:
+			i, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("parsing option %s: %w", name, err)
+			}
+			field.SetInt(i)
+		default:
+			return nil, fmt.Errorf("option %s cannot be set from the command line", name)
+		}
+	}
+	return v.Interface(), nil
+}
+
+// outputOptions are the flags shared by the commands that write SBOMs
+type outputOptions struct {
+	Format        string
+	Output        string
+	Mods          []string
+	FormatOptions []string
+	InToto        bool
+}
+
+// writeDocument renders doc according to the output options. The document
+// is written to stdout when no output file is set.
+func (oo *outputOptions) writeDocument(doc *sbom.Document, stdout io.Writer) error {
+	format, err := parseFormat(oo.Format)
+	if err != nil {
+		return err
+	}
+
+	mods, err := parseMods(oo.Mods)
+	if err != nil {
+		return err
+	}
+
+	serializer, err := writer.GetFormatSerializer(format)
+	if err != nil {
+		return err
+	}
+
+	formatOpts, err := applyFormatOptions(serializer, oo.FormatOptions)
+	if err != nil {
+		return err
+	}
+
+	opts := &writer.Options{
+		Format:           format,
+		RenderOptions:    &native.RenderOptions{Indent: 2},
+		SerializeOptions: &native.SerializeOptions{Mods: mods},
+		InTotoStatement:  oo.InToto,
+	}
+	if formatOpts != nil {
+		opts.SetFormatOptions(serializer, formatOpts)
+	}
+
+	w := writer.New()
+	if oo.Output == "" || oo.Output == "-" {
+		return w.WriteStreamWithOptions(doc, stdout, opts)
+	}
+	return w.WriteFileWithOptions(doc, oo.Output, opts)
+}
+
+// readOptions returns the reader options to parse documents with the mods
+// passed in the command line.
+func readOptions(modNames []string) (*reader.Options, error) {
+	mods, err := parseMods(modNames)
+	if err != nil {
+		return nil, err
+	}
+	return &reader.Options{
+		UnserializeOptions: &native.UnserializeOptions{Mods: mods},
+	}, nil
+}
+
+// readDocument parses the SBOM at path. If path is "-" the document is read
+// from stdin.
+func readDocument(path string, opts *reader.Options, stdin io.Reader) (*sbom.Document, error) {
+	r := reader.New()
+	if path != "-" {
+		return r.ParseFileWithOptions(path, opts)
+	}
+
+	data, err := io.ReadAll(stdin)
+	if err != nil {
+		return nil, fmt.Errorf("reading stdin: %w", err)
+	}
+	return r.ParseStreamWithOptions(bytes.NewReader(data), opts)
+}
+
+// addFlags registers the output flags in cmd
+func (oo *outputOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&oo.Format, "format", "f", "", "output format, a full format string or a short name (eg spdx-2.3, cyclonedx-1.6)")
+	cmd.Flags().StringVarP(&oo.Output, "output", "o", "", "file to write the document to (default stdout). Compression is inferred from .gz and .zst extensions")
+	cmd.Flags().StringSliceVar(&oo.Mods, "mod", nil, "mod to enable when serializing (repeatable)")
+	cmd.Flags().BoolVar(&oo.InToto, "in-toto", false, "wrap the document in an in-toto attestation statement")
+	cmd.Flags().StringArrayVar(&oo.FormatOptions, "format-option", nil, "serializer driver option as key=value (eg GenerateSerialNumber=false)")
+}
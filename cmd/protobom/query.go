@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+type queryOptions struct {
+	ID          string
+	Name        string
+	Purl        string
+	PurlType    string
+	CPE         string
+	Identifiers []string
+	Descendants string
+	Depth       int
+	Roots       bool
+}
+
+func newQueryCommand() *cobra.Command {
+	qo := &queryOptions{}
+	var inputMods []string
+
+	cmd := &cobra.Command{
+		Use:   "query FILE",
+		Short: "Look up nodes in an SBOM",
+		Long: `Query lists the nodes of an SBOM that match all the filters passed. Each
+match is printed as a tab separated line with the node ID, name, version and
+package URL. Without filters, all the nodes are listed.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ro, err := readOptions(inputMods)
+			if err != nil {
+				return err
+			}
+
+			doc, err := readDocument(args[0], ro, cmd.InOrStdin())
+			if err != nil {
+				return fmt.Errorf("reading %s: %w", args[0], err)
+			}
+
+			nodes, err := qo.run(doc.GetNodeList())
+			if err != nil {
+				return err
+			}
+
+			for _, n := range nodes {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\t%s\t%s\n", n.GetId(), n.GetName(), n.GetVersion(), n.Purl())
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&qo.ID, "id", "", "match the node with this ID")
+	cmd.Flags().StringVar(&qo.Name, "name", "", "match nodes with this name")
+	cmd.Flags().StringVar(&qo.Purl, "purl", "", "match nodes with this package URL")
+	cmd.Flags().StringVar(&qo.PurlType, "purl-type", "", "match nodes with package URLs of this type (eg npm)")
+	cmd.Flags().StringVar(&qo.CPE, "cpe", "", "match nodes with a CPE matching this 2.2 or 2.3 pattern (eg cpe:2.3:a:apache:*)")
+	cmd.Flags().StringArrayVar(&qo.Identifiers, "identifier", nil, "match nodes with an identifier as type=value (eg cpe23=cpe:2.3:...)")
+	cmd.Flags().StringVar(&qo.Descendants, "descendants", "", "only consider the nodes related to the node with this ID")
+	cmd.Flags().IntVar(&qo.Depth, "depth", 0, "maximum depth when looking for descendants (0 for no limit)")
+	cmd.Flags().BoolVar(&qo.Roots, "roots", false, "match only the root nodes of the document")
+	cmd.Flags().StringSliceVar(&inputMods, "input-mod", nil, "mod to enable when reading the document (repeatable)")
+	return cmd
+}
+
+// run returns the nodes in nl that match all the query filters
+func (qo *queryOptions) run(nl *sbom.NodeList) ([]*sbom.Node, error) {
+	if nl == nil {
+		return []*sbom.Node{}, nil
+	}
+
+	if qo.Descendants != "" {
+		// The depth of the graph cannot exceed the number of nodes
+		depth := qo.Depth
+		if depth <= 0 {
+			depth = len(nl.GetNodes())
+		}
+		nl = nl.NodeDescendants(qo.Descendants, depth)
+	}
+	if qo.PurlType != "" {
+		nl = nl.GetNodesByPurlType(qo.PurlType)
+	}
+
+	nodes := nl.GetNodes()
+	if qo.Roots {
+		nodes = intersectNodes(nodes, nl.GetRootNodes())
+	}
+	if qo.ID != "" {
+		nodes = intersectNodes(nodes, []*sbom.Node{nl.GetNodeByID(qo.ID)})
+	}
+	if qo.Name != "" {
+		nodes = intersectNodes(nodes, nl.GetNodesByName(qo.Name))
+	}
+	if qo.Purl != "" {
+		nodes = intersectNodes(nodes, nl.GetNodesByIdentifier("purl", qo.Purl))
+	}
+	if qo.CPE != "" {
+		matches, err := nl.GetNodesByCPE(qo.CPE)
+		if err != nil {
+			return nil, err
+		}
+		nodes = intersectNodes(nodes, matches)
+	}
+	for _, id := range qo.Identifiers {
+		t, v, ok := strings.Cut(id, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid identifier %q, must be type=value", id)
+		}
+		if sbom.SoftwareIdentifierTypeFromString(t) == sbom.SoftwareIdentifierType_UNKNOWN_IDENTIFIER_TYPE {
+			return nil, fmt.Errorf("unknown identifier type %q", t)
+		}
+		nodes = intersectNodes(nodes, nl.GetNodesByIdentifier(t, v))
+	}
+	return nodes, nil
+}
+
+// intersectNodes returns the nodes in a that are also in b, keeping the
+// order of a.
+func intersectNodes(a, b []*sbom.Node) []*sbom.Node {
+	ids := map[string]struct{}{}
+	for _, n := range b {
+		if n != nil {
+			ids[n.GetId()] = struct{}{}
+		}
+	}
+
+	ret := []*sbom.Node{}
+	for _, n := range a {
+		if _, ok := ids[n.GetId()]; ok {
+			ret = append(ret, n)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/protobom/protobom/pkg/service"
+	"github.com/protobom/protobom/pkg/storage"
+)
+
+func newServeCommand() *cobra.Command {
+	bo := &backendOptions{}
+	var grpcAddr, httpAddr string
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Run the conversion service",
+		Long: `Serve runs the protobom conversion service over gRPC and, unless
+--http-addr is empty, its HTTP/JSON gateway. Documents are stored in the
+configured backend. Without --path the Store and Retrieve methods are
+disabled.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) (err error) {
+			var backend storage.Backend
+			closeFn := func() error { return nil }
+			if bo.Path != "" {
+				if backend, closeFn, err = bo.open(); err != nil {
+					return err
+				}
+			}
+			defer func() {
+				err = errors.Join(err, closeFn())
+			}()
+
+			ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+
+			return serve(ctx, service.NewServer(backend), grpcAddr, httpAddr)
+		},
+	}
+
+	bo.addFlags(cmd)
+	cmd.Flags().StringVar(&grpcAddr, "grpc-addr", ":9090", "address to serve gRPC on")
+	cmd.Flags().StringVar(&httpAddr, "http-addr", ":8080", "address to serve the HTTP/JSON gateway on (empty to disable)")
+	return cmd
+}
+
+// serve runs the gRPC server and the gateway until ctx is canceled
+func serve(ctx context.Context, srv *service.Server, grpcAddr, httpAddr string) error {
+	lis, err := (&net.ListenConfig{}).Listen(ctx, "tcp", grpcAddr)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", grpcAddr, err)
+	}
+
+	gs := grpc.NewServer()
+	srv.Register(gs)
+
+	errs := make(chan error, 2)
+	go func() {
+		logrus.Infof("serving gRPC on %s", lis.Addr())
+		errs <- gs.Serve(lis)
+	}()
+	defer gs.GracefulStop()
+
+	if httpAddr != "" {
+		conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return fmt.Errorf("connecting gateway to service: %w", err)
+		}
+		defer conn.Close() //nolint:errcheck
+
+		mux, err := service.NewGateway(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		hs := &http.Server{Addr: httpAddr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+		go func() {
+			logrus.Infof("serving HTTP gateway on %s", httpAddr)
+			if err := hs.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+				errs <- err
+			}
+		}()
+		defer hs.Shutdown(context.Background()) //nolint:errcheck,contextcheck
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case err := <-errs:
+		return err
+	}
+}
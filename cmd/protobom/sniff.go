@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/protobom/protobom/pkg/compression"
+	"github.com/protobom/protobom/pkg/formats"
+)
+
+func newSniffCommand() *cobra.Command {
+	var verbose bool
+
+	cmd := &cobra.Command{
+		Use:   "sniff FILE...",
+		Short: "Detect the format of SBOM files",
+		Long: `Sniff prints the format detected in each file and the confidence of the
+detection. Files whose format cannot be determined are reported as
+unknown. Compressed files are decompressed before sniffing. With --verbose
+all the candidate formats considered are listed.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := cmd.OutOrStdout()
+			for _, path := range args {
+				detection, err := sniffFile(path)
+				var unknownErr *formats.UnknownFormatError
+				if errors.As(err, &unknownErr) {
+					detection = &formats.Detection{
+						Format:     "unknown",
+						Confidence: formats.ConfidenceNone,
+						Candidates: unknownErr.Candidates,
+					}
+				} else if err != nil {
+					return fmt.Errorf("sniffing %s: %w", path, err)
+				}
+
+				fmt.Fprintf(out, "%s\t%s\t%s\n", path, detection.Format, detection.Confidence)
+				if !verbose {
+					continue
+				}
+				for _, c := range detection.Candidates {
+					fmt.Fprintf(out, "  %s\t%s\t%s\n", c.Format, c.Confidence, c.Reason)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "list all the candidate formats")
+	return cmd
+}
+
+// sniffFile detects the format of the (possibly compressed) file at path
+func sniffFile(path string) (*formats.Detection, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() //nolint:errcheck
+
+	c, err := compression.DetectReader(f)
+	if err != nil {
+		return nil, err
+	}
+
+	var rs io.ReadSeeker = f
+	if c != compression.None {
+		zr, err := compression.NewReader(c, f)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close() //nolint:errcheck
+		data, err := io.ReadAll(zr)
+		if err != nil {
+			return nil, fmt.Errorf("decompressing: %w", err)
+		}
+		rs = bytes.NewReader(data)
+	}
+
+	return (&formats.Sniffer{}).Detect(rs)
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	_ "modernc.org/sqlite" // SQLite driver for the sql backend
+
+	"github.com/protobom/protobom/pkg/storage"
+)
+
+const (
+	backendFileSystem = "filesystem"
+	backendContent    = "content"
+	backendOCI        = "oci"
+	backendSQLite     = "sqlite"
+)
+
+// backendOptions are the flags that configure the storage backend
+type backendOptions struct {
+	Backend   string
+	Path      string
+	Revisions bool
+}
+
+func (bo *backendOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&bo.Backend, "backend", "b", backendFileSystem,
+		fmt.Sprintf("storage backend: %s, %s (content addressed), %s (image layout) or %s",
+			backendFileSystem, backendContent, backendOCI, backendSQLite))
+	cmd.Flags().StringVarP(&bo.Path, "path", "p", "", "directory of the storage (database file for sqlite)")
+	cmd.Flags().BoolVar(&bo.Revisions, "revisions", false, "keep a revision history (filesystem backend)")
+}
+
+// open returns the configured backend and a function to release it
+func (bo *backendOptions) open() (storage.Backend, func() error, error) {
+	noop := func() error { return nil }
+	switch bo.Backend {
+	case backendFileSystem:
+		fs := storage.NewFileSystem()
+		fs.Options.Path = bo.Path
+		fs.Options.Revisions = bo.Revisions
+		return fs, noop, nil
+	case backendContent:
+		ca := storage.NewContentAddressed()
+		ca.Options.Path = bo.Path
+		return ca, noop, nil
+	case backendOCI:
+		o := storage.NewOCILayout()
+		o.Options.Path = bo.Path
+		return o, noop, nil
+	case backendSQLite:
+		db, err := sql.Open("sqlite", bo.Path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("opening database: %w", err)
+		}
+		s := storage.NewSQL(db)
+		if err := s.Init(); err != nil {
+			db.Close() //nolint:errcheck,gosec
+			return nil, nil, fmt.Errorf("initializing database: %w", err)
+		}
+		return s, db.Close, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown storage backend %q", bo.Backend)
+	}
+}
+
+func newStoreCommand() *cobra.Command {
+	bo := &backendOptions{}
+	var inputMods []string
+
+	cmd := &cobra.Command{
+		Use:   "store FILE...",
+		Short: "Store SBOMs in a storage backend",
+		Long: `Store reads SBOMs and saves them in the configured storage backend. For
+each document, it prints the reference to retrieve it: the document ID or,
+in the content addressed and OCI backends, the digest of the stored data.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			ro, err := readOptions(inputMods)
+			if err != nil {
+				return err
+			}
+
+			backend, closeFn, err := bo.open()
+			if err != nil {
+				return err
+			}
+			defer func() {
+				err = errors.Join(err, closeFn())
+			}()
+
+			for _, path := range args {
+				doc, err := readDocument(path, ro, cmd.InOrStdin())
+				if err != nil {
+					return fmt.Errorf("reading %s: %w", path, err)
+				}
+
+				ref := doc.GetMetadata().GetId()
+				switch b := backend.(type) {
+				case *storage.ContentAddressed:
+					ref, err = b.StoreDigest(doc, &storage.StoreOptions{})
+				case *storage.OCILayout:
+					desc, derr := b.StoreDescriptor(doc, &storage.StoreOptions{})
+					ref, err = desc.Digest.String(), derr
+				default:
+					err = b.Store(doc, &storage.StoreOptions{})
+				}
+				if err != nil {
+					return fmt.Errorf("storing %s: %w", path, err)
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\n", path, ref)
+			}
+			return nil
+		},
+	}
+
+	bo.addFlags(cmd)
+	_ = cmd.MarkFlagRequired("path") //nolint:errcheck
+	cmd.Flags().StringSliceVar(&inputMods, "input-mod", nil, "mod to enable when reading the documents (repeatable)")
+	return cmd
+}
+
+func newRetrieveCommand() *cobra.Command {
+	bo := &backendOptions{}
+	oo := &outputOptions{}
+	var revision int
+
+	cmd := &cobra.Command{
+		Use:   "retrieve REF",
+		Short: "Retrieve an SBOM from a storage backend",
+		Long: `Retrieve reads a document from the configured storage backend using the
+reference printed by store. The document is rendered in the format set with
+--format or, when not set, printed as protobom JSON.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			backend, closeFn, err := bo.open()
+			if err != nil {
+				return err
+			}
+			defer func() {
+				err = errors.Join(err, closeFn())
+			}()
+
+			opts := &storage.RetrieveOptions{}
+			if cmd.Flags().Changed("revision") {
+				opts.Revision = storage.RevisionByIndex(revision)
+			}
+
+			doc, err := backend.Retrieve(args[0], opts)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %w", args[0], err)
+			}
+
+			if oo.Format != "" {
+				return oo.writeDocument(doc, cmd.OutOrStdout())
+			}
+
+			data, err := protojson.MarshalOptions{Multiline: true}.Marshal(doc)
+			if err != nil {
+				return fmt.Errorf("marshaling document: %w", err)
+			}
+			if oo.Output != "" && oo.Output != "-" {
+				return os.WriteFile(oo.Output, append(data, '\n'), 0o600)
+			}
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), string(data))
+			return err
+		},
+	}
+
+	bo.addFlags(cmd)
+	_ = cmd.MarkFlagRequired("path") //nolint:errcheck
+	oo.addFlags(cmd)
+	cmd.Flags().IntVar(&revision, "revision", 0, "revision of the document to retrieve (filesystem backend with --revisions)")
+	return cmd
+}
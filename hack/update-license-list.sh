@@ -0,0 +1,20 @@
+#!/usr/bin/env bash
+
+set -euo pipefail
+
+# Refreshes the copy of the SPDX license list embedded in pkg/license with
+# the data published in https://github.com/spdx/license-list-data. Only the
+# fields used by the license package are kept.
+
+LICENSE_LIST_VER="${1:-main}"
+BASE_URL="https://raw.githubusercontent.com/spdx/license-list-data/${LICENSE_LIST_VER}/json"
+
+curl --fail --silent --show-error --location "${BASE_URL}/licenses.json" | jq '{
+    licenseListVersion,
+    licenses: [.licenses[] | {licenseId, name, isDeprecatedLicenseId, isOsiApproved}] | sort_by(.licenseId)
+}' > pkg/license/licenses.json
+
+curl --fail --silent --show-error --location "${BASE_URL}/exceptions.json" | jq '{
+    licenseListVersion,
+    exceptions: [.exceptions[] | {licenseExceptionId, isDeprecatedLicenseId}] | sort_by(.licenseExceptionId | ascii_downcase)
+}' > pkg/license/exceptions.json
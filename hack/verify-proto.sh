@@ -7,10 +7,16 @@ source hack/common.sh
 # These are pinned to the versions used in the latest generated code
 export PROTOC_GO_VER="v1.36.7"
 export BUF_VERSION="v1.56.0"
+export PROTOC_GO_GRPC_VER="v1.6.2"
+export GRPC_GATEWAY_VER="v2.30.0"
 
 # Install protoc-gen-go plugin
 GOBIN="${PWD}/.bin" go install google.golang.org/protobuf/cmd/protoc-gen-go@${PROTOC_GO_VER}
 
+# Install the gRPC and gateway plugins used for the service definition
+GOBIN="${PWD}/.bin" go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@${PROTOC_GO_GRPC_VER}
+GOBIN="${PWD}/.bin" go install github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-grpc-gateway@${GRPC_GATEWAY_VER}
+
 # Install Buf CLI
 mkdir -p .bin
 curl --fail --silent --show-error --location \
@@ -30,5 +36,5 @@ export PATH=${PWD}/.bin:${PATH}
 
 VERSION=$tag_name make buf-format buf-lint proto
 
-git diff --exit-code -- **/{*.pb,value_scanner}.go **/*.proto ||
+git diff --exit-code -- **/{*.pb,*.pb.gw,value_scanner}.go **/*.proto ||
   exit_with_msg "The protobuf definitions are not up to date. Check the docs and run make proto"
@@ -0,0 +1,149 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2026 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+// Package compression implements the detection, decompression and
+// compression of SBOM documents shipped in compressed files.
+package compression
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression identifies a compression algorithm
+type Compression string
+
+const (
+	// None means the data is not compressed
+	None Compression = ""
+
+	// Gzip is the gzip compression format (RFC 1952)
+	Gzip Compression = "gzip"
+
+	// Zstd is the Zstandard compression format (RFC 8878)
+	Zstd Compression = "zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// MagicLength is the number of bytes needed to detect the compression
+const MagicLength = 4
+
+// Extension returns the filename extension of the compression format,
+// including the leading dot.
+func (c Compression) Extension() string {
+	switch c {
+	case Gzip:
+		return ".gz"
+	case Zstd:
+		return ".zst"
+	default:
+		return ""
+	}
+}
+
+// Detect returns the compression of data by looking at its first bytes
+func Detect(header []byte) Compression {
+	switch {
+	case bytes.HasPrefix(header, gzipMagic):
+		return Gzip
+	case bytes.HasPrefix(header, zstdMagic):
+		return Zstd
+	default:
+		return None
+	}
+}
+
+// DetectReader peeks at the beginning of a stream to detect its compression.
+// The stream is rewound to where it was before returning.
+func DetectReader(rs io.ReadSeeker) (Compression, error) {
+	pos, err := rs.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return None, fmt.Errorf("getting stream position: %w", err)
+	}
+
+	header := make([]byte, MagicLength)
+	n, err := io.ReadFull(rs, header)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF { //nolint:errorlint // io.ReadFull returns the errors unwrapped
+		return None, fmt.Errorf("reading stream header: %w", err)
+	}
+
+	if _, err := rs.Seek(pos, io.SeekStart); err != nil {
+		return None, fmt.Errorf("rewinding stream: %w", err)
+	}
+
+	return Detect(header[:n]), nil
+}
+
+// FromFilename returns the compression suggested by the extension of path
+func FromFilename(path string) Compression {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".gz", ".gzip":
+		return Gzip
+	case ".zst", ".zstd":
+		return Zstd
+	default:
+		return None
+	}
+}
+
+// NewReader returns a reader that decompresses the data read from r. If the
+// compression is None, the returned reader reads r unmodified.
+func NewReader(c Compression, r io.Reader) (io.ReadCloser, error) {
+	switch c {
+	case None:
+		return io.NopCloser(r), nil
+	case Gzip:
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("opening gzip stream: %w", err)
+		}
+		return gz, nil
+	case Zstd:
+		zr, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("opening zstd stream: %w", err)
+		}
+		return zr.IOReadCloser(), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression %q", c)
+	}
+}
+
+// NewWriter returns a writer that compresses the data written to it before
+// passing it to w. The returned writer must be closed to flush the data. If
+// the compression is None, the data is written to w unmodified.
+func NewWriter(c Compression, w io.Writer) (io.WriteCloser, error) {
+	switch c {
+	case None:
+		return nopWriteCloser{w}, nil
+	case Gzip:
+		return gzip.NewWriter(w), nil
+	case Zstd:
+		zw, err := zstd.NewWriter(w)
+		if err != nil {
+			return nil, fmt.Errorf("creating zstd writer: %w", err)
+		}
+		return zw, nil
+	default:
+		return nil, fmt.Errorf("unsupported compression %q", c)
+	}
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
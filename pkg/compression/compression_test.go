@@ -0,0 +1,54 @@
+package compression
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+	data := []byte(`{"bomFormat": "CycloneDX", "specVersion": "1.5"}`)
+	for _, c := range []Compression{None, Gzip, Zstd} {
+		t.Run(string(c), func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			w, err := NewWriter(c, &buf)
+			require.NoError(t, err)
+			_, err = w.Write(data)
+			require.NoError(t, err)
+			require.NoError(t, w.Close())
+
+			rs := bytes.NewReader(buf.Bytes())
+			detected, err := DetectReader(rs)
+			require.NoError(t, err)
+			require.Equal(t, c, detected)
+
+			r, err := NewReader(detected, rs)
+			require.NoError(t, err)
+			got, err := io.ReadAll(r)
+			require.NoError(t, err)
+			require.NoError(t, r.Close())
+			require.Equal(t, data, got)
+		})
+	}
+}
+
+func TestDetect(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, None, Detect([]byte{}))
+	require.Equal(t, None, Detect([]byte{0x1f}))
+	require.Equal(t, Gzip, Detect([]byte{0x1f, 0x8b, 0x08}))
+	require.Equal(t, Zstd, Detect([]byte{0x28, 0xb5, 0x2f, 0xfd, 0x00}))
+	require.Equal(t, None, Detect([]byte("SPDXVersion: SPDX-2.3")))
+}
+
+func TestFromFilename(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, Gzip, FromFilename("sbom.spdx.json.gz"))
+	require.Equal(t, Zstd, FromFilename("sbom.cdx.json.ZST"))
+	require.Equal(t, None, FromFilename("sbom.json"))
+	require.Equal(t, ".zst", Zstd.Extension())
+}
@@ -0,0 +1,146 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2026 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+// Package dsse signs documents and verifies their signatures using Dead
+// Simple Signing Envelopes (DSSE). See https://github.com/secure-systems-lab/dsse
+// for the envelope specification.
+package dsse
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// PayloadTypeInToto is the payload type of in-toto statements
+const PayloadTypeInToto = "application/vnd.in-toto+json"
+
+var (
+	// ErrNoSignatures is returned when an envelope has no signatures
+	ErrNoSignatures = errors.New("envelope has no signatures")
+
+	// ErrUnverified is returned when none of the signatures of an envelope
+	// can be verified with the verifiers passed.
+	ErrUnverified = errors.New("no signature could be verified")
+)
+
+// Envelope is a DSSE envelope
+type Envelope struct {
+	PayloadType string      `json:"payloadType"`
+	Payload     []byte      `json:"payload"`
+	Signatures  []Signature `json:"signatures"`
+}
+
+// Signature is a signature of the envelope payload
+type Signature struct {
+	KeyID string `json:"keyid,omitempty"`
+	Sig   []byte `json:"sig"`
+}
+
+// Acceptance describes a verified signature of an envelope
+type Acceptance struct {
+	// KeyID is the ID of the key that verified the signature
+	KeyID string
+
+	// Identity is the identity of the signer, as defined by the verifier
+	Identity string
+}
+
+// PAE returns the pre-authentication encoding of the payload, which is the
+// message signed.
+func PAE(payloadType string, payload []byte) []byte {
+	return fmt.Appendf(nil, "DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload)
+}
+
+// Sign returns an envelope with the payload signed by all the signers
+func Sign(payloadType string, payload []byte, signers ...Signer) (*Envelope, error) {
+	if len(signers) == 0 {
+		return nil, errors.New("no signers specified")
+	}
+
+	env := &Envelope{
+		PayloadType: payloadType,
+		Payload:     payload,
+		Signatures:  []Signature{},
+	}
+	message := PAE(payloadType, payload)
+	for _, s := range signers {
+		sig, err := s.Sign(message)
+		if err != nil {
+			return nil, fmt.Errorf("signing payload: %w", err)
+		}
+		env.Signatures = append(env.Signatures, Signature{KeyID: s.KeyID(), Sig: sig})
+	}
+	return env, nil
+}
+
+// Verify checks the signatures of the envelope and returns the ones
+// verified by any of the verifiers. If none can be verified, it returns
+// an error wrapping ErrUnverified.
+func (e *Envelope) Verify(verifiers ...Verifier) ([]Acceptance, error) {
+	if len(e.Signatures) == 0 {
+		return nil, ErrNoSignatures
+	}
+
+	message := PAE(e.PayloadType, e.Payload)
+	accepted := []Acceptance{}
+	for _, sig := range e.Signatures {
+		for _, v := range verifiers {
+			// Skip verifiers for other keys when the key is identified
+			if sig.KeyID != "" && sig.KeyID != v.KeyID() {
+				continue
+			}
+			if err := v.Verify(message, sig.Sig); err != nil {
+				continue
+			}
+			accepted = append(accepted, Acceptance{KeyID: v.KeyID(), Identity: v.Identity()})
+			break
+		}
+	}
+
+	if len(accepted) == 0 {
+		return nil, fmt.Errorf("verifying %d signatures with %d verifiers: %w", len(e.Signatures), len(verifiers), ErrUnverified)
+	}
+	return accepted, nil
+}
+
+// IsEnvelope returns true if the JSON document read from r is a DSSE
+// envelope. The document is read until its payloadType field is found.
+func IsEnvelope(r io.Reader) bool {
+	dec := json.NewDecoder(r)
+	if t, err := dec.Token(); err != nil || t != json.Delim('{') {
+		return false
+	}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return false
+		}
+		if key != "payloadType" {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return false
+			}
+			continue
+		}
+		var value string
+		return dec.Decode(&value) == nil && value != ""
+	}
+	return false
+}
+
+// ParseEnvelope reads a DSSE envelope
+func ParseEnvelope(data []byte) (*Envelope, error) {
+	env := &Envelope{}
+	if err := json.Unmarshal(data, env); err != nil {
+		return nil, fmt.Errorf("decoding DSSE envelope: %w", err)
+	}
+	if env.PayloadType == "" {
+		return nil, errors.New("envelope has no payload type")
+	}
+	return env, nil
+}
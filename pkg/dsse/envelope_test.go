@@ -0,0 +1,148 @@
+package dsse
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestKeys(t *testing.T) map[string]crypto.Signer {
+	t.Helper()
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ec384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return map[string]crypto.Signer{"ecdsa-p256": ecKey, "ecdsa-p384": ec384Key, "ed25519": edKey}
+}
+
+func TestPAE(t *testing.T) {
+	t.Parallel()
+	require.Equal(t,
+		"DSSEv1 29 http://example.com/HelloWorld 11 hello world",
+		string(PAE("http://example.com/HelloWorld", []byte("hello world"))),
+	)
+}
+
+func TestSignVerify(t *testing.T) {
+	t.Parallel()
+	payload := []byte(`{"hello":"world"}`)
+	keys := newTestKeys(t)
+	for name, key := range keys {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			signer, err := NewSigner(key)
+			require.NoError(t, err)
+			verifier, err := NewVerifier(signer.Public())
+			require.NoError(t, err)
+			verifier.Name = "builder@example.com"
+			require.Equal(t, signer.KeyID(), verifier.KeyID())
+
+			env, err := Sign(PayloadTypeInToto, payload, signer)
+			require.NoError(t, err)
+			require.Len(t, env.Signatures, 1)
+			require.Equal(t, signer.KeyID(), env.Signatures[0].KeyID)
+
+			// The envelope survives a JSON round trip
+			data, err := json.Marshal(env)
+			require.NoError(t, err)
+			require.True(t, IsEnvelope(bytes.NewReader(data)))
+			parsed, err := ParseEnvelope(data)
+			require.NoError(t, err)
+			require.Equal(t, payload, parsed.Payload)
+
+			accepted, err := parsed.Verify(verifier)
+			require.NoError(t, err)
+			require.Equal(t, []Acceptance{{KeyID: signer.KeyID(), Identity: "builder@example.com"}}, accepted)
+
+			// Tampered payloads are rejected
+			parsed.Payload = []byte(`{"hello":"mars"}`)
+			_, err = parsed.Verify(verifier)
+			require.ErrorIs(t, err, ErrUnverified)
+		})
+	}
+}
+
+func TestVerifyWrongKey(t *testing.T) {
+	t.Parallel()
+	keys := newTestKeys(t)
+	signer, err := NewSigner(keys["ecdsa-p256"])
+	require.NoError(t, err)
+	other, err := NewVerifier(keys["ed25519"].Public())
+	require.NoError(t, err)
+
+	env, err := Sign("text/plain", []byte("data"), signer)
+	require.NoError(t, err)
+	_, err = env.Verify(other)
+	require.ErrorIs(t, err, ErrUnverified)
+
+	_, err = (&Envelope{PayloadType: "text/plain"}).Verify(other)
+	require.ErrorIs(t, err, ErrNoSignatures)
+}
+
+func TestLoadKeys(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	for name, key := range newTestKeys(t) {
+		priv, err := x509.MarshalPKCS8PrivateKey(key)
+		require.NoError(t, err)
+		pub, err := x509.MarshalPKIXPublicKey(key.Public())
+		require.NoError(t, err)
+
+		privPath := filepath.Join(dir, name+".key")
+		pubPath := filepath.Join(dir, name+".pub")
+		require.NoError(t, os.WriteFile(privPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: priv}), 0o600))
+		require.NoError(t, os.WriteFile(pubPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pub}), 0o600))
+
+		signer, err := LoadSigner(privPath)
+		require.NoError(t, err, name)
+		verifier, err := LoadVerifier(pubPath)
+		require.NoError(t, err, name)
+		require.Equal(t, signer.KeyID(), verifier.KeyID(), name)
+		require.Equal(t, verifier.KeyID(), verifier.Identity(), name)
+
+		// Public keys are not signers
+		_, err = LoadSigner(pubPath)
+		require.Error(t, err, name)
+	}
+
+	// SEC 1 encoded EC keys
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(ecKey)
+	require.NoError(t, err)
+	path := filepath.Join(dir, "sec1.key")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0o600))
+	_, err = LoadSigner(path)
+	require.NoError(t, err)
+
+	_, err = LoadVerifier(filepath.Join(dir, "missing.pub"))
+	require.Error(t, err)
+}
+
+func TestIsEnvelope(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		data     string
+		expected bool
+	}{
+		{`{"payloadType":"text/plain","payload":"","signatures":[]}`, true},
+		{`{"_type":"https://in-toto.io/Statement/v1"}`, false},
+		{`{"spdxVersion":"SPDX-2.3"}`, false},
+		{`not json`, false},
+	} {
+		require.Equal(t, tc.expected, IsEnvelope(bytes.NewReader([]byte(tc.data))), tc.data)
+	}
+}
@@ -0,0 +1,211 @@
+package dsse
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+	"os"
+)
+
+// Signer signs the pre-authentication encoding of envelopes. It can be
+// implemented to sign with keys held in-process or elsewhere.
+type Signer interface {
+	// KeyID returns the ID of the signing key, recorded in the envelope
+	KeyID() string
+	Sign(message []byte) ([]byte, error)
+}
+
+// Verifier checks the signatures of envelopes
+type Verifier interface {
+	// KeyID returns the ID of the key used to verify signatures
+	KeyID() string
+
+	// Identity returns the name of the signer recorded when a signature
+	// is verified.
+	Identity() string
+	Verify(message, sig []byte) error
+}
+
+// KeySigner signs messages with a local ECDSA or Ed25519 private key
+type KeySigner struct {
+	key   crypto.Signer
+	keyID string
+}
+
+// KeyVerifier verifies signatures with a local ECDSA or Ed25519 public key
+type KeyVerifier struct {
+	// Name is the identity of the signer. If empty, the key ID is used.
+	Name  string
+	key   crypto.PublicKey
+	keyID string
+}
+
+// KeyID computes the ID of a public key as the hex encoded SHA-256 digest
+// of its DER encoded PKIX form.
+func KeyID(pub crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return "", fmt.Errorf("encoding public key: %w", err)
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// NewSigner returns a signer using key, which must be an ECDSA or an
+// Ed25519 private key.
+func NewSigner(key crypto.Signer) (*KeySigner, error) {
+	switch key.(type) {
+	case *ecdsa.PrivateKey, ed25519.PrivateKey:
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", key)
+	}
+	keyID, err := KeyID(key.Public())
+	if err != nil {
+		return nil, err
+	}
+	return &KeySigner{key: key, keyID: keyID}, nil
+}
+
+// LoadSigner reads a PEM encoded private key (PKCS #8 or SEC 1) from path
+func LoadSigner(path string) (*KeySigner, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found in key file")
+	}
+
+	var key any
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing private key: %w", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported key type %T", key)
+	}
+	return NewSigner(signer)
+}
+
+// KeyID returns the ID of the signing key
+func (s *KeySigner) KeyID() string {
+	return s.keyID
+}
+
+// Public returns the public key of the signer
+func (s *KeySigner) Public() crypto.PublicKey {
+	return s.key.Public()
+}
+
+// Sign signs message. ECDSA signatures are ASN.1 encoded.
+func (s *KeySigner) Sign(message []byte) ([]byte, error) {
+	switch k := s.key.(type) {
+	case ed25519.PrivateKey:
+		return ed25519.Sign(k, message), nil
+	case *ecdsa.PrivateKey:
+		return ecdsa.SignASN1(rand.Reader, k, digest(k.Curve, message))
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", s.key)
+	}
+}
+
+// NewVerifier returns a verifier using key, which must be an ECDSA or an
+// Ed25519 public key.
+func NewVerifier(key crypto.PublicKey) (*KeyVerifier, error) {
+	switch key.(type) {
+	case *ecdsa.PublicKey, ed25519.PublicKey:
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", key)
+	}
+	keyID, err := KeyID(key)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyVerifier{key: key, keyID: keyID}, nil
+}
+
+// LoadVerifier reads a PEM encoded PKIX public key from path
+func LoadVerifier(path string) (*KeyVerifier, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found in key file")
+	}
+	if block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing public key: %w", err)
+	}
+	return NewVerifier(key)
+}
+
+// KeyID returns the ID of the verification key
+func (v *KeyVerifier) KeyID() string {
+	return v.keyID
+}
+
+// Identity returns the name of the verifier or, if not set, its key ID
+func (v *KeyVerifier) Identity() string {
+	if v.Name != "" {
+		return v.Name
+	}
+	return v.keyID
+}
+
+// Verify checks the signature of message
+func (v *KeyVerifier) Verify(message, sig []byte) error {
+	switch k := v.key.(type) {
+	case ed25519.PublicKey:
+		if !ed25519.Verify(k, message, sig) {
+			return errors.New("invalid ed25519 signature")
+		}
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(k, digest(k.Curve, message), sig) {
+			return errors.New("invalid ECDSA signature")
+		}
+	default:
+		return fmt.Errorf("unsupported key type %T", v.key)
+	}
+	return nil
+}
+
+// digest hashes message with the hash function matching the curve size
+func digest(curve elliptic.Curve, message []byte) []byte {
+	var h hash.Hash
+	switch curve.Params().BitSize {
+	case 384:
+		h = sha512.New384()
+	case 521:
+		h = sha512.New()
+	default:
+		h = sha256.New()
+	}
+	h.Write(message)
+	return h.Sum(nil)
+}
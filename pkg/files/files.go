@@ -0,0 +1,172 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2026 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+// Package files computes the hashes and gitoids of the file nodes of an SBOM
+// from local content and verifies the file nodes of a document against a
+// directory tree to detect drift between the SBOM and the filesystem.
+package files
+
+import (
+	"crypto/md5"  //nolint:gosec // MD5 hashes are still found in SBOMs
+	"crypto/sha1" //nolint:gosec // SHA1 is required in SPDX2
+	"crypto/sha256"
+	"crypto/sha3"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+
+	"github.com/protobom/protobom/pkg/gitoid"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// DefaultHashAlgorithms are the hashes computed for file nodes when the
+// options don't list any.
+var DefaultHashAlgorithms = []sbom.HashAlgorithm{
+	sbom.HashAlgorithm_SHA1,
+	sbom.HashAlgorithm_SHA256,
+	sbom.HashAlgorithm_SHA512,
+}
+
+// hashFuncs are the hash algorithms supported to compute and verify hashes
+var hashFuncs = map[sbom.HashAlgorithm]func() hash.Hash{
+	sbom.HashAlgorithm_MD5:    md5.New,
+	sbom.HashAlgorithm_SHA1:   sha1.New,
+	sbom.HashAlgorithm_SHA224: sha256.New224,
+	sbom.HashAlgorithm_SHA256: sha256.New,
+	sbom.HashAlgorithm_SHA384: sha512.New384,
+	sbom.HashAlgorithm_SHA512: sha512.New,
+	sbom.HashAlgorithm_SHA3_256: func() hash.Hash {
+		return sha3.New256()
+	},
+	sbom.HashAlgorithm_SHA3_384: func() hash.Hash {
+		return sha3.New384()
+	},
+	sbom.HashAlgorithm_SHA3_512: func() hash.Hash {
+		return sha3.New512()
+	},
+}
+
+// HashOptions control the digests computed for files
+type HashOptions struct {
+	// Algorithms are the hashes to compute. Defaults to DefaultHashAlgorithms.
+	Algorithms []sbom.HashAlgorithm
+
+	// GitoidHashType is the hash of the gitoid identifier. Defaults to sha1,
+	// which matches the blob IDs of most git repositories.
+	GitoidHashType gitoid.HashType
+}
+
+// Digests are the hashes and the gitoid computed from a file
+type Digests struct {
+	// Hashes are the hex encoded hashes keyed by their sbom.HashAlgorithm
+	Hashes map[int32]string
+
+	// Gitoid is the gitoid URI of the file content
+	Gitoid string
+}
+
+// Supported returns true if the hash algorithm can be computed
+func Supported(algo sbom.HashAlgorithm) bool {
+	_, ok := hashFuncs[algo]
+	return ok
+}
+
+// HashFile computes the digests of the file at path
+func HashFile(path string, opts *HashOptions) (*Digests, error) {
+	if opts == nil {
+		opts = &HashOptions{}
+	}
+	algos := opts.Algorithms
+	if len(algos) == 0 {
+		algos = DefaultHashAlgorithms
+	}
+	gitoidType := opts.GitoidHashType
+	if gitoidType == "" {
+		gitoidType = gitoid.SHA1
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening file: %w", err)
+	}
+	defer f.Close() //nolint:errcheck
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("reading file info: %w", err)
+	}
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("%s is not a regular file", path)
+	}
+	return digest(f, info.Size(), algos, gitoidType)
+}
+
+// digest computes in a single pass the hashes and, if gitoidType is set, the
+// gitoid of the size bytes read from r.
+func digest(r io.Reader, size int64, algos []sbom.HashAlgorithm, gitoidType gitoid.HashType) (*Digests, error) {
+	hashers := map[int32]hash.Hash{}
+	writers := []io.Writer{}
+	for _, algo := range algos {
+		newHash, ok := hashFuncs[algo]
+		if !ok {
+			return nil, fmt.Errorf("unsupported hash algorithm %s", algo)
+		}
+		hashers[int32(algo)] = newHash()
+		writers = append(writers, hashers[int32(algo)])
+	}
+
+	var gh hash.Hash
+	if gitoidType != "" {
+		var err error
+		gh, err = gitoid.New(gitoidType, size)
+		if err != nil {
+			return nil, err
+		}
+		writers = append(writers, gh)
+	}
+
+	n, err := io.Copy(io.MultiWriter(writers...), r)
+	if err != nil {
+		return nil, fmt.Errorf("reading file: %w", err)
+	}
+	if n != size {
+		return nil, fmt.Errorf("file changed while reading, read %d bytes, expected %d", n, size)
+	}
+
+	d := &Digests{Hashes: map[int32]string{}}
+	for algo, h := range hashers {
+		d.Hashes[algo] = hex.EncodeToString(h.Sum(nil))
+	}
+	if gh != nil {
+		d.Gitoid = gitoid.Format(gitoidType, gh.Sum(nil))
+	}
+	return d, nil
+}
+
+// HashNode computes the digests of the file at path and records them in
+// the node: the hashes are added to (or replace those in) its Hashes and the
+// gitoid is set as its GITOID identifier. The node must be of type FILE.
+func HashNode(n *sbom.Node, path string, opts *HashOptions) error {
+	if n.GetType() != sbom.Node_FILE {
+		return fmt.Errorf("node %s is not a file", n.GetId())
+	}
+	d, err := HashFile(path, opts)
+	if err != nil {
+		return err
+	}
+
+	for algo, value := range d.Hashes {
+		n.AddHash(sbom.HashAlgorithm(algo), value)
+	}
+	if n.Identifiers == nil {
+		n.Identifiers = map[int32]string{}
+	}
+	n.Identifiers[int32(sbom.SoftwareIdentifierType_GITOID)] = d.Gitoid
+	return nil
+}
@@ -0,0 +1,131 @@
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/gitoid"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+const (
+	helloSHA1   = "2aae6c35c94fcfb415dbe95f408b9ce91ee846ed"
+	helloSHA256 = "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"
+	helloGitoid = "gitoid:blob:sha1:95d09f2b10159347eece71399a7e2e907ea3df4f"
+)
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	p := filepath.Join(dir, filepath.FromSlash(name))
+	require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+	require.NoError(t, os.WriteFile(p, []byte(content), 0o600))
+}
+
+func TestHashFile(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	writeFile(t, dir, "hello.txt", "hello world")
+
+	d, err := HashFile(filepath.Join(dir, "hello.txt"), nil)
+	require.NoError(t, err)
+	require.Len(t, d.Hashes, 3)
+	require.Equal(t, helloSHA1, d.Hashes[int32(sbom.HashAlgorithm_SHA1)])
+	require.Equal(t, helloSHA256, d.Hashes[int32(sbom.HashAlgorithm_SHA256)])
+	require.Equal(t, helloGitoid, d.Gitoid)
+
+	d, err = HashFile(filepath.Join(dir, "hello.txt"), &HashOptions{
+		Algorithms:     []sbom.HashAlgorithm{sbom.HashAlgorithm_MD5},
+		GitoidHashType: gitoid.SHA256,
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[int32]string{int32(sbom.HashAlgorithm_MD5): "5eb63bbbe01eeed093cb22bb8f5acdc3"}, d.Hashes)
+	require.Equal(t, "gitoid:blob:sha256:fee53a18d32820613c0527aa79be5cb30173c823a9b448fa4817767cc84c6f03", d.Gitoid)
+
+	_, err = HashFile(filepath.Join(dir, "hello.txt"), &HashOptions{Algorithms: []sbom.HashAlgorithm{sbom.HashAlgorithm_BLAKE3}})
+	require.Error(t, err)
+	_, err = HashFile(dir, nil)
+	require.Error(t, err)
+	_, err = HashFile(filepath.Join(dir, "missing"), nil)
+	require.Error(t, err)
+}
+
+func TestHashNode(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	writeFile(t, dir, "hello.txt", "hello world")
+
+	n := &sbom.Node{Id: "file", Type: sbom.Node_FILE, Name: "hello.txt"}
+	require.NoError(t, HashNode(n, filepath.Join(dir, "hello.txt"), nil))
+	require.Equal(t, helloSHA256, n.GetHashes()[int32(sbom.HashAlgorithm_SHA256)])
+	require.Equal(t, helloGitoid, n.GetIdentifiers()[int32(sbom.SoftwareIdentifierType_GITOID)])
+
+	pkg := &sbom.Node{Id: "package", Type: sbom.Node_PACKAGE}
+	require.Error(t, HashNode(pkg, filepath.Join(dir, "hello.txt"), nil))
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	writeFile(t, dir, "hello.txt", "hello world")
+	writeFile(t, dir, "src/main.go", "package main\n")
+	writeFile(t, dir, "src/extra.go", "package main\n")
+	writeFile(t, dir, "docs/README", "changed")
+	writeFile(t, dir, "LICENSE", "Apache-2.0")
+
+	hashes := func(sha256 string) map[int32]string {
+		return map[int32]string{int32(sbom.HashAlgorithm_SHA256): sha256}
+	}
+
+	mainGitoid, err := gitoid.FromBytes([]byte("package main\n"), gitoid.SHA1)
+	require.NoError(t, err)
+
+	doc := sbom.NewDocument()
+	doc.NodeList.AddNode(&sbom.Node{Id: "hello", Type: sbom.Node_FILE, Name: "./hello.txt", Hashes: hashes(helloSHA256)})
+	doc.NodeList.AddNode(&sbom.Node{
+		Id: "main", Type: sbom.Node_FILE, Name: "/src/main.go",
+		Identifiers: map[int32]string{int32(sbom.SoftwareIdentifierType_GITOID): mainGitoid},
+	})
+	doc.NodeList.AddNode(&sbom.Node{Id: "readme", Type: sbom.Node_FILE, Name: "docs/README", Hashes: hashes(helloSHA256)})
+	doc.NodeList.AddNode(&sbom.Node{Id: "gone", Type: sbom.Node_FILE, Name: "gone.txt", Hashes: hashes(helloSHA256)})
+	doc.NodeList.AddNode(&sbom.Node{Id: "escape", Type: sbom.Node_FILE, Name: "../hello.txt", Hashes: hashes(helloSHA256)})
+	doc.NodeList.AddNode(&sbom.Node{Id: "license", Type: sbom.Node_FILE, Name: "LICENSE"})
+	doc.NodeList.AddNode(&sbom.Node{Id: "package", Type: sbom.Node_PACKAGE, Name: "hello.txt"})
+
+	report, err := Verify(doc, dir, &VerifyOptions{Untracked: true})
+	require.NoError(t, err)
+	require.Len(t, report.Results, 6)
+
+	status := map[string]Status{}
+	for _, res := range report.Results {
+		status[res.Node.GetId()] = res.Status
+	}
+	require.Equal(t, map[string]Status{
+		"hello":   StatusVerified,
+		"main":    StatusVerified,
+		"readme":  StatusModified,
+		"gone":    StatusMissing,
+		"escape":  StatusMissing,
+		"license": StatusUnverified,
+	}, status)
+
+	modified := report.Filter(StatusModified)
+	require.Len(t, modified, 1)
+	require.Equal(t, "docs/README", modified[0].Path)
+	require.Len(t, modified[0].Mismatches, 1)
+	require.Equal(t, sbom.HashAlgorithm_SHA256.String(), modified[0].Mismatches[0].Algorithm)
+	require.Equal(t, helloSHA256, modified[0].Mismatches[0].Expected)
+
+	require.Equal(t, []string{"src/extra.go"}, report.Untracked)
+	require.True(t, report.HasDrift())
+
+	// Without untracked files the report only covers the nodes
+	report, err = Verify(doc, dir, nil)
+	require.NoError(t, err)
+	require.Empty(t, report.Untracked)
+
+	_, err = Verify(doc, filepath.Join(dir, "missing"), nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,241 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2026 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package files
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/protobom/protobom/pkg/gitoid"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// Status is the result of verifying a file node
+type Status string
+
+const (
+	// StatusVerified files exist and match all the digests of their node
+	StatusVerified Status = "verified"
+
+	// StatusModified files exist but their content does not match the node
+	StatusModified Status = "modified"
+
+	// StatusMissing files are described in the document but not found in
+	// the directory tree.
+	StatusMissing Status = "missing"
+
+	// StatusUnverified files exist but their node has no digests that can
+	// be checked.
+	StatusUnverified Status = "unverified"
+)
+
+// gitoidAlgorithm is the name of gitoid digests in mismatches
+const gitoidAlgorithm = "gitoid"
+
+// Mismatch is a digest of a file that differs from the one in its node
+type Mismatch struct {
+	// Algorithm is the name of the hash algorithm or "gitoid"
+	Algorithm string
+
+	// Expected is the digest recorded in the node
+	Expected string
+
+	// Actual is the digest computed from the file
+	Actual string
+}
+
+// Result is the verification of a file node
+type Result struct {
+	// Node is the file node verified
+	Node *sbom.Node
+
+	// Path is the slash separated path of the file relative to the directory
+	Path string
+
+	// Status is the outcome of the verification
+	Status Status
+
+	// Mismatches lists the digests that differ when the file is modified
+	Mismatches []Mismatch
+}
+
+// Report collects the results of verifying a document against a directory
+type Report struct {
+	// Results has one entry per file node in the document
+	Results []*Result
+
+	// Untracked lists the files in the directory not described in the
+	// document. It is only populated when VerifyOptions.Untracked is set.
+	Untracked []string
+}
+
+// VerifyOptions control the verification of a document
+type VerifyOptions struct {
+	// Untracked lists the files found in the directory tree that are not
+	// described in the document.
+	Untracked bool
+}
+
+// Filter returns the results with the status s
+func (r *Report) Filter(s Status) []*Result {
+	ret := []*Result{}
+	for _, res := range r.Results {
+		if res.Status == s {
+			ret = append(ret, res)
+		}
+	}
+	return ret
+}
+
+// HasDrift returns true if files are missing, modified or untracked
+func (r *Report) HasDrift() bool {
+	for _, res := range r.Results {
+		if res.Status == StatusMissing || res.Status == StatusModified {
+			return true
+		}
+	}
+	return len(r.Untracked) > 0
+}
+
+// Verify checks the file nodes of the document against the files in dir.
+// Nodes are matched to files by their name, taken as a path relative to dir.
+// Each file is checked against all the hashes of its node that can be
+// computed (see Supported) and against its gitoid identifier, if set.
+func Verify(doc *sbom.Document, dir string, opts *VerifyOptions) (*Report, error) {
+	if opts == nil {
+		opts = &VerifyOptions{}
+	}
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return nil, fmt.Errorf("opening directory: %w", err)
+	}
+	defer root.Close() //nolint:errcheck
+
+	report := &Report{Results: []*Result{}, Untracked: []string{}}
+	tracked := map[string]struct{}{}
+	for _, n := range doc.GetNodeList().GetNodes() {
+		if n.GetType() != sbom.Node_FILE {
+			continue
+		}
+		res, err := verifyNode(root, n)
+		if err != nil {
+			return nil, err
+		}
+		tracked[res.Path] = struct{}{}
+		report.Results = append(report.Results, res)
+	}
+
+	if opts.Untracked {
+		err := fs.WalkDir(root.FS(), ".", func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			if _, ok := tracked[p]; !ok {
+				report.Untracked = append(report.Untracked, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("listing directory: %w", err)
+		}
+	}
+	return report, nil
+}
+
+// nodePath returns the path of the file node relative to the root. Leading
+// "./" and "/" are removed as SBOMs commonly use them for the files in the
+// analyzed tree.
+func nodePath(n *sbom.Node) string {
+	p := filepath.ToSlash(n.GetName())
+	return path.Clean(strings.TrimLeft(strings.TrimPrefix(p, "./"), "/"))
+}
+
+// verifyNode checks the file of a node. Files outside of the root are
+// reported as missing.
+func verifyNode(root *os.Root, n *sbom.Node) (*Result, error) {
+	res := &Result{Node: n, Path: nodePath(n)}
+	if n.GetName() == "" || !filepath.IsLocal(res.Path) {
+		res.Status = StatusMissing
+		return res, nil
+	}
+
+	f, err := root.Open(filepath.FromSlash(res.Path))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			res.Status = StatusMissing
+			return res, nil
+		}
+		return nil, fmt.Errorf("opening %s: %w", res.Path, err)
+	}
+	defer f.Close() //nolint:errcheck
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("reading file info of %s: %w", res.Path, err)
+	}
+	if !info.Mode().IsRegular() {
+		res.Status = StatusMissing
+		return res, nil
+	}
+
+	algos := []sbom.HashAlgorithm{}
+	for algo := range n.GetHashes() {
+		if Supported(sbom.HashAlgorithm(algo)) {
+			algos = append(algos, sbom.HashAlgorithm(algo))
+		}
+	}
+	slices.Sort(algos)
+
+	expectedGitoid := n.GetIdentifiers()[int32(sbom.SoftwareIdentifierType_GITOID)]
+	var gitoidType gitoid.HashType
+	if expectedGitoid != "" {
+		if gitoidType, _, err = gitoid.Parse(expectedGitoid); err != nil {
+			// Invalid gitoids cannot match any content
+			res.Mismatches = append(res.Mismatches, Mismatch{Algorithm: gitoidAlgorithm, Expected: expectedGitoid})
+			expectedGitoid = ""
+		}
+	}
+
+	if len(algos) == 0 && expectedGitoid == "" && len(res.Mismatches) == 0 {
+		res.Status = StatusUnverified
+		return res, nil
+	}
+
+	d, err := digest(f, info.Size(), algos, gitoidType)
+	if err != nil {
+		return nil, fmt.Errorf("hashing %s: %w", res.Path, err)
+	}
+
+	for _, algo := range algos {
+		expected := n.GetHashes()[int32(algo)]
+		if !strings.EqualFold(expected, d.Hashes[int32(algo)]) {
+			res.Mismatches = append(res.Mismatches, Mismatch{
+				Algorithm: algo.String(), Expected: expected, Actual: d.Hashes[int32(algo)],
+			})
+		}
+	}
+	if expectedGitoid != "" && !strings.EqualFold(expectedGitoid, d.Gitoid) {
+		res.Mismatches = append(res.Mismatches, Mismatch{
+			Algorithm: gitoidAlgorithm, Expected: expectedGitoid, Actual: d.Gitoid,
+		})
+	}
+
+	res.Status = StatusVerified
+	if len(res.Mismatches) > 0 {
+		res.Status = StatusModified
+	}
+	return res, nil
+}
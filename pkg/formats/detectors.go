@@ -0,0 +1,73 @@
+package formats
+
+import (
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Detector inspects the data of a document and returns the formats it may be
+// encoded in. Detectors return no candidates when they find no evidence of
+// the formats they know.
+//
+// Drivers for new formats can register a Detector to make the Sniffer
+// recognize them.
+type Detector interface {
+	Detect(*SniffData) []Candidate
+}
+
+// DetectorFunc is an adapter to use ordinary functions as detectors
+type DetectorFunc func(*SniffData) []Candidate
+
+// Detect calls f(data)
+func (f DetectorFunc) Detect(data *SniffData) []Candidate {
+	return f(data)
+}
+
+const (
+	cdxDetectorName  = "cyclonedx"
+	spdxDetectorName = "spdx"
+)
+
+var (
+	detectorsMtx sync.RWMutex
+	detectors    = map[string]Detector{
+		cdxDetectorName:  DetectorFunc(sniffCDX),
+		spdxDetectorName: DetectorFunc(sniffSPDX),
+	}
+)
+
+// RegisterDetector adds a detector to the ones used by the Sniffer. The new
+// detector replaces any previously registered under the same name. The
+// built-in detectors are registered as "cyclonedx" and "spdx".
+func RegisterDetector(name string, d Detector) {
+	detectorsMtx.Lock()
+	detectors[name] = d
+	detectorsMtx.Unlock()
+}
+
+// UnregisterDetector removes a detector from the Sniffer
+func UnregisterDetector(name string) {
+	detectorsMtx.Lock()
+	delete(detectors, name)
+	detectorsMtx.Unlock()
+}
+
+// registeredDetectors returns the registered detectors sorted by name so
+// the sniffer results are deterministic.
+func registeredDetectors() []Detector {
+	detectorsMtx.RLock()
+	defer detectorsMtx.RUnlock()
+
+	names := make([]string, 0, len(detectors))
+	for name := range detectors {
+		names = append(names, name)
+	}
+	slices.SortFunc(names, strings.Compare)
+
+	ret := make([]Detector, 0, len(names))
+	for _, name := range names {
+		ret = append(ret, detectors[name])
+	}
+	return ret
+}
@@ -2,12 +2,14 @@ package formats
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"regexp"
+	"slices"
 	"strings"
-	"sync"
 )
 
 const (
@@ -15,24 +17,123 @@ const (
 
 	spdxVersion22 = "2.2"
 	spdxVersion23 = "2.3"
+
+	// sniffHeaderSize is the number of bytes read from the start of a
+	// document to look for the text format hints.
+	sniffHeaderSize = 32 * 1024
 )
 
-// sniffedSPDXVersions lists the SPDX versions the sniffer can detect.
-var sniffedSPDXVersions = []string{spdxVersion22, spdxVersion23}
+var (
+	// sniffedCDXVersions maps the CycloneDX versions the sniffer can detect
+	// to their JSON formats.
+	sniffedCDXVersions = map[string]Format{
+		"1.0": CDX10JSON,
+		"1.1": CDX11JSON,
+		"1.2": CDX12JSON,
+		"1.3": CDX13JSON,
+		"1.4": CDX14JSON,
+		"1.5": CDX15JSON,
+		"1.6": CDX16JSON,
+		"1.7": CDX17JSON,
+	}
+
+	// sniffedSPDXVersions maps the SPDX versions the sniffer can detect to
+	// their JSON and tag-value formats.
+	sniffedSPDXVersions = map[string][2]Format{
+		spdxVersion22: {SPDX22JSON, SPDX22TV},
+		spdxVersion23: {SPDX23JSON, SPDX23TV},
+	}
+
+	cdxSchemaRegex  = regexp.MustCompile(`cyclonedx\.org/schema/bom-(\d+\.\d+)`)
+	spdxSchemaRegex = regexp.MustCompile(`spdx-spec/v(\d+\.\d+)/schemas/`)
+)
+
+// Confidence expresses how sure the sniffer is that a document is encoded
+// in a format.
+type Confidence int
+
+const (
+	// ConfidenceNone means there is no evidence of the format
+	ConfidenceNone Confidence = iota
+
+	// ConfidenceLow means some traits of the format were found but they
+	// are not enough to tell the format apart from others.
+	ConfidenceLow
+
+	// ConfidenceMedium means the format was inferred from hints, such as
+	// the $schema URL, and not from the fields defined by the spec.
+	ConfidenceMedium
 
-var sniffFormats = []sniffFormat{
-	cdxSniff{},
-	spdxSniff{},
+	// ConfidenceHigh means the document declares the format in the fields
+	// defined by its spec.
+	ConfidenceHigh
+)
+
+func (c Confidence) String() string {
+	switch c {
+	case ConfidenceLow:
+		return "low"
+	case ConfidenceMedium:
+		return "medium"
+	case ConfidenceHigh:
+		return "high"
+	default:
+		return "none"
+	}
+}
+
+// Candidate is a format considered by the sniffer when detecting the format
+// of a document.
+type Candidate struct {
+	Format     Format
+	Confidence Confidence
+
+	// Reason describes the evidence found for the format
+	Reason string
 }
 
-var state = make(map[string]sniffState, len(sniffFormats))
+// Detection is the result of sniffing a document
+type Detection struct {
+	// Format is the detected format
+	Format Format
+
+	// Confidence is the confidence on the detected format
+	Confidence Confidence
 
-// stateMu guards the package-level state map, which SniffReader reassigns
-// and the sniffers read and write while scanning a non-JSON document.
-var stateMu sync.Mutex
+	// Candidates lists all the formats considered, sorted from the most
+	// to the least likely.
+	Candidates []Candidate
+}
 
-type sniffFormat interface {
-	sniff(data []byte) Format
+// UnknownFormatError is returned when the sniffer cannot determine the format
+// of a document. It lists the candidates it considered, if any.
+type UnknownFormatError struct {
+	Candidates []Candidate
+}
+
+func (e *UnknownFormatError) Error() string {
+	if len(e.Candidates) == 0 {
+		return "unknown SBOM format"
+	}
+
+	candidates := make([]string, 0, len(e.Candidates))
+	for _, c := range e.Candidates {
+		candidates = append(candidates, fmt.Sprintf("%s (%s confidence: %s)", c.Format, c.Confidence, c.Reason))
+	}
+	return fmt.Sprintf("unknown SBOM format, candidates considered: %s", strings.Join(candidates, ", "))
+}
+
+// SniffData holds the data extracted from a document and passed to the
+// format detectors.
+type SniffData struct {
+	// Header holds the first bytes of the document (up to 32 KiB)
+	Header []byte
+
+	// IsJSON is true when the document is a JSON object
+	IsJSON bool
+
+	// Fields holds the top level string fields of JSON documents
+	Fields map[string]string
 }
 
 type Sniffer struct{}
@@ -55,8 +156,21 @@ func (fs *Sniffer) SniffFile(path string) (Format, error) {
 	return fs.SniffReader(f)
 }
 
-// SniffReader reads a stream and return the SBOM format
+// SniffReader reads a stream and return the SBOM format. If the format cannot
+// be determined, the returned error is an *UnknownFormatError.
 func (fs *Sniffer) SniffReader(f io.ReadSeeker) (Format, error) {
+	detection, err := fs.Detect(f)
+	if err != nil {
+		return "", err
+	}
+	return detection.Format, nil
+}
+
+// Detect reads a stream and returns the detected format along with the
+// confidence of the detection and the rest of the candidates considered.
+// If no format can be detected with at least medium confidence, the returned
+// error is an *UnknownFormatError.
+func (fs *Sniffer) Detect(f io.ReadSeeker) (*Detection, error) {
 	defer func() {
 		_, err := f.Seek(0, 0)
 		if err != nil {
@@ -64,164 +178,211 @@ func (fs *Sniffer) SniffReader(f io.ReadSeeker) (Format, error) {
 		}
 	}()
 
-	type SpecVersionStruct struct {
-		BomFormat       string `json:"bomFormat"`
-		CDXSpecVersion  string `json:"specVersion"`
-		SPDXSpecVersion string `json:"spdxVersion"`
+	data, err := readSniffData(f)
+	if err != nil {
+		return nil, err
 	}
 
-	decoder := json.NewDecoder(f)
-
-	var specversionjson SpecVersionStruct
-	err := decoder.Decode(&specversionjson)
-	if err == nil {
-		if strings.EqualFold(specversionjson.BomFormat, CDXFORMAT) {
-			switch specversionjson.CDXSpecVersion {
-			case "1.3":
-				return CDX13JSON, nil
-			case "1.4":
-				return CDX14JSON, nil
-			case "1.5":
-				return CDX15JSON, nil
-			case "1.6":
-				return CDX16JSON, nil
-			case "1.7":
-				return CDX17JSON, nil
-			default:
-				// JSON + BomFormat CycloneDX but specVersion not 1.3, 1.4, 1.5, 1.6, or 1.7
-				return "", fmt.Errorf("unknown SBOM format")
-			}
-		} else {
-			// JSON but not CycloneDX so assuming SPDX
-			switch specversionjson.SPDXSpecVersion {
-			case "SPDX-2.2":
-				return SPDX22JSON, nil
-			case "SPDX-2.3":
-				return SPDX23JSON, nil
-			default:
-				// JSON + not CycloneDX but spdxVersion not SPDX-2.2 or SPDX-2.3
-				return "", fmt.Errorf("unknown SBOM format")
-			}
-		}
+	candidates := []Candidate{}
+	for _, d := range registeredDetectors() {
+		candidates = append(candidates, d.Detect(data)...)
 	}
 
-	// not JSON.  Parse line-by-line with string hacks
+	slices.SortStableFunc(candidates, func(a, b Candidate) int {
+		return int(b.Confidence) - int(a.Confidence)
+	})
 
-	_, err = f.Seek(0, 0)
-	if err != nil {
-		return "", fmt.Errorf("seeking to the beginning of SBOM file: %w", err)
+	// The best candidate must be at least of medium confidence and
+	// not tied with a candidate of a different format.
+	if len(candidates) == 0 || candidates[0].Confidence < ConfidenceMedium ||
+		(len(candidates) > 1 && candidates[1].Confidence == candidates[0].Confidence &&
+			candidates[1].Format != candidates[0].Format) {
+		return nil, &UnknownFormatError{Candidates: candidates}
 	}
 
-	fileScanner := bufio.NewScanner(f)
-	fileScanner.Split(bufio.ScanLines)
-
-	var format Format
+	return &Detection{
+		Format:     candidates[0].Format,
+		Confidence: candidates[0].Confidence,
+		Candidates: candidates,
+	}, nil
+}
 
-	// The scan below reads and writes the shared state map, so serialize
-	// concurrent sniffs to keep them from racing on it.
-	stateMu.Lock()
-	defer stateMu.Unlock()
+// readSniffData reads the document header and, if the document is JSON, its
+// top level string fields.
+func readSniffData(f io.ReadSeeker) (*SniffData, error) {
+	if _, err := f.Seek(0, 0); err != nil {
+		return nil, fmt.Errorf("seeking to the beginning of SBOM file: %w", err)
+	}
 
-	initSniffState()
-	for fileScanner.Scan() {
-		format = fs.sniff(fileScanner.Bytes())
+	header, err := io.ReadAll(io.LimitReader(f, sniffHeaderSize))
+	if err != nil {
+		return nil, fmt.Errorf("reading SBOM data: %w", err)
+	}
 
-		if format != EmptyFormat {
-			break
-		}
+	data := &SniffData{Header: header, Fields: map[string]string{}}
+	if !bytes.HasPrefix(bytes.TrimSpace(header), []byte("{")) {
+		return data, nil
 	}
 
-	if format != EmptyFormat {
-		return format, nil
+	if _, err := f.Seek(0, 0); err != nil {
+		return nil, fmt.Errorf("seeking to the beginning of SBOM file: %w", err)
 	}
 
-	// TODO(puerco): Implement a light parser in case the string hacks don't work
-	return "", fmt.Errorf("unknown SBOM format")
+	fields, err := jsonFields(f)
+	if err == nil {
+		data.IsJSON = true
+		data.Fields = fields
+	}
+	return data, nil
 }
 
-func (fs *Sniffer) sniff(data []byte) Format {
-	for _, sniffer := range sniffFormats {
-		format := sniffer.sniff(data)
-		if format != EmptyFormat {
-			return format
+// jsonFields decodes a JSON object and returns its top level string fields
+func jsonFields(r io.Reader) (map[string]string, error) {
+	dec := json.NewDecoder(r)
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, fmt.Errorf("reading JSON: %w", err)
+	}
+	if tok != json.Delim('{') {
+		return nil, fmt.Errorf("data is not a JSON object")
+	}
+
+	fields := map[string]string{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("reading JSON key: %w", err)
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid JSON key")
+		}
+
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, fmt.Errorf("reading JSON value: %w", err)
+		}
+
+		if len(value) > 0 && value[0] == '"' {
+			var s string
+			if err := json.Unmarshal(value, &s); err == nil {
+				fields[key] = s
+			}
 		}
 	}
-	return EmptyFormat
+	return fields, nil
 }
 
-type sniffState struct {
-	Type     string
-	Version  string
-	Encoding string
-}
+// sniffCDX returns the CycloneDX candidates for the document. protobom only
+// supports CycloneDX encoded as JSON.
+func sniffCDX(data *SniffData) []Candidate {
+	if !data.IsJSON {
+		return nil
+	}
 
-func (st *sniffState) Format() Format {
-	if st.Type != "" && st.Encoding != "" && st.Version != "" {
-		return Format(fmt.Sprintf("%s+%s;version=%s", st.Type, st.Encoding, st.Version))
+	bomFormat := data.Fields["bomFormat"]
+	specVersion := data.Fields["specVersion"]
+
+	schemaVersion := ""
+	if m := cdxSchemaRegex.FindStringSubmatch(data.Fields["$schema"]); m != nil {
+		schemaVersion = m[1]
 	}
 
-	return EmptyFormat
-}
+	if strings.EqualFold(bomFormat, CDXFORMAT) {
+		if f, ok := sniffedCDXVersions[specVersion]; ok {
+			return []Candidate{{f, ConfidenceHigh, "bomFormat is CycloneDX and specVersion is " + specVersion}}
+		}
+		if f, ok := sniffedCDXVersions[schemaVersion]; ok {
+			return []Candidate{{f, ConfidenceMedium, "bomFormat is CycloneDX and $schema points to version " + schemaVersion}}
+		}
+		return allCDXCandidates(ConfidenceLow, fmt.Sprintf("bomFormat is CycloneDX but specVersion %q is not supported", specVersion))
+	}
 
-type cdxSniff struct{}
+	if f, ok := sniffedCDXVersions[schemaVersion]; ok {
+		return []Candidate{{f, ConfidenceMedium, "$schema points to CycloneDX " + schemaVersion}}
+	}
 
-func (c cdxSniff) sniff(data []byte) Format {
-	// protobom only supports CDX formats as JSON
-	//  we are parsing the JSON in SniffReader by decoding to the SpecVersionStruct
-	//   removing all the previous JSON-related string matching from this function
-	//   if we want to support CDX XML formats in the future, could sniff for that in this func
-	//   until then, return EmptyFormat because we wont get here with a supported scenario
+	if bomFormat == "" && data.Fields["spdxVersion"] == "" {
+		if f, ok := sniffedCDXVersions[specVersion]; ok {
+			return []Candidate{{f, ConfidenceLow, "specVersion is " + specVersion + " but bomFormat is missing"}}
+		}
+	}
 
-	return EmptyFormat
+	return nil
 }
 
-type spdxSniff struct{}
-
-func (c spdxSniff) sniff(data []byte) Format {
-	state := getSniffState(SPDXFORMAT)
+// allCDXCandidates returns a candidate for each CycloneDX format
+func allCDXCandidates(confidence Confidence, reason string) []Candidate {
+	ret := []Candidate{}
+	for _, f := range sniffedCDXVersions {
+		ret = append(ret, Candidate{f, confidence, reason})
+	}
+	slices.SortFunc(ret, func(a, b Candidate) int {
+		return strings.Compare(string(a.Format), string(b.Format))
+	})
+	return ret
+}
 
-	stringValue := string(data)
+// sniffSPDX returns the SPDX candidates for JSON and tag-value documents
+func sniffSPDX(data *SniffData) []Candidate {
+	if !data.IsJSON {
+		return sniffSPDXText(data)
+	}
 
-	if strings.Contains(stringValue, "SPDXVersion:") {
-		state.Type = "text/spdx"
-		state.Encoding = TEXT
+	spdxVersion := data.Fields["spdxVersion"]
+	if version, ok := strings.CutPrefix(spdxVersion, "SPDX-"); ok {
+		if f, ok := sniffedSPDXVersions[version]; ok {
+			return []Candidate{{f[0], ConfidenceHigh, "spdxVersion is " + spdxVersion}}
+		}
+	}
 
-		for _, ver := range sniffedSPDXVersions {
-			if strings.Contains(stringValue, fmt.Sprintf("SPDX-%s", ver)) {
-				state.Version = ver
-				return state.Format()
-			}
+	if m := spdxSchemaRegex.FindStringSubmatch(data.Fields["$schema"]); m != nil {
+		if f, ok := sniffedSPDXVersions[m[1]]; ok {
+			return []Candidate{{f[0], ConfidenceMedium, "$schema points to SPDX " + m[1]}}
 		}
 	}
 
-	// Removed the strings.Contains to check for the JSON version
-	//  JSON version should be detected above in SniffReader via json.NewDecoder()
+	if spdxVersion != "" {
+		return allSPDXCandidates(0, ConfidenceLow, fmt.Sprintf("spdxVersion %q is not supported", spdxVersion))
+	}
 
-	for _, ver := range sniffedSPDXVersions {
-		if strings.Contains(stringValue, fmt.Sprintf("'SPDX-%s'", ver)) ||
-			strings.Contains(stringValue, fmt.Sprintf("\"SPDX-%s\"", ver)) {
-			state.Version = ver
-			return state.Format()
-		}
+	if data.Fields["SPDXID"] == "SPDXRef-DOCUMENT" {
+		return allSPDXCandidates(0, ConfidenceLow, "document SPDXID found but spdxVersion is missing")
 	}
 
-	setSniffState(SPDXFORMAT, state)
-	return state.Format()
+	return nil
 }
 
-func initSniffState() {
-	state = make(map[string]sniffState, len(sniffFormats))
-}
+// sniffSPDXText looks for the SPDXVersion tag in tag-value documents
+func sniffSPDXText(data *SniffData) []Candidate {
+	scanner := bufio.NewScanner(bytes.NewReader(data.Header))
+	scanner.Split(bufio.ScanLines)
+	for scanner.Scan() {
+		value, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "SPDXVersion:")
+		if !ok {
+			continue
+		}
 
-func getSniffState(t string) sniffState {
-	dm, ok := state[t]
-	if !ok {
-		state[t] = sniffState{}
-		return state[t]
+		value = strings.TrimSpace(value)
+		if version, ok := strings.CutPrefix(value, "SPDX-"); ok {
+			if f, ok := sniffedSPDXVersions[version]; ok {
+				return []Candidate{{f[1], ConfidenceHigh, "SPDXVersion tag is " + value}}
+			}
+		}
+		return allSPDXCandidates(1, ConfidenceLow, fmt.Sprintf("SPDXVersion tag %q is not supported", value))
 	}
-	return dm
+	return nil
 }
 
-func setSniffState(t string, snifferState sniffState) {
-	state[t] = snifferState
+// allSPDXCandidates returns a candidate for each SPDX version in the
+// encoding at index i of sniffedSPDXVersions (0 for JSON, 1 for tag-value).
+func allSPDXCandidates(i int, confidence Confidence, reason string) []Candidate {
+	ret := []Candidate{}
+	for _, f := range sniffedSPDXVersions {
+		ret = append(ret, Candidate{f[i], confidence, reason})
+	}
+	slices.SortFunc(ret, func(a, b Candidate) int {
+		return strings.Compare(string(a.Format), string(b.Format))
+	})
+	return ret
 }
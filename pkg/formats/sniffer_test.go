@@ -98,8 +98,8 @@ func TestSniffReader(t *testing.T) {
 
 func TestSniffReaderConcurrent(t *testing.T) {
 	// A tag-value SPDX document falls through the JSON decode and exercises
-	// the shared, map-based line scanner. Running many sniffs at once must
-	// not race on that map. Run this with -race to catch regressions.
+	// the line scanner. Running many sniffs at once must not race on any
+	// shared state. Run this with -race to catch regressions.
 	data, err := os.ReadFile("testdata/nginx.spdx")
 	require.NoError(t, err)
 
@@ -148,3 +148,96 @@ func TestSniffFile(t *testing.T) {
 		}
 	}
 }
+
+func TestDetect(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name       string
+		data       string
+		format     Format
+		confidence Confidence
+		candidates int
+	}{
+		{"cdx 1.0", `{"bomFormat": "CycloneDX", "specVersion": "1.0"}`, CDX10JSON, ConfidenceHigh, 1},
+		{"cdx 1.1", `{"bomFormat": "CycloneDX", "specVersion": "1.1"}`, CDX11JSON, ConfidenceHigh, 1},
+		{"cdx 1.2", `{"specVersion": "1.2", "components": [{"name": "a"}], "bomFormat": "CycloneDX"}`, CDX12JSON, ConfidenceHigh, 1},
+		{
+			"cdx schema", `{"$schema": "http://cyclonedx.org/schema/bom-1.5.schema.json", "bomFormat": "CycloneDX"}`,
+			CDX15JSON, ConfidenceMedium, 1,
+		},
+		{
+			"cdx schema only", `{"$schema": "http://cyclonedx.org/schema/bom-1.6.schema.json", "components": []}`,
+			CDX16JSON, ConfidenceMedium, 1,
+		},
+		{"spdx json", `{"SPDXID": "SPDXRef-DOCUMENT", "spdxVersion": "SPDX-2.3"}`, SPDX23JSON, ConfidenceHigh, 1},
+		{
+			"spdx schema", `{"$schema": "https://raw.githubusercontent.com/spdx/spdx-spec/v2.2/schemas/spdx-schema.json", "name": "x"}`,
+			SPDX22JSON, ConfidenceMedium, 1,
+		},
+		{"spdx tag-value", "## Document\nSPDXVersion: SPDX-2.3\nDataLicense: CC0-1.0\n", SPDX23TV, ConfidenceHigh, 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			fs := Sniffer{}
+			d, err := fs.Detect(bytes.NewReader([]byte(tc.data)))
+			require.NoError(t, err)
+			require.Equal(t, tc.format, d.Format)
+			require.Equal(t, tc.confidence, d.Confidence)
+			require.Len(t, d.Candidates, tc.candidates)
+		})
+	}
+}
+
+func TestDetectUnknown(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name       string
+		data       string
+		candidates int
+	}{
+		{"not an sbom", `{"name": "package.json", "version": "1.0.0"}`, 0},
+		{"unsupported cdx version", `{"bomFormat": "CycloneDX", "specVersion": "2.0"}`, len(sniffedCDXVersions)},
+		{"spdx without version", `{"SPDXID": "SPDXRef-DOCUMENT"}`, len(sniffedSPDXVersions)},
+		{"cdx without bomFormat", `{"specVersion": "1.4"}`, 1},
+		{"unsupported spdx text", "SPDXVersion: SPDX-3.0\n", len(sniffedSPDXVersions)},
+		{"text", "hello world\n", 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			fs := Sniffer{}
+			_, err := fs.SniffReader(bytes.NewReader([]byte(tc.data)))
+			require.Error(t, err)
+
+			var unknownErr *UnknownFormatError
+			require.ErrorAs(t, err, &unknownErr)
+			require.Len(t, unknownErr.Candidates, tc.candidates)
+		})
+	}
+}
+
+func TestRegisterDetector(t *testing.T) {
+	t.Parallel()
+	custom := Format("application/vnd.example+json;version=1.0")
+	name := "test-detector"
+	RegisterDetector(name, DetectorFunc(func(data *SniffData) []Candidate {
+		if data.Fields["exampleVersion"] == "1.0" {
+			return []Candidate{{custom, ConfidenceHigh, "exampleVersion is 1.0"}}
+		}
+		return nil
+	}))
+	defer UnregisterDetector(name)
+
+	fs := Sniffer{}
+	format, err := fs.SniffReader(bytes.NewReader([]byte(`{"exampleVersion": "1.0"}`)))
+	require.NoError(t, err)
+	require.Equal(t, custom, format)
+
+	// Built-in formats are still detected
+	format, err = fs.SniffReader(bytes.NewReader([]byte(`{"bomFormat": "CycloneDX", "specVersion": "1.5"}`)))
+	require.NoError(t, err)
+	require.Equal(t, CDX15JSON, format)
+
+	UnregisterDetector(name)
+	_, err = fs.SniffReader(bytes.NewReader([]byte(`{"exampleVersion": "1.0"}`)))
+	require.Error(t, err)
+}
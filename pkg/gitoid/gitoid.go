@@ -0,0 +1,118 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2026 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+// Package gitoid computes git object identifiers (gitoids) of file contents.
+// A gitoid is the hash git uses for blob objects, rendered as a URI such as
+// gitoid:blob:sha1:<hex digest>. See https://www.iana.org/assignments/uri-schemes/prov/gitoid
+// for the scheme and https://omnibor.io for its use in software supply chains.
+package gitoid
+
+import (
+	"bytes"
+	"crypto/sha1" //nolint:gosec // gitoids are defined on sha1 blob hashes
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+)
+
+// HashType is the hash algorithm of a gitoid
+type HashType string
+
+const (
+	// SHA1 gitoids match the blob IDs of git repositories using sha1
+	SHA1 HashType = "sha1"
+
+	// SHA256 gitoids match the blob IDs of git repositories using sha256
+	SHA256 HashType = "sha256"
+
+	// prefix is the start of all the blob gitoid URIs
+	prefix = "gitoid:blob:"
+)
+
+// blobHash is a hash of a git blob object. It writes the object header
+// again when reset so it can be reused for contents of the same size.
+type blobHash struct {
+	hash.Hash
+	header []byte
+}
+
+func (b *blobHash) Reset() {
+	b.Hash.Reset()
+	b.Hash.Write(b.header) //nolint:errcheck // hash writes never fail
+}
+
+// New returns a hash that computes the gitoid of size bytes of content
+// written to it. The header of the blob object is already written.
+func New(t HashType, size int64) (hash.Hash, error) {
+	var h hash.Hash
+	switch t {
+	case SHA1:
+		h = sha1.New() //nolint:gosec // gitoids are defined on sha1 blob hashes
+	case SHA256:
+		h = sha256.New()
+	default:
+		return nil, fmt.Errorf("unsupported gitoid hash type %q", t)
+	}
+	b := &blobHash{Hash: h, header: fmt.Appendf(nil, "blob %d\x00", size)}
+	b.Reset()
+	return b, nil
+}
+
+// Format renders the gitoid URI of a blob hash sum
+func Format(t HashType, sum []byte) string {
+	return prefix + string(t) + ":" + hex.EncodeToString(sum)
+}
+
+// FromReader computes the gitoid of the size bytes read from r. It returns
+// an error if r has a different number of bytes.
+func FromReader(r io.Reader, size int64, t HashType) (string, error) {
+	h, err := New(t, size)
+	if err != nil {
+		return "", err
+	}
+	n, err := io.Copy(h, r)
+	if err != nil {
+		return "", fmt.Errorf("reading content: %w", err)
+	}
+	if n != size {
+		return "", fmt.Errorf("read %d bytes, expected %d", n, size)
+	}
+	return Format(t, h.Sum(nil)), nil
+}
+
+// FromBytes computes the gitoid of data
+func FromBytes(data []byte, t HashType) (string, error) {
+	return FromReader(bytes.NewReader(data), int64(len(data)), t)
+}
+
+// Parse returns the hash type and the hex encoded digest of a blob gitoid
+func Parse(id string) (HashType, string, error) {
+	rest, ok := strings.CutPrefix(strings.ToLower(strings.TrimSpace(id)), prefix)
+	if !ok {
+		return "", "", fmt.Errorf("%q is not a blob gitoid", id)
+	}
+	t, digest, ok := strings.Cut(rest, ":")
+	if !ok {
+		return "", "", fmt.Errorf("gitoid %q has no digest", id)
+	}
+
+	var size int
+	switch HashType(t) {
+	case SHA1:
+		size = sha1.Size
+	case SHA256:
+		size = sha256.Size
+	default:
+		return "", "", fmt.Errorf("unsupported gitoid hash type %q", t)
+	}
+	if b, err := hex.DecodeString(digest); err != nil || len(b) != size {
+		return "", "", fmt.Errorf("invalid %s digest in gitoid %q", t, id)
+	}
+	return HashType(t), digest, nil
+}
@@ -0,0 +1,62 @@
+package gitoid
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromBytes(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		data     string
+		hashType HashType
+		expected string
+	}{
+		// Values produced by git hash-object
+		{"hello world", SHA1, "gitoid:blob:sha1:95d09f2b10159347eece71399a7e2e907ea3df4f"},
+		{"", SHA1, "gitoid:blob:sha1:e69de29bb2d1d6434b8b29ae775ad8c2e48c5391"},
+		{"hello world", SHA256, "gitoid:blob:sha256:fee53a18d32820613c0527aa79be5cb30173c823a9b448fa4817767cc84c6f03"},
+	} {
+		id, err := FromBytes([]byte(tc.data), tc.hashType)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, id)
+	}
+
+	_, err := FromBytes([]byte("hello"), HashType("md5"))
+	require.Error(t, err)
+}
+
+func TestFromReader(t *testing.T) {
+	t.Parallel()
+	_, err := FromReader(strings.NewReader("hello world"), 5, SHA1)
+	require.Error(t, err)
+
+	h, err := New(SHA1, 11)
+	require.NoError(t, err)
+	h.Write([]byte("goodbye")) //nolint:errcheck
+	h.Reset()
+	h.Write([]byte("hello world")) //nolint:errcheck
+	require.Equal(t, "gitoid:blob:sha1:95d09f2b10159347eece71399a7e2e907ea3df4f", Format(SHA1, h.Sum(nil)))
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	ht, digest, err := Parse("gitoid:blob:sha1:95d09f2b10159347eece71399a7e2e907ea3df4f")
+	require.NoError(t, err)
+	require.Equal(t, SHA1, ht)
+	require.Equal(t, "95d09f2b10159347eece71399a7e2e907ea3df4f", digest)
+
+	for _, id := range []string{
+		"",
+		"gitoid:tree:sha1:95d09f2b10159347eece71399a7e2e907ea3df4f",
+		"gitoid:blob:sha1",
+		"gitoid:blob:md5:95d09f2b10159347eece71399a7e2e90",
+		"gitoid:blob:sha256:95d09f2b10159347eece71399a7e2e907ea3df4f",
+		"gitoid:blob:sha1:not-hex",
+	} {
+		_, _, err := Parse(id)
+		require.Error(t, err, id)
+	}
+}
@@ -0,0 +1,57 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2026 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+type dotExporter struct{}
+
+// dotQuote returns s as a quoted DOT identifier
+func dotQuote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + r.Replace(s) + `"`
+}
+
+func (dotExporter) export(w io.Writer, nl *sbom.NodeList, opts *Options) error {
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, "digraph %s {\n", dotQuote(opts.Name))
+	fmt.Fprintln(b, "  node [shape=box];")
+
+	for _, n := range nl.Nodes {
+		attrs := fmt.Sprintf("label=%s", dotQuote(nodeLabel(n)))
+		if isRoot(nl, n.Id) {
+			attrs += ", style=bold"
+		}
+		fmt.Fprintf(b, "  %s [%s];\n", dotQuote(n.Id), attrs)
+	}
+
+	nodes := knownNodes(nl)
+	for _, e := range nl.Edges {
+		if _, ok := nodes[e.From]; !ok {
+			continue
+		}
+		for _, to := range e.To {
+			if _, ok := nodes[to]; !ok {
+				continue
+			}
+			fmt.Fprintf(
+				b, "  %s -> %s [label=%s];\n",
+				dotQuote(e.From), dotQuote(to), dotQuote(edgeLabel(e.Type)),
+			)
+		}
+	}
+
+	fmt.Fprintln(b, "}")
+	return b.Flush()
+}
@@ -0,0 +1,219 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2026 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+// Package graph renders the protobom NodeList graph into formats understood
+// by graph visualization tools: Graphviz DOT, GraphML and Mermaid flowcharts.
+//
+// Nodes are labeled with their name@version and edges with their protobom
+// edge type. The output can be limited to a fragment of the graph, like the
+// ones returned by NodeList.NodeGraph or NodeList.NodeDescendants.
+package graph
+
+import (
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// Format identifies one of the supported graph output formats.
+type Format string
+
+const (
+	DOT     = Format("dot")
+	GraphML = Format("graphml")
+	Mermaid = Format("mermaid")
+)
+
+// Subset determines the fragment of the NodeList that is exported.
+type Subset int
+
+const (
+	// SubsetAll exports the whole NodeList.
+	SubsetAll Subset = iota
+
+	// SubsetNodeGraph exports the graph returned by NodeList.NodeGraph
+	// for the node specified in Options.NodeID.
+	SubsetNodeGraph
+
+	// SubsetNodeDescendants exports the nodes returned by
+	// NodeList.NodeDescendants for the node specified in Options.NodeID,
+	// up to Options.MaxDepth levels. The original edges between the
+	// descendants are kept to preserve the graph structure.
+	SubsetNodeDescendants
+)
+
+// Options controls how the graph is exported.
+type Options struct {
+	// Subset limits the output to a fragment of the graph
+	Subset Subset
+
+	// NodeID is the identifier of the node used to compute the subset
+	NodeID string
+
+	// MaxDepth is the number of levels included in SubsetNodeDescendants
+	MaxDepth int
+
+	// Name is the title of the exported graph. Defaults to "protobom".
+	Name string
+}
+
+var defaultOptions = Options{
+	Subset: SubsetAll,
+	Name:   "protobom",
+}
+
+// exporter is the interface implemented by the format writers
+type exporter interface {
+	export(io.Writer, *sbom.NodeList, *Options) error
+}
+
+var exporters = map[Format]exporter{
+	DOT:     dotExporter{},
+	GraphML: graphMLExporter{},
+	Mermaid: mermaidExporter{},
+}
+
+// Write exports the NodeList graph to the stream w in the specified format.
+// If opts is nil, the whole graph is exported.
+func Write(w io.Writer, nl *sbom.NodeList, format Format, opts *Options) error {
+	e, ok := exporters[format]
+	if !ok {
+		return fmt.Errorf("unsupported graph format %q", format)
+	}
+
+	if opts == nil {
+		o := defaultOptions
+		opts = &o
+	}
+
+	if opts.Name == "" {
+		o := *opts
+		o.Name = defaultOptions.Name
+		opts = &o
+	}
+
+	subset, err := selectSubset(nl, opts)
+	if err != nil {
+		return err
+	}
+
+	if err := e.export(w, subset, opts); err != nil {
+		return fmt.Errorf("exporting graph to %s: %w", format, err)
+	}
+	return nil
+}
+
+// WriteDOT exports the NodeList graph as a Graphviz DOT digraph.
+func WriteDOT(w io.Writer, nl *sbom.NodeList, opts *Options) error {
+	return Write(w, nl, DOT, opts)
+}
+
+// WriteGraphML exports the NodeList graph as a GraphML document.
+func WriteGraphML(w io.Writer, nl *sbom.NodeList, opts *Options) error {
+	return Write(w, nl, GraphML, opts)
+}
+
+// WriteMermaid exports the NodeList graph as a Mermaid flowchart.
+func WriteMermaid(w io.Writer, nl *sbom.NodeList, opts *Options) error {
+	return Write(w, nl, Mermaid, opts)
+}
+
+// selectSubset returns the fragment of the NodeList defined in the options
+func selectSubset(nl *sbom.NodeList, opts *Options) (*sbom.NodeList, error) {
+	if nl == nil {
+		return nil, fmt.Errorf("unable to export graph, nodelist is nil")
+	}
+
+	switch opts.Subset {
+	case SubsetAll:
+		return nl, nil
+	case SubsetNodeGraph:
+		if opts.NodeID == "" {
+			return nil, fmt.Errorf("node ID required to export a node graph")
+		}
+		subset := nl.NodeGraph(opts.NodeID)
+		if subset == nil {
+			return nil, fmt.Errorf("node %q not found", opts.NodeID)
+		}
+		return subset, nil
+	case SubsetNodeDescendants:
+		if opts.NodeID == "" {
+			return nil, fmt.Errorf("node ID required to export node descendants")
+		}
+		descendants := nl.NodeDescendants(opts.NodeID, opts.MaxDepth)
+		if len(descendants.Nodes) == 0 {
+			return nil, fmt.Errorf("node %q not found", opts.NodeID)
+		}
+		return inducedSubgraph(nl, descendants), nil
+	default:
+		return nil, fmt.Errorf("unknown graph subset %d", opts.Subset)
+	}
+}
+
+// inducedSubgraph returns a NodeList with the nodes of subset and all the
+// edges of nl connecting them.
+func inducedSubgraph(nl, subset *sbom.NodeList) *sbom.NodeList {
+	members := map[string]struct{}{}
+	for _, n := range subset.Nodes {
+		members[n.Id] = struct{}{}
+	}
+
+	ret := &sbom.NodeList{
+		Nodes:        subset.Nodes,
+		Edges:        []*sbom.Edge{},
+		RootElements: subset.RootElements,
+	}
+	for _, e := range nl.Edges {
+		if _, ok := members[e.From]; !ok {
+			continue
+		}
+		edge := &sbom.Edge{Type: e.Type, From: e.From, To: []string{}}
+		for _, to := range e.To {
+			if _, ok := members[to]; ok {
+				edge.To = append(edge.To, to)
+			}
+		}
+		if len(edge.To) > 0 {
+			ret.Edges = append(ret.Edges, edge)
+		}
+	}
+	return ret
+}
+
+// nodeLabel returns the label used to display a node: its name@version,
+// falling back to the node identifier if the node has no name.
+func nodeLabel(n *sbom.Node) string {
+	switch {
+	case n.Name == "":
+		return n.Id
+	case n.Version == "":
+		return n.Name
+	default:
+		return n.Name + "@" + n.Version
+	}
+}
+
+// edgeLabel returns the label of a relationship
+func edgeLabel(t sbom.Edge_Type) string {
+	return t.String()
+}
+
+// isRoot returns true if the node id is one of the NodeList root elements
+func isRoot(nl *sbom.NodeList, id string) bool {
+	return slices.Contains(nl.RootElements, id)
+}
+
+// knownNodes returns a set of the node identifiers in the nodelist. Edges
+// pointing to nodes not in the set are skipped when exporting.
+func knownNodes(nl *sbom.NodeList) map[string]struct{} {
+	ret := make(map[string]struct{}, len(nl.Nodes))
+	for _, n := range nl.Nodes {
+		ret[n.Id] = struct{}{}
+	}
+	return ret
+}
@@ -0,0 +1,128 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+func testNodeList() *sbom.NodeList {
+	return &sbom.NodeList{
+		Nodes: []*sbom.Node{
+			{Id: "root", Name: "app", Version: "1.0.0"},
+			{Id: "lib", Name: "lib\"quoted\"", Version: "2.0"},
+			{Id: "file", Name: "README"},
+			{Id: "other"},
+		},
+		Edges: []*sbom.Edge{
+			{Type: sbom.Edge_dependsOn, From: "root", To: []string{"lib"}},
+			{Type: sbom.Edge_contains, From: "lib", To: []string{"file", "missing"}},
+		},
+		RootElements: []string{"root", "other"},
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	t.Parallel()
+	var b bytes.Buffer
+	require.NoError(t, WriteDOT(&b, testNodeList(), nil))
+	out := b.String()
+	require.Contains(t, out, `digraph "protobom" {`)
+	require.Contains(t, out, `"root" [label="app@1.0.0", style=bold];`)
+	require.Contains(t, out, `"lib" [label="lib\"quoted\"@2.0"];`)
+	require.Contains(t, out, `"file" [label="README"];`)
+	require.Contains(t, out, `"other" [label="other", style=bold];`)
+	require.Contains(t, out, `"root" -> "lib" [label="dependsOn"];`)
+	require.Contains(t, out, `"lib" -> "file" [label="contains"];`)
+	require.NotContains(t, out, "missing")
+}
+
+func TestWriteMermaid(t *testing.T) {
+	t.Parallel()
+	var b bytes.Buffer
+	require.NoError(t, WriteMermaid(&b, testNodeList(), &Options{Name: "test"}))
+	out := b.String()
+	require.Contains(t, out, "title: test")
+	require.Contains(t, out, "flowchart TD")
+	require.Contains(t, out, `n0["app@1.0.0"]`)
+	require.Contains(t, out, `n1["lib#quot;quoted#quot;@2.0"]`)
+	require.Contains(t, out, "n0 -->|dependsOn| n1")
+	require.Contains(t, out, "n1 -->|contains| n2")
+	require.Contains(t, out, "class n0,n3 root")
+}
+
+func TestWriteGraphML(t *testing.T) {
+	t.Parallel()
+	var b bytes.Buffer
+	require.NoError(t, WriteGraphML(&b, testNodeList(), nil))
+
+	doc := graphMLDocument{}
+	require.NoError(t, xml.Unmarshal(b.Bytes(), &doc))
+	require.Len(t, doc.Graph.Nodes, 4)
+	require.Len(t, doc.Graph.Edges, 2)
+	require.Equal(t, "root", doc.Graph.Edges[0].Source)
+	require.Equal(t, "lib", doc.Graph.Edges[0].Target)
+	require.Equal(t, "dependsOn", doc.Graph.Edges[0].Data[0].Value)
+	require.Equal(t, "app@1.0.0", doc.Graph.Nodes[0].Data[0].Value)
+}
+
+func TestWriteSubsets(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name      string
+		opts      *Options
+		contains  []string
+		missing   []string
+		shouldErr bool
+	}{
+		{
+			name:     "node graph",
+			opts:     &Options{Subset: SubsetNodeGraph, NodeID: "lib"},
+			contains: []string{`"lib" -> "file"`},
+			missing:  []string{`"root"`, `"other"`},
+		},
+		{
+			name:     "descendants keep edges",
+			opts:     &Options{Subset: SubsetNodeDescendants, NodeID: "root", MaxDepth: 1},
+			contains: []string{`"root" -> "lib" [label="dependsOn"]`},
+			missing:  []string{`"file"`, "ancestor"},
+		},
+		{
+			name:      "missing node",
+			opts:      &Options{Subset: SubsetNodeGraph, NodeID: "nope"},
+			shouldErr: true,
+		},
+		{
+			name:      "no node id",
+			opts:      &Options{Subset: SubsetNodeDescendants},
+			shouldErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var b bytes.Buffer
+			err := WriteDOT(&b, testNodeList(), tc.opts)
+			if tc.shouldErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			for _, s := range tc.contains {
+				require.Contains(t, b.String(), s)
+			}
+			for _, s := range tc.missing {
+				require.NotContains(t, b.String(), s)
+			}
+		})
+	}
+}
+
+func TestWriteUnknownFormat(t *testing.T) {
+	t.Parallel()
+	var b bytes.Buffer
+	require.Error(t, Write(&b, testNodeList(), Format("png"), nil))
+}
@@ -0,0 +1,122 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2026 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package graph
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+type graphMLExporter struct{}
+
+const graphMLNamespace = "http://graphml.graphdrawing.org/xmlns"
+
+type graphMLDocument struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	ID     string        `xml:"id,attr"`
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+func (graphMLExporter) export(w io.Writer, nl *sbom.NodeList, opts *Options) error {
+	doc := graphMLDocument{
+		XMLNS: graphMLNamespace,
+		Keys: []graphMLKey{
+			{ID: "label", For: "node", AttrName: "label", AttrType: "string"},
+			{ID: "name", For: "node", AttrName: "name", AttrType: "string"},
+			{ID: "version", For: "node", AttrName: "version", AttrType: "string"},
+			{ID: "purl", For: "node", AttrName: "purl", AttrType: "string"},
+			{ID: "root", For: "node", AttrName: "root", AttrType: "boolean"},
+			{ID: "type", For: "edge", AttrName: "type", AttrType: "string"},
+		},
+		Graph: graphMLGraph{
+			ID:          opts.Name,
+			EdgeDefault: "directed",
+			Nodes:       []graphMLNode{},
+			Edges:       []graphMLEdge{},
+		},
+	}
+
+	for _, n := range nl.Nodes {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{
+			ID: n.Id,
+			Data: []graphMLData{
+				{Key: "label", Value: nodeLabel(n)},
+				{Key: "name", Value: n.Name},
+				{Key: "version", Value: n.Version},
+				{Key: "purl", Value: string(n.Purl())},
+				{Key: "root", Value: strconv.FormatBool(isRoot(nl, n.Id))},
+			},
+		})
+	}
+
+	nodes := knownNodes(nl)
+	for _, e := range nl.Edges {
+		if _, ok := nodes[e.From]; !ok {
+			continue
+		}
+		for _, to := range e.To {
+			if _, ok := nodes[to]; !ok {
+				continue
+			}
+			doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+				ID:     fmt.Sprintf("e%d", len(doc.Graph.Edges)),
+				Source: e.From,
+				Target: to,
+				Data:   []graphMLData{{Key: "type", Value: edgeLabel(e.Type)}},
+			})
+		}
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("writing xml header: %w", err)
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("encoding graphml: %w", err)
+	}
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return fmt.Errorf("writing graphml: %w", err)
+	}
+	return nil
+}
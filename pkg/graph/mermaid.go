@@ -0,0 +1,65 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2026 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+type mermaidExporter struct{}
+
+// mermaidText escapes s to be used as a quoted mermaid label
+func mermaidText(s string) string {
+	r := strings.NewReplacer(`"`, "#quot;", "\n", " ")
+	return r.Replace(s)
+}
+
+// Mermaid node identifiers are limited to a few characters, so nodes are
+// rendered with generated identifiers and their names as labels.
+func (mermaidExporter) export(w io.Writer, nl *sbom.NodeList, opts *Options) error {
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, "---\ntitle: %s\n---\n", mermaidText(opts.Name))
+	fmt.Fprintln(b, "flowchart TD")
+
+	ids := map[string]string{}
+	roots := []string{}
+	for i, n := range nl.Nodes {
+		if _, ok := ids[n.Id]; ok {
+			continue
+		}
+		ids[n.Id] = fmt.Sprintf("n%d", i)
+		fmt.Fprintf(b, "  %s[\"%s\"]\n", ids[n.Id], mermaidText(nodeLabel(n)))
+		if isRoot(nl, n.Id) {
+			roots = append(roots, ids[n.Id])
+		}
+	}
+
+	for _, e := range nl.Edges {
+		from, ok := ids[e.From]
+		if !ok {
+			continue
+		}
+		for _, to := range e.To {
+			if _, ok := ids[to]; !ok {
+				continue
+			}
+			fmt.Fprintf(b, "  %s -->|%s| %s\n", from, edgeLabel(e.Type), ids[to])
+		}
+	}
+
+	if len(roots) > 0 {
+		fmt.Fprintln(b, "  classDef root stroke-width:3px")
+		fmt.Fprintf(b, "  class %s root\n", strings.Join(roots, ","))
+	}
+
+	return b.Flush()
+}
@@ -0,0 +1,213 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2026 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+// Package intoto wraps rendered SBOMs in in-toto attestation statements and
+// reads them back. See https://github.com/in-toto/attestation for the
+// statement specification.
+package intoto
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/protobom/protobom/pkg/formats"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+const (
+	// StatementType is the type of the in-toto statements generated
+	StatementType = "https://in-toto.io/Statement/v1"
+
+	// PredicateTypeSPDX is the predicate type of SPDX documents
+	PredicateTypeSPDX = "https://spdx.dev/Document"
+
+	// PredicateTypeCycloneDX is the predicate type of CycloneDX documents
+	PredicateTypeCycloneDX = "https://cyclonedx.org/bom"
+
+	// statementTypePrefix matches all the versions of the statement
+	statementTypePrefix = "https://in-toto.io/Statement/"
+)
+
+// ErrNoSubjects is returned when a document has no root nodes with hashes
+// to use as the subjects of a statement.
+var ErrNoSubjects = errors.New("no root node hashes found to use as statement subjects")
+
+// digestNames maps the protobom hash algorithms to the names used in the
+// in-toto digest sets.
+var digestNames = map[sbom.HashAlgorithm]string{
+	sbom.HashAlgorithm_MD5:         "md5",
+	sbom.HashAlgorithm_SHA1:        "sha1",
+	sbom.HashAlgorithm_SHA224:      "sha224",
+	sbom.HashAlgorithm_SHA256:      "sha256",
+	sbom.HashAlgorithm_SHA384:      "sha384",
+	sbom.HashAlgorithm_SHA512:      "sha512",
+	sbom.HashAlgorithm_SHA3_256:    "sha3_256",
+	sbom.HashAlgorithm_SHA3_384:    "sha3_384",
+	sbom.HashAlgorithm_SHA3_512:    "sha3_512",
+	sbom.HashAlgorithm_BLAKE2B_256: "blake2b_256",
+	sbom.HashAlgorithm_BLAKE2B_384: "blake2b_384",
+	sbom.HashAlgorithm_BLAKE2B_512: "blake2b",
+	sbom.HashAlgorithm_BLAKE3:      "blake3",
+}
+
+// Statement is an in-toto attestation statement
+type Statement struct {
+	Type          string          `json:"_type"`
+	Subject       []Subject       `json:"subject"`
+	PredicateType string          `json:"predicateType"`
+	Predicate     json.RawMessage `json:"predicate"`
+}
+
+// Subject is a software artifact the statement refers to
+type Subject struct {
+	Name   string            `json:"name,omitempty"`
+	Digest map[string]string `json:"digest"`
+}
+
+// PredicateType returns the predicate type of SBOMs rendered in format.
+// Only JSON encoded formats can be used as predicates.
+func PredicateType(format formats.Format) (string, error) {
+	if format.Encoding() != formats.JSON {
+		return "", fmt.Errorf("format %s cannot be used as an in-toto predicate, it must be JSON", format)
+	}
+	switch format.Type() {
+	case formats.SPDXFORMAT:
+		return PredicateTypeSPDX, nil
+	case formats.CDXFORMAT:
+		return PredicateTypeCycloneDX, nil
+	default:
+		return "", fmt.Errorf("no predicate type known for format %s", format)
+	}
+}
+
+// Subjects returns the statement subjects of a document, built from the
+// hashes of its root nodes. Root nodes without hashes are skipped.
+func Subjects(doc *sbom.Document) []Subject {
+	ret := []Subject{}
+	if doc.GetNodeList() == nil {
+		return ret
+	}
+	for _, n := range doc.GetRootNodes() {
+		digest := map[string]string{}
+		for algo, value := range n.GetHashes() {
+			if name, ok := digestNames[sbom.HashAlgorithm(algo)]; ok {
+				digest[name] = strings.ToLower(value)
+			}
+		}
+		if len(digest) == 0 {
+			continue
+		}
+
+		name := n.GetName()
+		if name == "" {
+			name = n.GetId()
+		}
+		ret = append(ret, Subject{Name: name, Digest: digest})
+	}
+	return ret
+}
+
+// NewStatement wraps a document rendered in format in a statement. The
+// subjects are taken from the root nodes of doc.
+func NewStatement(doc *sbom.Document, format formats.Format, rendered []byte) (*Statement, error) {
+	predicateType, err := PredicateType(format)
+	if err != nil {
+		return nil, err
+	}
+
+	subjects := Subjects(doc)
+	if len(subjects) == 0 {
+		return nil, ErrNoSubjects
+	}
+
+	if !json.Valid(rendered) {
+		return nil, errors.New("rendered document is not valid JSON")
+	}
+
+	return &Statement{
+		Type:          StatementType,
+		Subject:       subjects,
+		PredicateType: predicateType,
+		Predicate:     json.RawMessage(bytes.TrimSpace(rendered)),
+	}, nil
+}
+
+// IsStatement returns true if the JSON document read from r is an in-toto
+// statement. The document is read until its _type field is found.
+func IsStatement(r io.Reader) bool {
+	dec := json.NewDecoder(r)
+	if t, err := dec.Token(); err != nil || t != json.Delim('{') {
+		return false
+	}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return false
+		}
+		if key != "_type" {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return false
+			}
+			continue
+		}
+		var value string
+		if err := dec.Decode(&value); err != nil {
+			return false
+		}
+		return strings.HasPrefix(value, statementTypePrefix)
+	}
+	return false
+}
+
+// ParseStatement reads an in-toto statement
+func ParseStatement(data []byte) (*Statement, error) {
+	s := &Statement{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("decoding in-toto statement: %w", err)
+	}
+	if !strings.HasPrefix(s.Type, statementTypePrefix) {
+		return nil, fmt.Errorf("unknown statement type %q", s.Type)
+	}
+	if len(s.Predicate) == 0 || bytes.Equal(s.Predicate, []byte("null")) {
+		return nil, errors.New("statement has no predicate")
+	}
+	return s, nil
+}
+
+// IsSBOM returns true if the predicate of the statement is an SBOM known to
+// protobom. Versioned predicate types (eg https://spdx.dev/Document/v2.3)
+// are recognized too.
+func (s *Statement) IsSBOM() bool {
+	for _, t := range []string{PredicateTypeSPDX, PredicateTypeCycloneDX} {
+		if s.PredicateType == t || strings.HasPrefix(s.PredicateType, t+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// SBOMSubjects returns the subjects of the statement as protobom subjects.
+// Digests of algorithms unknown to protobom are dropped.
+func (s *Statement) SBOMSubjects() []*sbom.Subject {
+	ret := make([]*sbom.Subject, 0, len(s.Subject))
+	for _, subject := range s.Subject {
+		hashes := map[int32]string{}
+		for name, value := range subject.Digest {
+			for algo, digestName := range digestNames {
+				if digestName == strings.ToLower(name) {
+					hashes[int32(algo)] = value
+				}
+			}
+		}
+		ret = append(ret, &sbom.Subject{Name: subject.Name, Hashes: hashes})
+	}
+	return ret
+}
@@ -0,0 +1,106 @@
+package intoto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/formats"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+func TestPredicateType(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		format   formats.Format
+		expected string
+		mustErr  bool
+	}{
+		{formats.SPDX23JSON, PredicateTypeSPDX, false},
+		{formats.CDX15JSON, PredicateTypeCycloneDX, false},
+		{formats.SPDX23TV, "", true},
+		{formats.Format("application/json"), "", true},
+	} {
+		pt, err := PredicateType(tc.format)
+		if tc.mustErr {
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, pt)
+	}
+}
+
+func TestSubjects(t *testing.T) {
+	t.Parallel()
+	doc := sbom.NewDocument()
+	doc.NodeList.AddRootNode(&sbom.Node{
+		Id: "no-name",
+		Hashes: map[int32]string{
+			int32(sbom.HashAlgorithm_SHA512): "ABC",
+			int32(sbom.HashAlgorithm_MD2):    "ignored",
+		},
+	})
+	doc.NodeList.AddRootNode(&sbom.Node{Id: "no-hashes", Name: "skipped"})
+	doc.NodeList.AddNode(&sbom.Node{
+		Id:     "not-root",
+		Hashes: map[int32]string{int32(sbom.HashAlgorithm_SHA256): "def"},
+	})
+
+	require.Equal(t, []Subject{
+		{Name: "no-name", Digest: map[string]string{"sha512": "abc"}},
+	}, Subjects(doc))
+
+	_, err := NewStatement(sbom.NewDocument(), formats.SPDX23JSON, []byte("{}"))
+	require.ErrorIs(t, err, ErrNoSubjects)
+
+	_, err = NewStatement(doc, formats.SPDX23JSON, []byte("not json"))
+	require.Error(t, err)
+}
+
+func TestIsStatement(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		data     string
+		expected bool
+	}{
+		{`{"_type": "https://in-toto.io/Statement/v1", "predicate": {}}`, true},
+		{`{"subject": [{"digest": {"sha256": "abc"}}], "_type": "https://in-toto.io/Statement/v0.1"}`, true},
+		{`{"_type": "https://example.com/Other"}`, false},
+		{`{"spdxVersion": "SPDX-2.3"}`, false},
+		{`SPDXVersion: SPDX-2.3`, false},
+		{``, false},
+	} {
+		require.Equal(t, tc.expected, IsStatement(strings.NewReader(tc.data)), tc.data)
+	}
+}
+
+func TestParseStatement(t *testing.T) {
+	t.Parallel()
+	s, err := ParseStatement([]byte(`{
+		"_type": "https://in-toto.io/Statement/v1",
+		"subject": [{"name": "app", "digest": {"sha256": "abc", "SHA1": "def", "gitCommit": "0123"}}],
+		"predicateType": "https://cyclonedx.org/bom/v1.6",
+		"predicate": {"bomFormat": "CycloneDX"}
+	}`))
+	require.NoError(t, err)
+	require.True(t, s.IsSBOM())
+
+	subjects := s.SBOMSubjects()
+	require.Len(t, subjects, 1)
+	require.Equal(t, "app", subjects[0].GetName())
+	require.Equal(t, map[int32]string{
+		int32(sbom.HashAlgorithm_SHA256): "abc",
+		int32(sbom.HashAlgorithm_SHA1):   "def",
+	}, subjects[0].GetHashes())
+
+	_, err = ParseStatement([]byte(`{"_type": "https://in-toto.io/Statement/v1", "subject": []}`))
+	require.Error(t, err)
+	_, err = ParseStatement([]byte(`{"_type": "other", "predicate": {}}`))
+	require.Error(t, err)
+
+	s, err = ParseStatement([]byte(`{"_type": "https://in-toto.io/Statement/v1", "predicateType": "https://cyclonedx.org/bomb", "predicate": {}}`))
+	require.NoError(t, err)
+	require.False(t, s.IsSBOM())
+}
@@ -0,0 +1,171 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2026 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package license
+
+import (
+	"strings"
+)
+
+// Category classifies licenses by the obligations they impose on the
+// software that uses the licensed code.
+type Category string
+
+const (
+	// CategoryPermissive licenses only require attribution (eg MIT, Apache-2.0)
+	CategoryPermissive Category = "permissive"
+
+	// CategoryWeakCopyleft licenses require sharing the changes to the
+	// licensed code but not to the software linking it (eg LGPL, MPL).
+	CategoryWeakCopyleft Category = "weak-copyleft"
+
+	// CategoryCopyleft licenses extend their terms to the software that
+	// includes the licensed code (eg GPL, AGPL).
+	CategoryCopyleft Category = "copyleft"
+
+	// CategoryUnknown is the category of licenses not classified, including
+	// all LicenseRefs.
+	CategoryUnknown Category = "unknown"
+)
+
+// categoryRule classifies the licenses with IDs starting with prefix
+type categoryRule struct {
+	prefix   string
+	category Category
+}
+
+// categoryIDs classifies licenses whose ID starts with the prefix of a
+// rule of another category. They are checked before the prefix rules.
+var categoryIDs = map[string]Category{
+	// CeCILL-B is a BSD-like license, unlike the other CeCILL licenses
+	"CECILL-B": CategoryPermissive,
+
+	// The BSD Protection License requires releasing changes under its terms
+	"BSD-Protection": CategoryCopyleft,
+}
+
+// categoryRules are checked in order, longer prefixes go before shorter
+// ones they overlap with.
+var categoryRules = []categoryRule{
+	// Weak copyleft
+	{"LGPL-", CategoryWeakCopyleft},
+	{"LGPLLR", CategoryWeakCopyleft},
+	{"MPL-", CategoryWeakCopyleft},
+	{"EPL-", CategoryWeakCopyleft},
+	{"CDDL-", CategoryWeakCopyleft},
+	{"CPL-1.0", CategoryWeakCopyleft},
+	{"MS-RL", CategoryWeakCopyleft},
+	{"ErlPL-", CategoryWeakCopyleft},
+	{"IPL-1.0", CategoryWeakCopyleft},
+	{"APSL-", CategoryWeakCopyleft},
+	{"NPL-", CategoryWeakCopyleft},
+	{"SPL-1.0", CategoryWeakCopyleft},
+	{"CECILL-C", CategoryWeakCopyleft},
+	{"LiLiQ-R", CategoryWeakCopyleft},
+	{"OSET-PL-", CategoryWeakCopyleft},
+	{"OFL-", CategoryWeakCopyleft},
+
+	// Copyleft
+	{"GPL-", CategoryCopyleft},
+	{"AGPL-", CategoryCopyleft},
+	{"EUPL-", CategoryCopyleft},
+	{"OSL-", CategoryCopyleft},
+	{"CC-BY-SA-", CategoryCopyleft},
+	{"CECILL-", CategoryCopyleft},
+	{"RPL-", CategoryCopyleft},
+	{"QPL-", CategoryCopyleft},
+	{"SSPL-", CategoryCopyleft},
+	{"GFDL-", CategoryCopyleft},
+	{"ODbL-", CategoryCopyleft},
+	{"Sleepycat", CategoryCopyleft},
+
+	// Permissive
+	{"MIT", CategoryPermissive},
+	{"BSD-", CategoryPermissive},
+	{"0BSD", CategoryPermissive},
+	{"Apache-", CategoryPermissive},
+	{"ISC", CategoryPermissive},
+	{"Zlib", CategoryPermissive},
+	{"zlib-acknowledgement", CategoryPermissive},
+	{"Unlicense", CategoryPermissive},
+	{"WTFPL", CategoryPermissive},
+	{"BSL-1.0", CategoryPermissive},
+	{"PSF-2.0", CategoryPermissive},
+	{"Python-2.0", CategoryPermissive},
+	{"X11", CategoryPermissive},
+	{"PostgreSQL", CategoryPermissive},
+	{"NCSA", CategoryPermissive},
+	{"UPL-1.0", CategoryPermissive},
+	{"Artistic-2.0", CategoryPermissive},
+	{"CC0-1.0", CategoryPermissive},
+	{"CC-BY-3.0", CategoryPermissive},
+	{"CC-BY-4.0", CategoryPermissive},
+	{"Unicode-", CategoryPermissive},
+	{"BlueOak-1.0.0", CategoryPermissive},
+	{"curl", CategoryPermissive},
+	{"libpng", CategoryPermissive},
+	{"OpenSSL", CategoryPermissive},
+	{"PHP-3", CategoryPermissive},
+	{"Ruby", CategoryPermissive},
+	{"AFL-", CategoryPermissive},
+	{"W3C", CategoryPermissive},
+}
+
+// linkingExceptions are the exceptions that allow linking copyleft code
+// without extending the license to the linking software.
+var linkingExceptions = map[string]struct{}{
+	"Autoconf-exception-2.0":           {},
+	"Autoconf-exception-3.0":           {},
+	"Bison-exception-2.2":              {},
+	"Classpath-exception-2.0":          {},
+	"eCos-exception-2.0":               {},
+	"Font-exception-2.0":               {},
+	"GCC-exception-2.0":                {},
+	"GCC-exception-3.1":                {},
+	"GPL-3.0-linking-exception":        {},
+	"LGPL-3.0-linking-exception":       {},
+	"Libtool-exception":                {},
+	"Linux-syscall-note":               {},
+	"OpenJDK-assembly-exception-1.0":   {},
+	"Universal-FOSS-exception-1.0":     {},
+	"WxWindows-exception-3.1":          {},
+	"GPL-3.0-linking-source-exception": {},
+}
+
+// Categorize returns the category of the license identifier
+func Categorize(id string) Category {
+	if info, ok := Lookup(id); ok {
+		id = info.ID
+	} else {
+		return CategoryUnknown
+	}
+	if c, ok := categoryIDs[id]; ok {
+		return c
+	}
+	for _, rule := range categoryRules {
+		if strings.HasPrefix(id, rule.prefix) {
+			return rule.category
+		}
+	}
+	return CategoryUnknown
+}
+
+// Category returns the category of a simple expression. Copyleft licenses
+// with a linking exception are considered weak copyleft.
+func (e *Expression) Category() Category {
+	if e.IsCompound() {
+		return CategoryUnknown
+	}
+	c := Categorize(e.License)
+	if c == CategoryCopyleft && e.Exception != "" {
+		if info, ok := LookupException(e.Exception); ok {
+			if _, ok := linkingExceptions[info.ID]; ok {
+				return CategoryWeakCopyleft
+			}
+		}
+	}
+	return c
+}
@@ -0,0 +1,360 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2026 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package license
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrUnknownLicense is returned when validating expressions with
+	// license identifiers not in the SPDX license list.
+	ErrUnknownLicense = errors.New("unknown license identifier")
+
+	// ErrUnknownException is returned when validating expressions with
+	// exception identifiers not in the SPDX exception list.
+	ErrUnknownException = errors.New("unknown license exception")
+)
+
+// Operator joins the terms of compound license expressions
+type Operator string
+
+const (
+	And Operator = "AND"
+	Or  Operator = "OR"
+
+	with = "WITH"
+)
+
+// Expression is a parsed SPDX license expression. Simple expressions have
+// a License and, optionally, an Exception. Compound expressions join their
+// Terms with the Operator.
+type Expression struct {
+	// Operator joins the terms of compound expressions
+	Operator Operator
+
+	// Terms are the subexpressions of compound expressions
+	Terms []*Expression
+
+	// License is the license identifier or reference of simple expressions
+	License string
+
+	// OrLater is set when the license is followed by "+"
+	OrLater bool
+
+	// Exception is the identifier of the exception added with WITH
+	Exception string
+}
+
+// IsCompound returns true if the expression joins other expressions
+func (e *Expression) IsCompound() bool {
+	return e.Operator != ""
+}
+
+// String renders the expression. Nested compound expressions are wrapped
+// in parentheses.
+func (e *Expression) String() string {
+	if e == nil {
+		return ""
+	}
+	if !e.IsCompound() {
+		s := e.License
+		if e.OrLater {
+			s += "+"
+		}
+		if e.Exception != "" {
+			s += " " + with + " " + e.Exception
+		}
+		return s
+	}
+
+	parts := make([]string, 0, len(e.Terms))
+	for _, t := range e.Terms {
+		if t.IsCompound() && t.Operator != e.Operator {
+			parts = append(parts, "("+t.String()+")")
+			continue
+		}
+		parts = append(parts, t.String())
+	}
+	return strings.Join(parts, " "+string(e.Operator)+" ")
+}
+
+// Licenses returns the license identifiers in the expression in the order
+// they appear, without duplicates.
+func (e *Expression) Licenses() []string {
+	ret := []string{}
+	seen := map[string]struct{}{}
+	e.walk(func(leaf *Expression) {
+		if _, ok := seen[leaf.License]; !ok {
+			seen[leaf.License] = struct{}{}
+			ret = append(ret, leaf.License)
+		}
+	})
+	return ret
+}
+
+// Validate checks that the licenses and exceptions in the expression are
+// in the SPDX lists or are license references. The returned error joins
+// all the problems found.
+func (e *Expression) Validate() error {
+	if e == nil {
+		return errors.New("expression is empty")
+	}
+	if !e.IsCompound() && (e.License == NoAssertion || e.License == None) {
+		return nil
+	}
+
+	var errs []error
+	e.walk(func(leaf *Expression) {
+		if err := leaf.validateLicense(); err != nil {
+			errs = append(errs, err)
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// validateLicense checks the license and exception of a simple expression
+// that is part of a larger one.
+func (e *Expression) validateLicense() error {
+	var errs []error
+	switch {
+	case e.License == NoAssertion || e.License == None:
+		errs = append(errs, fmt.Errorf("%s cannot be part of a compound expression", e.License))
+	case IsLicenseRef(e.License):
+	default:
+		if _, ok := Lookup(e.License); !ok {
+			errs = append(errs, fmt.Errorf("%w: %q", ErrUnknownLicense, e.License))
+		}
+	}
+	if e.Exception != "" {
+		if _, ok := LookupException(e.Exception); !ok {
+			errs = append(errs, fmt.Errorf("%w: %q", ErrUnknownException, e.Exception))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// walk calls fn with each simple expression in e
+func (e *Expression) walk(fn func(*Expression)) {
+	if e == nil {
+		return
+	}
+	if !e.IsCompound() {
+		fn(e)
+		return
+	}
+	for _, t := range e.Terms {
+		t.walk(fn)
+	}
+}
+
+// copy returns a deep copy of the expression
+func (e *Expression) copy() *Expression {
+	if e == nil {
+		return nil
+	}
+	c := *e
+	if e.Terms != nil {
+		c.Terms = make([]*Expression, len(e.Terms))
+		for i, t := range e.Terms {
+			c.Terms[i] = t.copy()
+		}
+	}
+	return &c
+}
+
+// Join returns a compound expression joining exprs with op. Nil expressions
+// are skipped and if only one remains it is returned as is.
+func Join(op Operator, exprs ...*Expression) *Expression {
+	terms := make([]*Expression, 0, len(exprs))
+	for _, e := range exprs {
+		if e != nil {
+			terms = append(terms, e)
+		}
+	}
+	switch len(terms) {
+	case 0:
+		return nil
+	case 1:
+		return terms[0]
+	}
+	return &Expression{Operator: op, Terms: terms}
+}
+
+// Parse parses an SPDX license expression. Parse only checks the syntax,
+// call Validate to check the identifiers against the SPDX lists. Operators
+// are accepted in any case.
+func Parse(s string) (*Expression, error) {
+	p := &parser{tokens: tokenize(s)}
+	if len(p.tokens) == 0 {
+		return nil, errors.New("license expression is empty")
+	}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("parsing license expression %q: %w", s, err)
+	}
+	if tok, ok := p.peek(); ok {
+		return nil, fmt.Errorf("parsing license expression %q: unexpected %q", s, tok)
+	}
+	return e, nil
+}
+
+// tokenize splits the expression in parentheses and words
+func tokenize(s string) []string {
+	tokens := []string{}
+	word := strings.Builder{}
+	flush := func() {
+		if word.Len() > 0 {
+			tokens = append(tokens, word.String())
+			word.Reset()
+		}
+	}
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')':
+			flush()
+			tokens = append(tokens, string(r))
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			flush()
+		default:
+			word.WriteRune(r)
+		}
+	}
+	flush()
+	return tokens
+}
+
+// parser is a recursive descent parser of license expressions. Precedence
+// from high to low is WITH, AND, OR.
+type parser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *parser) peek() (string, bool) {
+	if p.pos >= len(p.tokens) {
+		return "", false
+	}
+	return p.tokens[p.pos], true
+}
+
+// accept consumes the next token if it is the keyword kw
+func (p *parser) accept(kw string) bool {
+	if tok, ok := p.peek(); ok && strings.EqualFold(tok, kw) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) parseOr() (*Expression, error) {
+	return p.parseCompound(Or, p.parseAnd)
+}
+
+func (p *parser) parseAnd() (*Expression, error) {
+	return p.parseCompound(And, p.parseTerm)
+}
+
+// parseCompound parses a list of subexpressions joined by op
+func (p *parser) parseCompound(op Operator, next func() (*Expression, error)) (*Expression, error) {
+	first, err := next()
+	if err != nil {
+		return nil, err
+	}
+	terms := []*Expression{first}
+	for p.accept(string(op)) {
+		t, err := next()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, t)
+	}
+	return Join(op, terms...), nil
+}
+
+// parseTerm parses a parenthesized expression or a license with an
+// optional exception.
+func (p *parser) parseTerm() (*Expression, error) {
+	tok, ok := p.peek()
+	if !ok {
+		return nil, errors.New("unexpected end of expression")
+	}
+	if tok == "(" {
+		p.pos++
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(")") {
+			return nil, errors.New("missing closing parenthesis")
+		}
+		return e, nil
+	}
+
+	if err := checkIdentifier(tok); err != nil {
+		return nil, err
+	}
+	p.pos++
+	e := &Expression{License: tok}
+	if strings.HasSuffix(tok, "+") {
+		e.License = strings.TrimSuffix(tok, "+")
+		e.OrLater = true
+	}
+
+	if p.accept(with) {
+		exception, ok := p.peek()
+		if !ok {
+			return nil, errors.New("missing exception after WITH")
+		}
+		if err := checkIdentifier(exception); err != nil {
+			return nil, err
+		}
+		p.pos++
+		e.Exception = exception
+	}
+	return e, nil
+}
+
+// checkIdentifier checks that tok is a valid license or exception identifier
+func checkIdentifier(tok string) error {
+	for _, kw := range []string{string(And), string(Or), with, "(", ")"} {
+		if strings.EqualFold(tok, kw) {
+			return fmt.Errorf("unexpected %q", tok)
+		}
+	}
+
+	id := strings.TrimSuffix(tok, "+")
+	if strings.HasPrefix(id, documentRefPrefix) {
+		doc, ref, found := strings.Cut(id, ":")
+		if !found || !strings.HasPrefix(ref, licenseRefPrefix) {
+			return fmt.Errorf("invalid license reference %q", tok)
+		}
+		if !validIDString(strings.TrimPrefix(doc, documentRefPrefix)) || !validIDString(strings.TrimPrefix(ref, licenseRefPrefix)) {
+			return fmt.Errorf("invalid license reference %q", tok)
+		}
+		return nil
+	}
+	if !validIDString(id) {
+		return fmt.Errorf("invalid license identifier %q", tok)
+	}
+	return nil
+}
+
+// validIDString returns true if s is made only of letters, numbers, "." and "-"
+func validIDString(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != '.' && r != '-' {
+			return false
+		}
+	}
+	return true
+}
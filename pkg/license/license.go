@@ -0,0 +1,145 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2026 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+// Package license parses, validates and normalizes SPDX license expressions.
+// License identifiers are checked against a copy of the SPDX license list
+// embedded in the package. See https://spdx.org/licenses/ for the list and
+// https://spdx.github.io/spdx-spec/v2.3/SPDX-license-expressions/ for the
+// expression syntax.
+//
+// The package also evaluates the licenses of the nodes in a NodeList
+// against allow and deny lists of licenses and categories (see Policy).
+package license
+
+import (
+	_ "embed"
+	"encoding/json"
+	"strings"
+	"sync"
+)
+
+const (
+	// NoAssertion is used when no license information is asserted
+	NoAssertion = "NOASSERTION"
+
+	// None is used when there is no license
+	None = "NONE"
+
+	// licenseRefPrefix is the prefix of custom license identifiers
+	licenseRefPrefix = "LicenseRef-"
+
+	// documentRefPrefix is the prefix of licenses defined in other documents
+	documentRefPrefix = "DocumentRef-"
+)
+
+// The lists are kept in the format published in
+// https://github.com/spdx/license-list-data (json/licenses.json and
+// json/exceptions.json), stripped of the fields not used here.
+var (
+	//go:embed licenses.json
+	licensesJSON []byte
+
+	//go:embed exceptions.json
+	exceptionsJSON []byte
+)
+
+// Info describes an entry of the SPDX license or exception lists
+type Info struct {
+	// ID is the SPDX identifier of the license or exception
+	ID string
+
+	// Name is the full name of the license
+	Name string
+
+	// Deprecated is true when the identifier is deprecated in the list
+	Deprecated bool
+
+	// OSIApproved is true for licenses approved by the Open Source Initiative
+	OSIApproved bool
+}
+
+type licenseList struct {
+	version    string
+	licenses   map[string]*Info
+	exceptions map[string]*Info
+	names      map[string]*Info
+}
+
+var loadList = sync.OnceValue(func() *licenseList {
+	var licenses struct {
+		Version  string `json:"licenseListVersion"`
+		Licenses []struct {
+			ID          string `json:"licenseId"`
+			Name        string `json:"name"`
+			Deprecated  bool   `json:"isDeprecatedLicenseId"`
+			OSIApproved bool   `json:"isOsiApproved"`
+		} `json:"licenses"`
+	}
+	var exceptions struct {
+		Exceptions []struct {
+			ID         string `json:"licenseExceptionId"`
+			Deprecated bool   `json:"isDeprecatedLicenseId"`
+		} `json:"exceptions"`
+	}
+
+	// The lists are embedded at build time, a failure here is a bug
+	if err := json.Unmarshal(licensesJSON, &licenses); err != nil {
+		panic("parsing embedded SPDX license list: " + err.Error())
+	}
+	if err := json.Unmarshal(exceptionsJSON, &exceptions); err != nil {
+		panic("parsing embedded SPDX exception list: " + err.Error())
+	}
+
+	list := &licenseList{
+		version:    licenses.Version,
+		licenses:   make(map[string]*Info, len(licenses.Licenses)),
+		exceptions: make(map[string]*Info, len(exceptions.Exceptions)),
+		names:      make(map[string]*Info, len(licenses.Licenses)),
+	}
+	for _, l := range licenses.Licenses {
+		info := &Info{ID: l.ID, Name: l.Name, Deprecated: l.Deprecated, OSIApproved: l.OSIApproved}
+		list.licenses[strings.ToLower(l.ID)] = info
+		if !l.Deprecated {
+			list.names[strings.ToLower(l.Name)] = info
+		}
+	}
+	for _, e := range exceptions.Exceptions {
+		list.exceptions[strings.ToLower(e.ID)] = &Info{ID: e.ID, Deprecated: e.Deprecated}
+	}
+	return list
+})
+
+// ListVersion returns the version of the embedded SPDX license list
+func ListVersion() string {
+	return loadList().version
+}
+
+// Lookup returns the license identified by id in the SPDX license list.
+// Identifiers are matched case insensitively.
+func Lookup(id string) (*Info, bool) {
+	info, ok := loadList().licenses[strings.ToLower(id)]
+	return info, ok
+}
+
+// LookupException returns the exception identified by id in the SPDX
+// exception list. Identifiers are matched case insensitively.
+func LookupException(id string) (*Info, bool) {
+	info, ok := loadList().exceptions[strings.ToLower(id)]
+	return info, ok
+}
+
+// LookupName returns the license with the full name in the SPDX license
+// list (eg "Apache License 2.0"). Names are matched case insensitively.
+func LookupName(name string) (*Info, bool) {
+	info, ok := loadList().names[strings.ToLower(strings.TrimSpace(name))]
+	return info, ok
+}
+
+// IsLicenseRef returns true if id is a custom license reference, either
+// local (LicenseRef-) or from another document (DocumentRef-).
+func IsLicenseRef(id string) bool {
+	return strings.HasPrefix(id, licenseRefPrefix) || strings.HasPrefix(id, documentRefPrefix)
+}
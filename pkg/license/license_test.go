@@ -0,0 +1,161 @@
+package license
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+	info, ok := Lookup("apache-2.0")
+	require.True(t, ok)
+	require.Equal(t, "Apache-2.0", info.ID)
+	require.True(t, info.OSIApproved)
+
+	info, ok = Lookup("GPL-2.0")
+	require.True(t, ok)
+	require.True(t, info.Deprecated)
+
+	_, ok = Lookup("Not-A-License")
+	require.False(t, ok)
+
+	info, ok = LookupException("classpath-exception-2.0")
+	require.True(t, ok)
+	require.Equal(t, "Classpath-exception-2.0", info.ID)
+
+	info, ok = LookupName("apache license 2.0")
+	require.True(t, ok)
+	require.Equal(t, "Apache-2.0", info.ID)
+
+	require.NotEmpty(t, ListVersion())
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		input    string
+		expected string
+		mustErr  bool
+	}{
+		{"MIT", "MIT", false},
+		{"MIT OR Apache-2.0", "MIT OR Apache-2.0", false},
+		{"mit or apache-2.0", "mit OR apache-2.0", false},
+		{"MIT AND Apache-2.0 OR BSD-3-Clause", "(MIT AND Apache-2.0) OR BSD-3-Clause", false},
+		{"MIT AND (Apache-2.0 OR BSD-3-Clause)", "MIT AND (Apache-2.0 OR BSD-3-Clause)", false},
+		{"GPL-2.0+ WITH Classpath-exception-2.0", "GPL-2.0+ WITH Classpath-exception-2.0", false},
+		{"(MIT)", "MIT", false},
+		{"LicenseRef-custom OR DocumentRef-other:LicenseRef-1", "LicenseRef-custom OR DocumentRef-other:LicenseRef-1", false},
+		{"", "", true},
+		{"MIT OR", "", true},
+		{"(MIT OR Apache-2.0", "", true},
+		{"MIT Apache-2.0", "", true},
+		{"Apache License 2.0", "", true},
+		{"MIT WITH", "", true},
+		{"DocumentRef-other", "", true},
+		{"MIT/X11", "", true},
+	} {
+		e, err := Parse(tc.input)
+		if tc.mustErr {
+			require.Error(t, err, tc.input)
+			continue
+		}
+		require.NoError(t, err, tc.input)
+		require.Equal(t, tc.expected, e.String(), tc.input)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		input   string
+		mustErr error
+	}{
+		{"MIT OR Apache-2.0", nil},
+		{"GPL-2.0-only WITH Classpath-exception-2.0", nil},
+		{"LicenseRef-custom AND MIT", nil},
+		{"NOASSERTION", nil},
+		{"MIT OR Not-A-License", ErrUnknownLicense},
+		{"MIT WITH Not-An-Exception", ErrUnknownException},
+	} {
+		e, err := Parse(tc.input)
+		require.NoError(t, err)
+		if tc.mustErr != nil {
+			require.ErrorIs(t, e.Validate(), tc.mustErr, tc.input)
+			continue
+		}
+		require.NoError(t, e.Validate(), tc.input)
+	}
+
+	e, err := Parse("MIT OR NONE")
+	require.NoError(t, err)
+	require.Error(t, e.Validate())
+}
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		input    string
+		expected string
+	}{
+		{"mit", "MIT"},
+		{"apache-2.0 and mit", "Apache-2.0 AND MIT"},
+		{"GPL-2.0", "GPL-2.0-only"},
+		{"GPL-2.0+", "GPL-2.0-or-later"},
+		{"LGPL-2.1+ OR MIT", "LGPL-2.1-or-later OR MIT"},
+		{"GPL-2.0-with-classpath-exception", "GPL-2.0-only WITH Classpath-exception-2.0"},
+		{"GPL-2.0 WITH classpath-exception-2.0", "GPL-2.0-only WITH Classpath-exception-2.0"},
+		{"noassertion", "NOASSERTION"},
+		{"MIT OR MIT", "MIT"},
+		{"MIT OR (MIT AND Apache-2.0)", "MIT"},
+		{"(MIT OR Apache-2.0) OR (BSD-3-Clause OR mit)", "MIT OR Apache-2.0 OR BSD-3-Clause"},
+		{"MIT AND (Apache-2.0 AND MIT)", "MIT AND Apache-2.0"},
+		{"Unknown-License OR MIT", "Unknown-License OR MIT"},
+	} {
+		s, err := Normalize(tc.input)
+		require.NoError(t, err, tc.input)
+		require.Equal(t, tc.expected, s, tc.input)
+	}
+}
+
+func TestLicenses(t *testing.T) {
+	t.Parallel()
+	e, err := Parse("MIT AND (Apache-2.0 OR MIT) AND GPL-2.0-only WITH Classpath-exception-2.0")
+	require.NoError(t, err)
+	require.Equal(t, []string{"MIT", "Apache-2.0", "GPL-2.0-only"}, e.Licenses())
+}
+
+func TestJoin(t *testing.T) {
+	t.Parallel()
+	require.Nil(t, Join(Or))
+	mit := &Expression{License: "MIT"}
+	require.Equal(t, mit, Join(And, nil, mit))
+	require.Equal(t, "MIT OR Apache-2.0", Join(Or, mit, &Expression{License: "Apache-2.0"}).String())
+}
+
+func TestRefSet(t *testing.T) {
+	t.Parallel()
+	rs := NewRefSet()
+	require.Nil(t, rs.Expression(" "))
+	require.Equal(t, "GPL-2.0-only OR MIT", rs.Expression("gpl-2.0 or mit").String())
+	require.Equal(t, "Apache-2.0", rs.Expression("Apache License 2.0").String())
+	require.Equal(t, "NOASSERTION", rs.Expression("NOASSERTION").String())
+	require.Empty(t, rs.Refs())
+
+	require.Equal(t, "LicenseRef-My-Custom-License", rs.Expression("My Custom License").String())
+	require.Equal(t, "LicenseRef-Custom-1.0 AND MIT", rs.Expression("Custom-1.0 AND MIT").String())
+	require.Equal(t, "LicenseRef-MIT-WITH-Bad-exception", rs.Expression("MIT WITH Bad-exception").String())
+
+	// Repeated texts reuse the reference and clashing IDs get a suffix
+	require.Equal(t, "LicenseRef-My-Custom-License", rs.Expression("My Custom License").String())
+	require.Equal(t, "LicenseRef-My-Custom-License-2", rs.Expression("My Custom License!").String())
+	require.Equal(t, "LicenseRef-existing OR MIT", rs.Expression("LicenseRef-existing OR MIT").String())
+
+	require.Equal(t, []Ref{
+		{ID: "LicenseRef-My-Custom-License", Text: "My Custom License"},
+		{ID: "LicenseRef-Custom-1.0", Text: "Custom-1.0"},
+		{ID: "LicenseRef-MIT-WITH-Bad-exception", Text: "MIT WITH Bad-exception"},
+		{ID: "LicenseRef-My-Custom-License-2", Text: "My Custom License!"},
+		{ID: "LicenseRef-existing"},
+	}, rs.Refs())
+}
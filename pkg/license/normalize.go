@@ -0,0 +1,162 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2026 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package license
+
+import (
+	"strings"
+)
+
+// deprecatedReplacements maps deprecated license identifiers to the
+// expressions that replace them in the current SPDX license list. The
+// deprecated "+" identifiers are keyed with the suffix.
+var deprecatedReplacements = map[string]string{
+	"AGPL-1.0":                         "AGPL-1.0-only",
+	"AGPL-3.0":                         "AGPL-3.0-only",
+	"BSD-2-Clause-FreeBSD":             "BSD-2-Clause",
+	"BSD-2-Clause-NetBSD":              "BSD-2-Clause",
+	"bzip2-1.0.5":                      "bzip2-1.0.6",
+	"eCos-2.0":                         "GPL-2.0-or-later WITH eCos-exception-2.0",
+	"GFDL-1.1":                         "GFDL-1.1-only",
+	"GFDL-1.2":                         "GFDL-1.2-only",
+	"GFDL-1.3":                         "GFDL-1.3-only",
+	"GPL-1.0":                          "GPL-1.0-only",
+	"GPL-1.0+":                         "GPL-1.0-or-later",
+	"GPL-2.0":                          "GPL-2.0-only",
+	"GPL-2.0+":                         "GPL-2.0-or-later",
+	"GPL-2.0-with-autoconf-exception":  "GPL-2.0-only WITH Autoconf-exception-2.0",
+	"GPL-2.0-with-bison-exception":     "GPL-2.0-only WITH Bison-exception-2.2",
+	"GPL-2.0-with-classpath-exception": "GPL-2.0-only WITH Classpath-exception-2.0",
+	"GPL-2.0-with-font-exception":      "GPL-2.0-only WITH Font-exception-2.0",
+	"GPL-2.0-with-GCC-exception":       "GPL-2.0-only WITH GCC-exception-2.0",
+	"GPL-3.0":                          "GPL-3.0-only",
+	"GPL-3.0+":                         "GPL-3.0-or-later",
+	"GPL-3.0-with-autoconf-exception":  "GPL-3.0-only WITH Autoconf-exception-3.0",
+	"GPL-3.0-with-GCC-exception":       "GPL-3.0-only WITH GCC-exception-3.1",
+	"LGPL-2.0":                         "LGPL-2.0-only",
+	"LGPL-2.0+":                        "LGPL-2.0-or-later",
+	"LGPL-2.1":                         "LGPL-2.1-only",
+	"LGPL-2.1+":                        "LGPL-2.1-or-later",
+	"LGPL-3.0":                         "LGPL-3.0-only",
+	"LGPL-3.0+":                        "LGPL-3.0-or-later",
+	"Nunit":                            "zlib-acknowledgement",
+	"StandardML-NJ":                    "SMLNJ",
+	"wxWindows":                        "GPL-2.0-or-later WITH WxWindows-exception-3.1",
+}
+
+// Normalize returns a copy of the expression with the identifiers in the
+// casing of the SPDX lists and the deprecated licenses replaced by their
+// current equivalents. Unknown identifiers are left untouched.
+func (e *Expression) Normalize() *Expression {
+	if e == nil {
+		return nil
+	}
+	if e.IsCompound() {
+		c := &Expression{Operator: e.Operator, Terms: make([]*Expression, len(e.Terms))}
+		for i, t := range e.Terms {
+			c.Terms[i] = t.Normalize()
+		}
+		return c
+	}
+
+	c := e.copy()
+	if strings.EqualFold(c.License, NoAssertion) || strings.EqualFold(c.License, None) {
+		c.License = strings.ToUpper(c.License)
+		return c
+	}
+	if info, ok := Lookup(c.License); ok {
+		c.License = info.ID
+	}
+	if c.Exception != "" {
+		if info, ok := LookupException(c.Exception); ok {
+			c.Exception = info.ID
+		}
+	}
+
+	key := c.License
+	if c.OrLater {
+		key += "+"
+	}
+	replacement, ok := deprecatedReplacements[key]
+	if !ok {
+		return c
+	}
+	// Replacements are fixed strings known to parse
+	r, err := Parse(replacement)
+	if err != nil {
+		return c
+	}
+	if c.OrLater && !strings.HasSuffix(key, "+") {
+		r.OrLater = true
+	}
+	if c.Exception != "" {
+		r.Exception = c.Exception
+	}
+	return r
+}
+
+// Simplify returns an equivalent copy of the expression with nested terms
+// with the same operator merged, duplicate terms removed and terms absorbed
+// by others dropped (eg "MIT OR (MIT AND Apache-2.0)" becomes "MIT"). The
+// order of the terms is preserved.
+func (e *Expression) Simplify() *Expression {
+	if e == nil || !e.IsCompound() {
+		return e.copy()
+	}
+
+	// Flatten the simplified terms that share the operator
+	terms := []*Expression{}
+	for _, t := range e.Terms {
+		st := t.Simplify()
+		if st.Operator == e.Operator {
+			terms = append(terms, st.Terms...)
+		} else {
+			terms = append(terms, st)
+		}
+	}
+
+	// Remove duplicates
+	seen := map[string]struct{}{}
+	unique := []*Expression{}
+	for _, t := range terms {
+		key := t.String()
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		unique = append(unique, t)
+	}
+
+	// Absorption: drop compound terms with the other operator that
+	// contain one of the terms at this level.
+	kept := []*Expression{}
+	for _, t := range unique {
+		absorbed := false
+		if t.IsCompound() {
+			for _, sub := range t.Terms {
+				if _, ok := seen[sub.String()]; ok {
+					absorbed = true
+					break
+				}
+			}
+		}
+		if !absorbed {
+			kept = append(kept, t)
+		}
+	}
+
+	return Join(e.Operator, kept...)
+}
+
+// Normalize parses the license expression in s and returns it normalized
+// and simplified.
+func Normalize(s string) (string, error) {
+	e, err := Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return e.Normalize().Simplify().String(), nil
+}
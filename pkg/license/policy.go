@@ -0,0 +1,287 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2026 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package license
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// Reason explains why a node breaks a license policy
+type Reason string
+
+const (
+	// ReasonDenied is reported when the licenses are in the deny lists
+	ReasonDenied Reason = "denied"
+
+	// ReasonNotAllowed is reported when the licenses are not in the allow lists
+	ReasonNotAllowed Reason = "not-allowed"
+
+	// ReasonNoLicense is reported for nodes without license information
+	// when the policy requires it.
+	ReasonNoLicense Reason = "no-license"
+)
+
+// RuntimeEdgeTypes are the relationships followed when a policy is
+// restricted to the runtime dependencies.
+var RuntimeEdgeTypes = []sbom.Edge_Type{
+	sbom.Edge_contains,
+	sbom.Edge_dependsOn,
+	sbom.Edge_runtimeDependency,
+	sbom.Edge_dynamicLink,
+	sbom.Edge_staticLink,
+}
+
+// Policy defines the licenses allowed in the nodes of an SBOM. Licenses are
+// matched by their SPDX identifier or by the full simple expression when
+// it has an exception (eg "GPL-2.0-only WITH Classpath-exception-2.0").
+// Denied licenses take precedence over allowed ones.
+type Policy struct {
+	// AllowLicenses are the licenses allowed. When there are no allowed
+	// licenses or categories, any license not denied is allowed.
+	AllowLicenses []string
+
+	// AllowCategories are the license categories allowed
+	AllowCategories []Category
+
+	// DenyLicenses are the licenses that break the policy
+	DenyLicenses []string
+
+	// DenyCategories are the license categories that break the policy
+	DenyCategories []Category
+
+	// RequireLicense makes nodes without license data break the policy
+	RequireLicense bool
+
+	// RuntimeOnly restricts the evaluation to the nodes reachable from the
+	// root nodes through the RuntimeEdgeTypes.
+	RuntimeOnly bool
+
+	// Operator joins the declared licenses of a node when it has no
+	// concluded license. Defaults to AND.
+	Operator Operator
+}
+
+// Violation is a node that breaks a license policy
+type Violation struct {
+	// Node is the node breaking the policy
+	Node *sbom.Node
+
+	// Expression is the license expression evaluated
+	Expression string
+
+	// Licenses are the licenses of the expression that break the policy
+	Licenses []string
+
+	// Reason explains why the node breaks the policy
+	Reason Reason
+
+	// Path lists the IDs of the nodes from a root node to the node. Nodes
+	// not reachable from a root have a path with only their ID.
+	Path []string
+}
+
+// Evaluate checks the licenses of the nodes in nl and returns the nodes that
+// break the policy. The license of a node is its concluded license or, when
+// not set, its declared licenses joined with the policy operator.
+func (p *Policy) Evaluate(nl *sbom.NodeList) []*Violation {
+	violations := []*Violation{}
+	if nl == nil {
+		return violations
+	}
+
+	paths := p.nodePaths(nl)
+	for _, n := range nl.GetNodes() {
+		path, ok := paths[n.GetId()]
+		if !ok {
+			if p.RuntimeOnly {
+				continue
+			}
+			path = []string{n.GetId()}
+		}
+
+		if v := p.evaluateNode(n); v != nil {
+			v.Path = path
+			violations = append(violations, v)
+		}
+	}
+	return violations
+}
+
+// Check evaluates a license expression. It returns true if it complies with
+// the policy or false and the licenses that break it.
+func (p *Policy) Check(e *Expression) (bool, []string) {
+	if e == nil {
+		return true, nil
+	}
+	ok, offending := p.check(e.Normalize())
+	if ok {
+		return true, nil
+	}
+	ids := []string{}
+	for _, o := range offending {
+		if s := o.String(); !slices.Contains(ids, s) {
+			ids = append(ids, s)
+		}
+	}
+	return false, ids
+}
+
+// check returns the simple expressions that make e break the policy. The
+// terms of AND expressions must all comply while OR expressions need only one.
+func (p *Policy) check(e *Expression) (bool, []*Expression) {
+	if !e.IsCompound() {
+		if p.denied(e) || !p.allowed(e) {
+			return false, []*Expression{e}
+		}
+		return true, nil
+	}
+
+	offending := []*Expression{}
+	for _, t := range e.Terms {
+		ok, o := p.check(t)
+		if ok && e.Operator == Or {
+			return true, nil
+		}
+		offending = append(offending, o...)
+	}
+	return len(offending) == 0, offending
+}
+
+// denied returns true if the simple expression is in the deny lists
+func (p *Policy) denied(e *Expression) bool {
+	return matchLicense(p.DenyLicenses, e) || slices.Contains(p.DenyCategories, e.Category())
+}
+
+// allowed returns true if the simple expression is in the allow lists or
+// if the lists are empty.
+func (p *Policy) allowed(e *Expression) bool {
+	if len(p.AllowLicenses) == 0 && len(p.AllowCategories) == 0 {
+		return true
+	}
+	return matchLicense(p.AllowLicenses, e) || slices.Contains(p.AllowCategories, e.Category())
+}
+
+// matchLicense returns true if the license of e or the whole expression,
+// when it has an exception, is in the list.
+func matchLicense(list []string, e *Expression) bool {
+	for _, l := range list {
+		if strings.EqualFold(l, e.License) || (e.Exception != "" && strings.EqualFold(l, e.String())) {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluateNode returns the violation of the policy by the node, if any
+func (p *Policy) evaluateNode(n *sbom.Node) *Violation {
+	e := p.nodeExpression(n)
+	if e == nil || (!e.IsCompound() && (e.License == NoAssertion || e.License == None)) {
+		if !p.RequireLicense {
+			return nil
+		}
+		return &Violation{Node: n, Expression: e.String(), Reason: ReasonNoLicense}
+	}
+
+	ok, licenses := p.Check(e)
+	if ok {
+		return nil
+	}
+
+	reason := ReasonNotAllowed
+	e.walk(func(leaf *Expression) {
+		if slices.Contains(licenses, leaf.String()) && p.denied(leaf) {
+			reason = ReasonDenied
+		}
+	})
+	return &Violation{Node: n, Expression: e.String(), Licenses: licenses, Reason: reason}
+}
+
+// nodeExpression returns the normalized license expression of the node.
+// Licenses that are not valid expressions are kept verbatim so they can
+// only be matched literally. NOASSERTION and NONE entries are left out of
+// the expression, when there is nothing else they are returned alone.
+func (p *Policy) nodeExpression(n *sbom.Node) *Expression {
+	licenses := n.GetLicenses()
+	if c := n.GetLicenseConcluded(); c != "" && !strings.EqualFold(c, NoAssertion) {
+		licenses = []string{c}
+	}
+
+	op := p.Operator
+	if op == "" {
+		op = And
+	}
+
+	var placeholder *Expression
+	exprs := []*Expression{}
+	for _, l := range licenses {
+		l = strings.TrimSpace(l)
+		if l == "" {
+			continue
+		}
+
+		var e *Expression
+		if parsed, err := Parse(l); err == nil {
+			e = parsed.Normalize()
+		} else if info, ok := LookupName(l); ok {
+			e = &Expression{License: info.ID}
+		} else {
+			e = &Expression{License: l}
+		}
+
+		if !e.IsCompound() && (e.License == NoAssertion || e.License == None) {
+			if placeholder == nil {
+				placeholder = e
+			}
+			continue
+		}
+		exprs = append(exprs, e)
+	}
+
+	if len(exprs) == 0 {
+		return placeholder
+	}
+	return Join(op, exprs...).Simplify()
+}
+
+// nodePaths returns the shortest path from a root node to each node
+// reachable from the roots.
+func (p *Policy) nodePaths(nl *sbom.NodeList) map[string][]string {
+	children := map[string][]string{}
+	for _, e := range nl.GetEdges() {
+		if p.RuntimeOnly && !slices.Contains(RuntimeEdgeTypes, e.GetType()) {
+			continue
+		}
+		children[e.GetFrom()] = append(children[e.GetFrom()], e.GetTo()...)
+	}
+
+	paths := map[string][]string{}
+	queue := []string{}
+	for _, id := range nl.GetRootElements() {
+		if _, ok := paths[id]; !ok {
+			paths[id] = []string{id}
+			queue = append(queue, id)
+		}
+	}
+
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, child := range children[id] {
+			if _, ok := paths[child]; ok {
+				continue
+			}
+			path := make([]string, len(paths[id]), len(paths[id])+1)
+			copy(path, paths[id])
+			paths[child] = append(path, child)
+			queue = append(queue, child)
+		}
+	}
+	return paths
+}
@@ -0,0 +1,142 @@
+package license
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+func TestCategorize(t *testing.T) {
+	t.Parallel()
+	for id, expected := range map[string]Category{
+		"MIT":               CategoryPermissive,
+		"apache-2.0":        CategoryPermissive,
+		"BSD-3-Clause":      CategoryPermissive,
+		"LGPL-2.1-only":     CategoryWeakCopyleft,
+		"MPL-2.0":           CategoryWeakCopyleft,
+		"GPL-3.0-or-later":  CategoryCopyleft,
+		"AGPL-3.0-only":     CategoryCopyleft,
+		"CC-BY-SA-4.0":      CategoryCopyleft,
+		"CECILL-2.1":        CategoryCopyleft,
+		"CECILL-B":          CategoryPermissive,
+		"CECILL-C":          CategoryWeakCopyleft,
+		"BSD-Protection":    CategoryCopyleft,
+		"LicenseRef-custom": CategoryUnknown,
+		"Not-A-License":     CategoryUnknown,
+		"Beerware":          CategoryUnknown,
+	} {
+		require.Equal(t, expected, Categorize(id), id)
+	}
+
+	e, err := Parse("GPL-2.0-only WITH Classpath-exception-2.0")
+	require.NoError(t, err)
+	require.Equal(t, CategoryWeakCopyleft, e.Category())
+}
+
+func TestPolicyCheck(t *testing.T) {
+	t.Parallel()
+	policy := &Policy{
+		AllowCategories: []Category{CategoryPermissive, CategoryWeakCopyleft},
+		AllowLicenses:   []string{"LicenseRef-approved"},
+		DenyLicenses:    []string{"WTFPL"},
+	}
+	for _, tc := range []struct {
+		expression string
+		compliant  bool
+		offending  []string
+	}{
+		{"MIT", true, nil},
+		{"MIT AND LGPL-2.1-only", true, nil},
+		{"GPL-2.0-only OR MIT", true, nil},
+		{"LicenseRef-approved", true, nil},
+		{"GPL-2.0-only WITH Classpath-exception-2.0", true, nil},
+		{"GPL-2.0-only", false, []string{"GPL-2.0-only"}},
+		{"MIT AND GPL-2.0+", false, []string{"GPL-2.0-or-later"}},
+		{"GPL-3.0-only OR AGPL-3.0-only", false, []string{"GPL-3.0-only", "AGPL-3.0-only"}},
+		{"WTFPL", false, []string{"WTFPL"}},
+		{"LicenseRef-other", false, []string{"LicenseRef-other"}},
+	} {
+		e, err := Parse(tc.expression)
+		require.NoError(t, err)
+		ok, offending := policy.Check(e)
+		require.Equal(t, tc.compliant, ok, tc.expression)
+		require.Equal(t, tc.offending, offending, tc.expression)
+	}
+}
+
+func TestPolicyEvaluate(t *testing.T) {
+	t.Parallel()
+	nl := sbom.NewNodeList()
+	nl.AddRootNode(&sbom.Node{Id: "app", Licenses: []string{"Apache-2.0"}})
+	nl.AddNode(&sbom.Node{Id: "lib", Licenses: []string{"MIT"}})
+	nl.AddNode(&sbom.Node{Id: "gpl", Licenses: []string{"MIT", "GPL-3.0-only"}})
+	nl.AddNode(&sbom.Node{Id: "denied", LicenseConcluded: "AGPL-3.0-only", Licenses: []string{"MIT"}})
+	nl.AddNode(&sbom.Node{Id: "test-tool", Licenses: []string{"GPL-2.0-only"}})
+	nl.AddNode(&sbom.Node{Id: "unlicensed", LicenseConcluded: NoAssertion})
+	nl.AddNode(&sbom.Node{Id: "orphan", Licenses: []string{"Custom License"}})
+	nl.AddEdge(&sbom.Edge{Type: sbom.Edge_dependsOn, From: "app", To: []string{"lib", "test-tool"}})
+	nl.AddEdge(&sbom.Edge{Type: sbom.Edge_dependsOn, From: "lib", To: []string{"gpl", "unlicensed"}})
+	nl.AddEdge(&sbom.Edge{Type: sbom.Edge_runtimeDependency, From: "gpl", To: []string{"denied"}})
+	nl.AddEdge(&sbom.Edge{Type: sbom.Edge_testDependency, From: "app", To: []string{"test-tool"}})
+
+	policy := &Policy{
+		AllowCategories: []Category{CategoryPermissive},
+		DenyLicenses:    []string{"AGPL-3.0-only"},
+		RequireLicense:  true,
+	}
+
+	byID := func(violations []*Violation) map[string]*Violation {
+		ret := map[string]*Violation{}
+		for _, v := range violations {
+			ret[v.Node.GetId()] = v
+		}
+		return ret
+	}
+
+	violations := byID(policy.Evaluate(nl))
+	require.Len(t, violations, 5)
+	require.Equal(t, ReasonNotAllowed, violations["gpl"].Reason)
+	require.Equal(t, []string{"GPL-3.0-only"}, violations["gpl"].Licenses)
+	require.Equal(t, "MIT AND GPL-3.0-only", violations["gpl"].Expression)
+	require.Equal(t, []string{"app", "lib", "gpl"}, violations["gpl"].Path)
+	require.Equal(t, ReasonDenied, violations["denied"].Reason)
+	require.Equal(t, []string{"app", "lib", "gpl", "denied"}, violations["denied"].Path)
+	require.Equal(t, ReasonNoLicense, violations["unlicensed"].Reason)
+	require.Equal(t, []string{"Custom License"}, violations["orphan"].Licenses)
+	require.Equal(t, []string{"orphan"}, violations["orphan"].Path)
+	require.Contains(t, violations, "test-tool")
+
+	// Joining the declared licenses with OR lets the gpl node comply
+	policy.Operator = Or
+	require.NotContains(t, byID(policy.Evaluate(nl)), "gpl")
+
+	// Restricting to runtime edges skips orphans but follows dependsOn,
+	// which also reaches test-tool.
+	policy.RuntimeOnly = true
+	violations = byID(policy.Evaluate(nl))
+	require.NotContains(t, violations, "orphan")
+	require.Contains(t, violations, "denied")
+
+	nl.GetEdges()[0].To = []string{"lib"}
+	require.NotContains(t, byID(policy.Evaluate(nl)), "test-tool")
+}
+
+func TestPolicyEvaluateNoAssertion(t *testing.T) {
+	t.Parallel()
+	nl := sbom.NewNodeList()
+	nl.AddRootNode(&sbom.Node{Id: "app", Licenses: []string{"MIT", "NOASSERTION"}})
+	nl.AddNode(&sbom.Node{Id: "lib", Licenses: []string{"NONE", "NOASSERTION"}})
+	nl.AddEdge(&sbom.Edge{Type: sbom.Edge_dependsOn, From: "app", To: []string{"lib"}})
+
+	policy := &Policy{AllowLicenses: []string{"MIT"}}
+	require.Empty(t, policy.Evaluate(nl))
+
+	// Nodes with only placeholders have no license
+	policy.RequireLicense = true
+	violations := policy.Evaluate(nl)
+	require.Len(t, violations, 1)
+	require.Equal(t, "lib", violations[0].Node.GetId())
+	require.Equal(t, ReasonNoLicense, violations[0].Reason)
+}
@@ -0,0 +1,140 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2026 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package license
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Ref is a license not in the SPDX license list. Formats that support
+// custom licenses reference it in expressions by its LicenseRef ID.
+type Ref struct {
+	// ID is the LicenseRef- identifier of the license
+	ID string
+
+	// Text is the original license string. It is empty for the LicenseRefs
+	// that were already in the license strings.
+	Text string
+}
+
+// RefSet turns license strings into valid expressions, collecting the
+// licenses not in the SPDX list as LicenseRef entries.
+type RefSet struct {
+	refs   []Ref
+	byText map[string]string
+	ids    map[string]struct{}
+}
+
+// NewRefSet returns an empty RefSet
+func NewRefSet() *RefSet {
+	return &RefSet{
+		refs:   []Ref{},
+		byText: map[string]string{},
+		ids:    map[string]struct{}{},
+	}
+}
+
+// Expression returns a valid normalized expression for the license string s:
+//
+//   - Valid expressions are returned normalized.
+//   - Full license names in the SPDX list are replaced by their identifier.
+//   - Unknown license identifiers in expressions are replaced by LicenseRefs.
+//   - Any other string is replaced by a LicenseRef.
+//
+// LicenseRefs already in s are recorded in the set without text.
+//
+// Returns nil if s is empty.
+func (rs *RefSet) Expression(s string) *Expression {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+
+	e, err := Parse(s)
+	if err != nil {
+		if info, ok := LookupName(s); ok {
+			return &Expression{License: info.ID}
+		}
+		return &Expression{License: rs.add(s)}
+	}
+
+	e = e.Normalize()
+
+	// Replace the licenses that fail validation, except NOASSERTION
+	// and NONE which have no LicenseRef equivalent.
+	e.walk(func(leaf *Expression) {
+		if strings.HasPrefix(leaf.License, licenseRefPrefix) {
+			rs.addExisting(leaf.License)
+		}
+		if leaf.License == NoAssertion || leaf.License == None || leaf.validateLicense() == nil {
+			return
+		}
+		leaf.License = rs.add(leaf.String())
+		leaf.OrLater = false
+		leaf.Exception = ""
+	})
+	return e
+}
+
+// Refs returns the LicenseRefs created, in the order they were added
+func (rs *RefSet) Refs() []Ref {
+	return rs.refs
+}
+
+// add registers text as a custom license and returns its LicenseRef ID
+func (rs *RefSet) add(text string) string {
+	if id, ok := rs.byText[text]; ok {
+		return id
+	}
+
+	base := licenseRefPrefix + sanitizeIDString(text)
+	id := base
+	for i := 2; ; i++ {
+		if _, ok := rs.ids[id]; !ok {
+			break
+		}
+		id = fmt.Sprintf("%s-%d", base, i)
+	}
+
+	rs.ids[id] = struct{}{}
+	rs.byText[text] = id
+	rs.refs = append(rs.refs, Ref{ID: id, Text: text})
+	return id
+}
+
+// addExisting records a LicenseRef found in a license string
+func (rs *RefSet) addExisting(id string) {
+	if _, ok := rs.ids[id]; ok {
+		return
+	}
+	rs.ids[id] = struct{}{}
+	rs.refs = append(rs.refs, Ref{ID: id})
+}
+
+// sanitizeIDString replaces the characters not allowed in identifiers
+// with dashes.
+func sanitizeIDString(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range s {
+		if validIDString(string(r)) && r != '-' {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && b.Len() > 0 {
+			b.WriteRune('-')
+			dash = true
+		}
+	}
+	id := strings.TrimSuffix(b.String(), "-")
+	if id == "" {
+		return "unknown"
+	}
+	return id
+}
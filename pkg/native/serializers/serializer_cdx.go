@@ -14,6 +14,7 @@ import (
 	"github.com/google/uuid"
 
 	cdxformats "github.com/protobom/protobom/pkg/formats/cyclonedx"
+	"github.com/protobom/protobom/pkg/license"
 	"github.com/protobom/protobom/pkg/mod"
 	"github.com/protobom/protobom/pkg/native"
 	"github.com/protobom/protobom/pkg/sbom"
@@ -449,17 +450,7 @@ func (s *CDX) nodeToComponent(n *sbom.Node) *cdx.Component {
 	}
 
 	if len(n.Licenses) > 0 {
-		var licenseChoices []cdx.LicenseChoice
-		var licenses cdx.Licenses
-		for _, l := range n.Licenses {
-			licenseChoices = append(licenseChoices, cdx.LicenseChoice{
-				License: &cdx.License{
-					ID: l,
-				},
-			})
-		}
-
-		licenses = licenseChoices
+		licenses := licenseChoices(n.Licenses)
 		c.Licenses = &licenses
 	}
 
@@ -673,6 +664,41 @@ func (s *CDX) protobomExtRefTypeToCdxType(protoExtRefType sbom.ExternalReference
 	}
 }
 
+// licenseChoices converts the node licenses to CycloneDX license choices.
+// SPDX license identifiers are written as license IDs and any other string
+// as a license name. CycloneDX does not allow mixing licenses and
+// expressions so, if one of the licenses is an expression, all of them are
+// joined in a single one.
+func licenseChoices(licenses []string) cdx.Licenses {
+	refs := license.NewRefSet()
+	exprs := []*license.Expression{}
+	texts := []string{}
+	compound := false
+	for _, l := range licenses {
+		e := refs.Expression(l)
+		if e == nil {
+			continue
+		}
+		compound = compound || e.IsCompound() || e.OrLater || e.Exception != ""
+		exprs = append(exprs, e)
+		texts = append(texts, strings.TrimSpace(l))
+	}
+
+	if compound {
+		return cdx.Licenses{{Expression: license.Join(license.Or, exprs...).Simplify().String()}}
+	}
+
+	choices := cdx.Licenses{}
+	for i, e := range exprs {
+		if _, ok := license.Lookup(e.License); ok {
+			choices = append(choices, cdx.LicenseChoice{License: &cdx.License{ID: e.License}})
+			continue
+		}
+		choices = append(choices, cdx.LicenseChoice{License: &cdx.License{Name: texts[i]}})
+	}
+	return choices
+}
+
 // protoHashAlgoToCdxAlgo converts the protobom algorithm to the CDX
 // algorithm string.
 // TODO(degradation): The use of the following algorithms will result in
@@ -252,3 +252,45 @@ func TestPurposeToComponentType(t *testing.T) {
 		require.Equal(t, cdxType, res)
 	}
 }
+
+func TestLicenseChoices(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		licenses []string
+		expected cdx.Licenses
+	}{
+		{
+			name:     "spdx-ids",
+			licenses: []string{"mit", "Apache-2.0"},
+			expected: cdx.Licenses{
+				{License: &cdx.License{ID: "MIT"}},
+				{License: &cdx.License{ID: "Apache-2.0"}},
+			},
+		},
+		{
+			name:     "custom-names",
+			licenses: []string{"MIT", "Custom License", "Apache License 2.0"},
+			expected: cdx.Licenses{
+				{License: &cdx.License{ID: "MIT"}},
+				{License: &cdx.License{Name: "Custom License"}},
+				{License: &cdx.License{ID: "Apache-2.0"}},
+			},
+		},
+		{
+			name:     "expressions",
+			licenses: []string{"MIT", "(Apache-2.0 and BSD-3-Clause)"},
+			expected: cdx.Licenses{
+				{Expression: "MIT OR (Apache-2.0 AND BSD-3-Clause)"},
+			},
+		},
+		{
+			name:     "deprecated-ids",
+			licenses: []string{"GPL-2.0+"},
+			expected: cdx.Licenses{{License: &cdx.License{ID: "GPL-2.0-or-later"}}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, licenseChoices(tc.licenses))
+		})
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"sigs.k8s.io/release-utils/version"
 
 	protospdx "github.com/protobom/protobom/pkg/formats/spdx"
+	"github.com/protobom/protobom/pkg/license"
 	"github.com/protobom/protobom/pkg/mod"
 	"github.com/protobom/protobom/pkg/native"
 	"github.com/protobom/protobom/pkg/sbom"
@@ -158,7 +159,7 @@ func (s *SPDX23) Serialize(bom *sbom.Document, serializeopts *native.SerializeOp
 		DocumentComment:   bom.Metadata.Comment,
 
 		CreationInfo: &spdx.CreationInfo{
-			LicenseListVersion: "3.20", // https://spdx.org/licenses/
+			LicenseListVersion: license.ListVersion(), // https://spdx.org/licenses/
 			Creators: []spdx.Creator{
 				// Register protobom as one of the document creation tools
 				{
@@ -213,12 +214,16 @@ func (s *SPDX23) Serialize(bom *sbom.Document, serializeopts *native.SerializeOp
 		})
 	}
 
-	packages, err := s.buildPackages(serializeopts, opts, bom)
+	// Licenses not in the SPDX list are collected to add them to the
+	// document as other licenses.
+	refs := license.NewRefSet()
+
+	packages, err := s.buildPackages(serializeopts, opts, bom, refs)
 	if err != nil {
 		return nil, fmt.Errorf("building SPDX packages: %w", err)
 	}
 
-	files, err := buildFiles(bom)
+	files, err := buildFiles(bom, refs)
 	if err != nil {
 		return nil, fmt.Errorf("building SPDX file list: %w", err)
 	}
@@ -243,6 +248,7 @@ func (s *SPDX23) Serialize(bom *sbom.Document, serializeopts *native.SerializeOp
 	doc.Packages = packages
 	doc.Files = files
 	doc.Relationships = rels
+	doc.OtherLicenses = buildOtherLicenses(refs)
 
 	return doc, nil
 }
@@ -263,7 +269,38 @@ func buildRelationships(bom *sbom.Document) ([]*spdx.Relationship, error) { //no
 	return relationships, nil
 }
 
-func buildFiles(bom *sbom.Document) ([]*spdx.File, error) { //nolint:unparam
+// licenseExpression returns a valid SPDX license expression joining the
+// license strings with op. Licenses not in the SPDX license list are
+// replaced with LicenseRefs registered in refs.
+func licenseExpression(refs *license.RefSet, op license.Operator, licenses ...string) string {
+	exprs := make([]*license.Expression, 0, len(licenses))
+	for _, l := range licenses {
+		exprs = append(exprs, refs.Expression(l))
+	}
+	return license.Join(op, exprs...).Simplify().String()
+}
+
+// buildOtherLicenses returns the SPDX other licenses entries for the
+// LicenseRefs in the document expressions. The extracted text of licenses
+// known only by their reference is NOASSERTION.
+func buildOtherLicenses(refs *license.RefSet) []*v2_3.OtherLicense {
+	others := []*v2_3.OtherLicense{}
+	for _, ref := range refs.Refs() {
+		other := &v2_3.OtherLicense{
+			LicenseIdentifier: ref.ID,
+			ExtractedText:     ref.Text,
+			LicenseName:       ref.Text,
+		}
+		if ref.Text == "" {
+			other.ExtractedText = protospdx.NOASSERTION
+			other.LicenseName = protospdx.NOASSERTION
+		}
+		others = append(others, other)
+	}
+	return others
+}
+
+func buildFiles(bom *sbom.Document, refs *license.RefSet) ([]*spdx.File, error) { //nolint:unparam
 	files := []*spdx.File{}
 	for _, node := range bom.NodeList.Nodes {
 		if node.Type == sbom.Node_PACKAGE {
@@ -275,7 +312,7 @@ func buildFiles(bom *sbom.Document) ([]*spdx.File, error) { //nolint:unparam
 			FileSPDXIdentifier: common.ElementID(node.Id),
 			FileTypes:          node.FileTypes,
 			Checksums:          []common.Checksum{},
-			LicenseConcluded:   node.LicenseConcluded,
+			LicenseConcluded:   licenseExpression(refs, license.And, node.LicenseConcluded),
 			// LicenseInfoInFiles:   []string{}, << bug in SPDX
 			LicenseComments:   node.LicenseComments,
 			FileCopyrightText: strings.TrimSpace(node.Copyright),
@@ -308,7 +345,7 @@ func buildFiles(bom *sbom.Document) ([]*spdx.File, error) { //nolint:unparam
 }
 
 func (s *SPDX23) buildPackages(
-	serializeopts *native.SerializeOptions, spdxopts SPDX23Options, bom *sbom.Document,
+	serializeopts *native.SerializeOptions, spdxopts SPDX23Options, bom *sbom.Document, refs *license.RefSet,
 ) ([]*spdx.Package, error) {
 	packages := []*spdx.Package{}
 
@@ -340,8 +377,8 @@ func (s *SPDX23) buildPackages(
 			PackageChecksums:            []common.Checksum{},
 			PackageHomePage:             node.UrlHome,
 			PackageSourceInfo:           node.SourceInfo,
-			PackageLicenseConcluded:     node.LicenseConcluded,
-			PackageLicenseDeclared:      strings.Join(node.Licenses, fmt.Sprintf(" %s ", spdxopts.LicenseExpressionOperator)),
+			PackageLicenseConcluded:     licenseExpression(refs, license.And, node.LicenseConcluded),
+			PackageLicenseDeclared:      licenseExpression(refs, license.Operator(spdxopts.LicenseExpressionOperator), node.Licenses...),
 			PackageLicenseInfoFromFiles: []string{},
 			PackageLicenseComments:      node.LicenseComments,
 			PackageCopyrightText:        strings.TrimSpace(node.Copyright),
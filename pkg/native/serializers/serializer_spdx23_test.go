@@ -13,6 +13,7 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	protospdx "github.com/protobom/protobom/pkg/formats/spdx"
+	"github.com/protobom/protobom/pkg/license"
 	"github.com/protobom/protobom/pkg/mod"
 	"github.com/protobom/protobom/pkg/native"
 	"github.com/protobom/protobom/pkg/sbom"
@@ -214,7 +215,7 @@ func TestPropertiesMod(t *testing.T) {
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			packages, err := s.buildPackages(
-				tc.serializeopts, SPDX23Options{}, doc,
+				tc.serializeopts, SPDX23Options{}, doc, license.NewRefSet(),
 			)
 			if tc.mustErr {
 				require.Error(t, err)
@@ -563,8 +564,41 @@ func TestBuildPackages(t *testing.T) {
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
-			packages, err := s23.buildPackages(&native.SerializeOptions{}, tc.spdxopts, tc.doc)
+			packages, err := s23.buildPackages(&native.SerializeOptions{}, tc.spdxopts, tc.doc, license.NewRefSet())
 			tc.validate(t, packages, err)
 		})
 	}
 }
+
+func TestSerializeLicenses(t *testing.T) {
+	doc := sbom.NewDocument()
+	doc.Metadata.Id = "https://spdx.org/spdxdocs/protobom-test"
+	doc.NodeList.AddNode(&sbom.Node{
+		Id:               "pkg1",
+		Name:             "pkg1",
+		Licenses:         []string{"GPL-2.0", "My Custom License", "LicenseRef-existing"},
+		LicenseConcluded: "mit and (apache-2.0 or mit)",
+	})
+	doc.NodeList.AddNode(&sbom.Node{
+		Id:               "file1",
+		Name:             "file1",
+		Type:             sbom.Node_FILE,
+		LicenseConcluded: "My Custom License",
+	})
+
+	nativeDoc, err := NewSPDX23().Serialize(doc, &native.SerializeOptions{}, nil)
+	require.NoError(t, err)
+	spdxDoc, ok := nativeDoc.(*spdx.Document)
+	require.True(t, ok)
+
+	require.Len(t, spdxDoc.Packages, 1)
+	require.Equal(t, "GPL-2.0-only OR LicenseRef-My-Custom-License OR LicenseRef-existing", spdxDoc.Packages[0].PackageLicenseDeclared)
+	require.Equal(t, "MIT", spdxDoc.Packages[0].PackageLicenseConcluded)
+	require.Len(t, spdxDoc.Files, 1)
+	require.Equal(t, "LicenseRef-My-Custom-License", spdxDoc.Files[0].LicenseConcluded)
+
+	require.Equal(t, []*v2_3.OtherLicense{
+		{LicenseIdentifier: "LicenseRef-My-Custom-License", ExtractedText: "My Custom License", LicenseName: "My Custom License"},
+		{LicenseIdentifier: "LicenseRef-existing", ExtractedText: protospdx.NOASSERTION, LicenseName: protospdx.NOASSERTION},
+	}, spdxDoc.OtherLicenses)
+}
@@ -11,6 +11,7 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	cdxformats "github.com/protobom/protobom/pkg/formats/cyclonedx"
+	"github.com/protobom/protobom/pkg/license"
 	"github.com/protobom/protobom/pkg/native"
 	"github.com/protobom/protobom/pkg/sbom"
 )
@@ -378,24 +379,24 @@ func (u *CDX) unserializeExternalReferences(cdxReferences *[]cdx.ExternalReferen
 }
 
 // licenseChoicesToLicenseList returns a flat list of license strings combining
-// expressions and IDs in one. This function should be part of a license package.
+// expressions, IDs and, for licenses without an ID, their names.
 func (u *CDX) licenseChoicesToLicenseList(lcs *cdx.Licenses) []string {
 	list := []string{}
 	if lcs == nil {
 		return list
 	}
 	for _, lc := range *lcs {
-		// TODO(license): This should handle licenses without an ID and
-		// create custom licenses or another solution that captures the
-		// full custom license text.
-		if lc.Expression == "" && (lc.License == nil || lc.License.ID == "") {
-			continue
-		}
-
-		if lc.Expression != "" {
+		switch {
+		case lc.Expression != "":
 			list = append(list, lc.Expression)
-		} else {
+		case lc.License == nil:
+			continue
+		case lc.License.ID != "":
 			list = append(list, lc.License.ID)
+		case lc.License.Name != "":
+			// TODO(degradation): The license text is lost, serializers
+			// can only record the name of custom licenses.
+			list = append(list, lc.License.Name)
 		}
 	}
 
@@ -403,34 +404,19 @@ func (u *CDX) licenseChoicesToLicenseList(lcs *cdx.Licenses) []string {
 }
 
 // licenseChoicesToLicenseString takes the component license data and computes
-// a license expression with its license entries. It will return the license or
-// expression verbatim if its just a single entry.
-// This function is temporary and probably should be part of a more complete
-// license package.
+// a normalized license expression joining its license entries. License names
+// are replaced by their SPDX identifier when they are in the SPDX license
+// list. Other names, unknown identifiers and invalid expressions are kept as
+// LicenseRefs so no license is dropped.
 func (u *CDX) licenseChoicesToLicenseString(lcs *cdx.Licenses) string {
-	if lcs == nil {
-		return ""
-	}
-	var parts []string
-	for _, lc := range *lcs {
-		// TODO(license): This should handle licenses without an ID and
-		// create custom licenses or another solution that captures the
-		// full custom license text.
-		if lc.Expression == "" && (lc.License == nil || lc.License.ID == "") {
-			continue
+	refs := license.NewRefSet()
+	exprs := []*license.Expression{}
+	for _, l := range u.licenseChoicesToLicenseList(lcs) {
+		if e := refs.Expression(l); e != nil {
+			exprs = append(exprs, e)
 		}
-
-		if lc.Expression != "" {
-			parts = append(parts, lc.Expression)
-		} else {
-			parts = append(parts, lc.License.ID)
-		}
-	}
-
-	if len(parts) == 1 {
-		return parts[0]
 	}
-	return strings.Join(parts, " OR ")
+	return license.Join(license.Or, exprs...).Simplify().String()
 }
 
 // phaseToSBOMType converts a CycloneDX lifecycle phase to an SBOM document type
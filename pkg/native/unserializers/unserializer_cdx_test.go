@@ -307,3 +307,26 @@ func TestLicenseChoicesNilLicense(t *testing.T) {
 		})
 	}
 }
+
+func TestLicenseChoices(t *testing.T) {
+	cdxu := NewCDX(cdxUnserializerTestVersion, cdxUnserializerTestEncoding)
+	licenses := cdx.Licenses{
+		{License: &cdx.License{ID: "MIT"}},
+		{License: &cdx.License{Name: "Apache License 2.0"}},
+		{License: &cdx.License{Name: "Custom License"}},
+		{Expression: "(bsd-3-clause OR MIT)"},
+		{Expression: "MIT/X11"},
+		{License: &cdx.License{ID: "Vendor-EULA"}},
+	}
+	require.Equal(t,
+		[]string{"MIT", "Apache License 2.0", "Custom License", "(bsd-3-clause OR MIT)", "MIT/X11", "Vendor-EULA"},
+		cdxu.licenseChoicesToLicenseList(&licenses),
+	)
+
+	// Unknown licenses are kept as LicenseRefs
+	require.Equal(t,
+		"MIT OR Apache-2.0 OR LicenseRef-Custom-License OR BSD-3-Clause OR LicenseRef-MIT-X11 OR LicenseRef-Vendor-EULA",
+		cdxu.licenseChoicesToLicenseString(&licenses),
+	)
+	require.Empty(t, cdxu.licenseChoicesToLicenseString(nil))
+}
@@ -0,0 +1,333 @@
+package reader
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/protobom/protobom/pkg/compression"
+	"github.com/protobom/protobom/pkg/formats"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// SourceProperty is the name of the property added to each node read by the
+// multi-document functions. Its value is the URI of the file where the node
+// was found. When documents are merged, nodes found in several files get a
+// property for each one.
+const SourceProperty = "protobom:source"
+
+var (
+	zipMagic = []byte("PK\x03\x04")
+	tarMagic = []byte("ustar")
+
+	// tarMagicOffset is the position of the magic string in a tar header
+	tarMagicOffset = 257
+
+	errNotArchive = errors.New("file is not a tar or zip archive")
+)
+
+// ParseMulti reads all the SBOMs found at path. The path can point to a
+// directory, a tar archive (optionally compressed with gzip or zstd), a zip
+// archive or a JSON lines file (.jsonl or .ndjson) with one SBOM per line.
+//
+// Files whose format cannot be detected are skipped. Each node in the
+// returned documents is tagged with the URI of the file it was read from.
+func (r *Reader) ParseMulti(path string) ([]*sbom.Document, error) {
+	return r.ParseMultiWithOptions(path, r.Options)
+}
+
+// ParseMultiWithOptions is the variant of ParseMulti that takes an options set
+func (r *Reader) ParseMultiWithOptions(path string, o *Options) ([]*sbom.Document, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("checking path: %w", err)
+	}
+
+	if info.IsDir() {
+		return r.ParseDirWithOptions(path, o)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jsonl", ".ndjson":
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("opening JSON lines file: %w", err)
+		}
+		defer f.Close() //nolint:errcheck
+		return r.parseJSONLines(f, fileURI(path), o)
+	}
+
+	docs, err := r.ParseArchiveWithOptions(path, o)
+	if !errors.Is(err, errNotArchive) {
+		return docs, err
+	}
+
+	// Not an archive, read it as a single document
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading file: %w", err)
+	}
+	doc, err := r.parseEntry(data, fileURI(path), o)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if doc == nil {
+		return []*sbom.Document{}, nil
+	}
+	return []*sbom.Document{doc}, nil
+}
+
+// ParseMultiMerged reads all the SBOMs found at path (see ParseMulti) and
+// returns them merged into a single document.
+func (r *Reader) ParseMultiMerged(path string) (*sbom.Document, error) {
+	docs, err := r.ParseMulti(path)
+	if err != nil {
+		return nil, err
+	}
+	return sbom.MergeDocuments(docs...), nil
+}
+
+// ParseDir reads the SBOMs in a directory and its subdirectories. Files whose
+// format cannot be detected are skipped. Documents are returned sorted by
+// their path.
+func (r *Reader) ParseDir(path string) ([]*sbom.Document, error) {
+	return r.ParseDirWithOptions(path, r.Options)
+}
+
+// ParseDirWithOptions is the variant of ParseDir that takes an options set
+func (r *Reader) ParseDirWithOptions(path string, o *Options) ([]*sbom.Document, error) {
+	files := []string{}
+	if err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			files = append(files, p)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("walking directory: %w", err)
+	}
+	slices.Sort(files)
+
+	docs := []*sbom.Document{}
+	for _, p := range files {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", p, err)
+		}
+
+		doc, err := r.parseEntry(data, fileURI(p), o)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", p, err)
+		}
+		if doc != nil {
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
+// ParseArchive reads the SBOMs in a tar or zip archive. Tar archives can be
+// compressed with gzip or zstd. Entries whose format cannot be detected are
+// skipped. Documents are returned in the order they appear in the archive.
+// Only POSIX (ustar) tar archives are recognized.
+func (r *Reader) ParseArchive(path string) ([]*sbom.Document, error) {
+	return r.ParseArchiveWithOptions(path, r.Options)
+}
+
+// ParseArchiveWithOptions is the variant of ParseArchive that takes an
+// options set.
+func (r *Reader) ParseArchiveWithOptions(path string, o *Options) ([]*sbom.Document, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening archive: %w", err)
+	}
+	defer f.Close() //nolint:errcheck
+
+	header := make([]byte, len(zipMagic))
+	if _, err := io.ReadFull(f, header); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, errNotArchive)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("rewinding archive: %w", err)
+	}
+
+	if bytes.Equal(header, zipMagic) {
+		return r.parseZip(path, o)
+	}
+	return r.parseTar(f, path, o)
+}
+
+// parseTar reads the documents in a tar stream
+func (r *Reader) parseTar(f io.ReadSeeker, path string, o *Options) ([]*sbom.Document, error) {
+	c, err := compression.DetectReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("detecting archive compression: %w", err)
+	}
+
+	zr, err := compression.NewReader(c, f)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close() //nolint:errcheck
+
+	br := bufio.NewReader(zr)
+	header, err := br.Peek(tarMagicOffset + len(tarMagic))
+	if err != nil || !bytes.Equal(header[tarMagicOffset:], tarMagic) {
+		return nil, fmt.Errorf("reading %s: %w", path, errNotArchive)
+	}
+
+	docs := []*sbom.Document{}
+	tr := tar.NewReader(br)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading tar archive: %w", err)
+		}
+
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		data, err := io.ReadAll(limitReader(tr, o.maxDecompressedSize()))
+		if err != nil {
+			return nil, fmt.Errorf("reading %s from archive: %w", hdr.Name, err)
+		}
+
+		doc, err := r.parseEntry(data, archiveEntryURI(path, hdr.Name), o)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", hdr.Name, err)
+		}
+		if doc != nil {
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
+// parseZip reads the documents in a zip archive
+func (r *Reader) parseZip(path string, o *Options) ([]*sbom.Document, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening zip archive: %w", err)
+	}
+	defer zr.Close() //nolint:errcheck
+
+	docs := []*sbom.Document{}
+	for _, zf := range zr.File {
+		if !zf.Mode().IsRegular() {
+			continue
+		}
+
+		data, err := readZipFile(zf, o.maxDecompressedSize())
+		if err != nil {
+			return nil, fmt.Errorf("reading %s from archive: %w", zf.Name, err)
+		}
+
+		doc, err := r.parseEntry(data, archiveEntryURI(path, zf.Name), o)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", zf.Name, err)
+		}
+		if doc != nil {
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
+// readZipFile returns the decompressed content of a zip entry. Entries
+// larger than limit return ErrDecompressedSizeExceeded.
+func readZipFile(zf *zip.File, limit int64) ([]byte, error) {
+	rc, err := zf.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close() //nolint:errcheck
+	return io.ReadAll(limitReader(rc, limit))
+}
+
+// ParseJSONLines reads a stream of JSON SBOMs, one per line. Empty lines are
+// ignored. The URI of each document records its line number.
+func (r *Reader) ParseJSONLines(rd io.Reader) ([]*sbom.Document, error) {
+	return r.parseJSONLines(rd, "", r.Options)
+}
+
+// ParseJSONLinesWithOptions is the variant of ParseJSONLines that takes an
+// options set.
+func (r *Reader) ParseJSONLinesWithOptions(rd io.Reader, o *Options) ([]*sbom.Document, error) {
+	return r.parseJSONLines(rd, "", o)
+}
+
+func (r *Reader) parseJSONLines(rd io.Reader, uri string, o *Options) ([]*sbom.Document, error) {
+	docs := []*sbom.Document{}
+	br := bufio.NewReader(rd)
+	for lineNr := 1; ; lineNr++ {
+		line, err := br.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("reading line %d: %w", lineNr, err)
+		}
+
+		if len(bytes.TrimSpace(line)) > 0 {
+			doc, perr := r.parseEntry(line, fmt.Sprintf("%s#L%d", uri, lineNr), o)
+			if perr != nil {
+				return nil, fmt.Errorf("parsing line %d: %w", lineNr, perr)
+			}
+			if doc != nil {
+				docs = append(docs, doc)
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+	}
+	return docs, nil
+}
+
+// parseEntry parses one of the documents read by the multi-document functions
+// and tags it with its source URI. If the format of the document cannot be
+// detected, it returns nil without an error.
+func (r *Reader) parseEntry(data []byte, uri string, o *Options) (*sbom.Document, error) {
+	doc, err := r.ParseStreamWithOptions(bytes.NewReader(data), o)
+	if err != nil {
+		var unknownErr *formats.UnknownFormatError
+		if errors.As(err, &unknownErr) {
+			logrus.Debugf("skipping %s: %v", uri, err)
+			return nil, nil //nolint:nilnil // Unknown files are skipped
+		}
+		return nil, err
+	}
+
+	if doc.Metadata != nil && doc.Metadata.SourceData != nil {
+		doc.Metadata.SourceData.Uri = &uri
+	}
+
+	for _, n := range doc.GetNodeList().GetNodes() {
+		n.Properties = append(n.Properties, &sbom.Property{Name: SourceProperty, Data: uri})
+	}
+	return doc, nil
+}
+
+func fileURI(path string) string {
+	return fmt.Sprintf("file://%s", path)
+}
+
+// archiveEntryURI returns the URI of a file in an archive
+func archiveEntryURI(archive, name string) string {
+	return fmt.Sprintf("file://%s!/%s", archive, strings.TrimPrefix(name, "/"))
+}
@@ -2,12 +2,16 @@ package reader
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 
 	"github.com/protobom/protobom/pkg/datasink"
+	"github.com/protobom/protobom/pkg/dsse"
 	"github.com/protobom/protobom/pkg/formats"
 	"github.com/protobom/protobom/pkg/mod"
 	"github.com/protobom/protobom/pkg/native"
 	"github.com/protobom/protobom/pkg/storage"
+	"github.com/protobom/protobom/pkg/transform"
 )
 
 type Options struct {
@@ -15,7 +19,40 @@ type Options struct {
 	Listeners          []datasink.Listener
 	UnserializeOptions *native.UnserializeOptions
 	RetrieveOptions    *storage.RetrieveOptions
-	formatOptions      map[string]interface{}
+
+	// Verifiers check the signatures of documents in DSSE envelopes. When
+	// set, only documents signed by one of them can be read.
+	Verifiers []dsse.Verifier
+
+	// Transformers run in order on each document after it is unserialized
+	Transformers []transform.Transformer
+
+	// MaxDecompressedSize is the largest size in bytes that compressed
+	// documents and archive entries can have once decompressed. When zero,
+	// DefaultMaxDecompressedSize applies. Negative values disable the limit.
+	MaxDecompressedSize int64
+
+	formatOptions map[string]interface{}
+}
+
+// copy returns a copy of the options that can be modified without changing
+// the original, the slices, maps and nested options are cloned.
+func (o *Options) copy() *Options {
+	c := *o
+	c.Listeners = slices.Clone(o.Listeners)
+	c.Verifiers = slices.Clone(o.Verifiers)
+	c.Transformers = slices.Clone(o.Transformers)
+	c.formatOptions = maps.Clone(o.formatOptions)
+	if o.UnserializeOptions != nil {
+		uo := *o.UnserializeOptions
+		uo.Mods = maps.Clone(o.UnserializeOptions.Mods)
+		c.UnserializeOptions = &uo
+	}
+	if o.RetrieveOptions != nil {
+		ro := *o.RetrieveOptions
+		c.RetrieveOptions = &ro
+	}
+	return &c
 }
 
 // argToOptsKeyVal returns a key value to access the options dictionary by using
@@ -29,6 +66,14 @@ func argToOptsKeyVal(key interface{}) string {
 	return keyVal
 }
 
+// maxDecompressedSize returns the decompressed size limit of the options
+func (o *Options) maxDecompressedSize() int64 {
+	if o.MaxDecompressedSize == 0 {
+		return DefaultMaxDecompressedSize
+	}
+	return o.MaxDecompressedSize
+}
+
 func (o *Options) GetFormatOptions(key interface{}) interface{} {
 	keyVal := argToOptsKeyVal(key)
 	if _, ok := o.formatOptions[keyVal]; ok {
@@ -111,6 +156,30 @@ func WithListener(l datasink.Listener) ReaderOption {
 	}
 }
 
+// WithVerifier adds a verifier to check the signatures of the documents
+// read. Once a verifier is set, unsigned documents are rejected.
+func WithVerifier(v dsse.Verifier) ReaderOption {
+	return func(r *Reader) {
+		r.Options.Verifiers = append(r.Options.Verifiers, v)
+	}
+}
+
+// WithTransformer adds a transformer to run on the documents read. The
+// transformers run in the order they are added.
+func WithTransformer(t transform.Transformer) ReaderOption {
+	return func(r *Reader) {
+		r.Options.Transformers = append(r.Options.Transformers, t)
+	}
+}
+
+// WithMaxDecompressedSize sets the largest size that compressed documents
+// and archive entries can have once decompressed.
+func WithMaxDecompressedSize(size int64) ReaderOption {
+	return func(r *Reader) {
+		r.Options.MaxDecompressedSize = size
+	}
+}
+
 func WithTrackSource(t bool) ReaderOption {
 	return func(r *Reader) {
 		r.Options.UnserializeOptions.TrackSource = t
@@ -10,18 +10,23 @@ import (
 	"crypto/sha1" //nolint:gosec // SHA1 is required in SPDX2
 	"crypto/sha256"
 	"crypto/sha512"
+	"errors"
 	"fmt"
 	"hash"
 	"io"
 	"os"
 	"sync"
 
+	"github.com/protobom/protobom/pkg/compression"
+	"github.com/protobom/protobom/pkg/dsse"
 	"github.com/protobom/protobom/pkg/formats"
+	"github.com/protobom/protobom/pkg/intoto"
 	"github.com/protobom/protobom/pkg/mod"
 	"github.com/protobom/protobom/pkg/native"
 	drivers "github.com/protobom/protobom/pkg/native/unserializers"
 	"github.com/protobom/protobom/pkg/sbom"
 	"github.com/protobom/protobom/pkg/storage"
+	"github.com/protobom/protobom/pkg/transform"
 )
 
 var (
@@ -40,6 +45,15 @@ var (
 	}
 )
 
+// DefaultMaxDecompressedSize is the largest size that compressed documents
+// and archive entries can have once decompressed when the options don't set
+// a limit.
+const DefaultMaxDecompressedSize = 1 << 30
+
+// ErrDecompressedSizeExceeded is returned when a compressed document or an
+// archive entry expands beyond the limit in the options.
+var ErrDecompressedSizeExceeded = errors.New("decompressed data exceeds the size limit")
+
 func init() {
 	regMtx.Lock()
 	unserializers[formats.CDX10JSON] = drivers.NewCDX("1.0", formats.JSON)
@@ -56,17 +70,27 @@ func init() {
 
 // RegisterUnserializer registers a new unserializer to parse a specific
 // format. The new unserializer replaces any previously defined driver.
+//
+// If the unserializer also implements formats.Detector, it is registered as
+// a format detector so the sniffer can recognize documents in its format.
 func RegisterUnserializer(format formats.Format, u native.Unserializer) {
 	regMtx.Lock()
 	unserializers[format] = u
 	regMtx.Unlock()
+
+	if d, ok := u.(formats.Detector); ok {
+		formats.RegisterDetector(string(format), d)
+	}
 }
 
 // UnregisterUnserializer removes a serializer from the list of available
+// drivers, along with the format detector it contributed, if any.
 func UnregisterUnserializer(format formats.Format) {
 	regMtx.Lock()
 	delete(unserializers, format)
 	regMtx.Unlock()
+
+	formats.UnregisterDetector(string(format))
 }
 
 func GetFormatUnserializer(format formats.Format) (native.Unserializer, error) {
@@ -93,11 +117,13 @@ var defaultOptions = &Options{
 	formatOptions:      map[string]interface{}{},
 }
 
+// New returns a reader configured with the options passed. Each reader gets
+// its own copy of the default options, so options never leak across readers.
 func New(opts ...ReaderOption) *Reader {
 	r := &Reader{
 		sniffer: &formats.Sniffer{},
 		Storage: storage.NewFileSystem(),
-		Options: defaultOptions,
+		Options: defaultOptions.copy(),
 	}
 
 	for _, opt := range opts {
@@ -133,12 +159,76 @@ func (r *Reader) ParseFileWithOptions(path string, o *Options) (*sbom.Document,
 	return doc, nil
 }
 
-// ParseStreamWithOptions returns a document from a ioreader, accept options for unserializer
+// newHashers returns the hashers used to track the checksums of the
+// original SBOM data.
+func newHashers() map[sbom.HashAlgorithm]hash.Hash {
+	return map[sbom.HashAlgorithm]hash.Hash{
+		sbom.HashAlgorithm_SHA1:   sha1.New(), //nolint:gosec // SHA1 is required in SPDX2
+		sbom.HashAlgorithm_SHA256: sha256.New(),
+		sbom.HashAlgorithm_SHA512: sha512.New(),
+	}
+}
+
+// ParseStreamWithOptions returns a document from a ioreader, accept options for unserializer.
+// Streams compressed with gzip or zstd are decompressed transparently.
 func (r *Reader) ParseStreamWithOptions(f io.ReadSeeker, o *Options) (*sbom.Document, error) {
 	if o == nil {
 		return nil, fmt.Errorf("options cannot be nil")
 	}
 
+	// Compressed documents are detected by their magic bytes and read
+	// into memory uncompressed before sniffing and parsing them.
+	c, err := compression.DetectReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("detecting compression: %w", err)
+	}
+
+	compressedCounter := &countingReader{r: f}
+	compressedHashers := newHashers()
+	if c != compression.None {
+		var compressed io.Reader = compressedCounter
+		if o.UnserializeOptions.TrackSource {
+			sinks := []io.Writer{}
+			for i := range compressedHashers {
+				sinks = append(sinks, compressedHashers[i])
+			}
+			compressed = io.TeeReader(compressedCounter, io.MultiWriter(sinks...))
+		}
+
+		zr, err := compression.NewReader(c, compressed)
+		if err != nil {
+			return nil, fmt.Errorf("decompressing document: %w", err)
+		}
+		data, err := io.ReadAll(limitReader(zr, o.maxDecompressedSize()))
+		if err != nil {
+			return nil, fmt.Errorf("decompressing document: %w", err)
+		}
+		if err := zr.Close(); err != nil {
+			return nil, fmt.Errorf("closing %s stream: %w", c, err)
+		}
+		f = bytes.NewReader(data)
+	}
+
+	// Signed documents are unwrapped from their DSSE envelope after
+	// verifying their signatures.
+	envelope, accepted, err := readEnvelope(f, o.Verifiers)
+	if err != nil {
+		return nil, err
+	}
+	if envelope != nil {
+		f = bytes.NewReader(envelope.Payload)
+	}
+
+	// Documents wrapped in in-toto statements are unwrapped to parse
+	// the SBOM in the predicate.
+	statement, err := readStatement(f)
+	if err != nil {
+		return nil, err
+	}
+	if statement != nil {
+		f = bytes.NewReader(statement.Predicate)
+	}
+
 	format := o.Format
 	if o.Format == "" {
 		f, err := r.detectFormat(f)
@@ -163,11 +253,7 @@ func (r *Reader) ParseStreamWithOptions(f io.ReadSeeker, o *Options) (*sbom.Docu
 	var counter bytes.Buffer
 
 	// Create the hashers to tack the checksum of the original SBOM
-	hashers := map[sbom.HashAlgorithm]hash.Hash{
-		sbom.HashAlgorithm_SHA1:   sha1.New(), //nolint:gosec // SHA1 is required in SPDX2
-		sbom.HashAlgorithm_SHA256: sha256.New(),
-		sbom.HashAlgorithm_SHA512: sha512.New(),
-	}
+	hashers := newHashers()
 
 	if o.UnserializeOptions.TrackSource {
 		sinks = append(sinks, &counter)
@@ -194,18 +280,158 @@ func (r *Reader) ParseStreamWithOptions(f io.ReadSeeker, o *Options) (*sbom.Docu
 		doc.Metadata = &sbom.Metadata{}
 	}
 
+	if statement != nil {
+		doc.Metadata.Subjects = statement.SBOMSubjects()
+	}
+
 	if o.UnserializeOptions.TrackSource {
 		doc.Metadata.SourceData = &sbom.SourceData{
 			Format: string(format),
 			Size:   int64(counter.Len()),
 			Hashes: map[int32]string{},
 		}
+		if statement != nil {
+			doc.Metadata.SourceData.PredicateType = statement.PredicateType
+		}
+		if len(accepted) > 0 {
+			doc.Metadata.SourceData.SignerIdentity = accepted[0].Identity
+			doc.Metadata.SourceData.SignerKeyId = accepted[0].KeyID
+		}
 		for algo, hasher := range hashers {
 			doc.Metadata.SourceData.Hashes[int32(algo)] = fmt.Sprintf("%x", hasher.Sum(nil))
 		}
+
+		if c != compression.None {
+			doc.Metadata.SourceData.Compression = string(c)
+			doc.Metadata.SourceData.CompressedSize = compressedCounter.n
+			doc.Metadata.SourceData.CompressedHashes = map[int32]string{}
+			for algo, hasher := range compressedHashers {
+				doc.Metadata.SourceData.CompressedHashes[int32(algo)] = fmt.Sprintf("%x", hasher.Sum(nil))
+			}
+		}
 	}
 
-	return doc, err
+	if err := transform.Run(doc, o.Transformers...); err != nil {
+		return nil, fmt.Errorf("transforming document: %w", err)
+	}
+
+	return doc, nil
+}
+
+// sizeLimitReader returns ErrDecompressedSizeExceeded once more than
+// remaining bytes are read from r.
+type sizeLimitReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+// limitReader wraps r to fail when reading more than limit bytes. Negative
+// limits return r unchanged.
+func limitReader(r io.Reader, limit int64) io.Reader {
+	if limit < 0 {
+		return r
+	}
+	return &sizeLimitReader{r: r, remaining: limit}
+}
+
+func (lr *sizeLimitReader) Read(p []byte) (int, error) {
+	// Read up to one byte past the limit to detect the overflow
+	if int64(len(p)) > lr.remaining+1 {
+		p = p[:lr.remaining+1]
+	}
+	n, err := lr.r.Read(p)
+	lr.remaining -= int64(n)
+	if lr.remaining < 0 {
+		return n, ErrDecompressedSizeExceeded
+	}
+	return n, err
+}
+
+// countingReader counts the bytes read from r
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
+
+// wrapperHeaderSize is the amount of data examined to detect in-toto
+// statements and DSSE envelopes. Wrappers whose type field comes after
+// a large payload are not detected.
+const wrapperHeaderSize = 32 * 1024
+
+// peekWrapper calls isWrapper with the beginning of the stream and rewinds
+// it before returning the result.
+func peekWrapper(rs io.ReadSeeker, isWrapper func(io.Reader) bool) (bool, error) {
+	pos, err := rs.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return false, fmt.Errorf("getting stream position: %w", err)
+	}
+	found := isWrapper(io.LimitReader(rs, wrapperHeaderSize))
+	if _, err := rs.Seek(pos, io.SeekStart); err != nil {
+		return false, fmt.Errorf("rewinding stream: %w", err)
+	}
+	return found, nil
+}
+
+// readStatement checks if the stream holds an in-toto statement and, if it
+// does, returns it parsed. If not, the stream is rewound before returning.
+func readStatement(rs io.ReadSeeker) (*intoto.Statement, error) {
+	isStatement, err := peekWrapper(rs, intoto.IsStatement)
+	if err != nil || !isStatement {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(rs)
+	if err != nil {
+		return nil, fmt.Errorf("reading in-toto statement: %w", err)
+	}
+	statement, err := intoto.ParseStatement(data)
+	if err != nil {
+		return nil, err
+	}
+	if !statement.IsSBOM() {
+		return nil, fmt.Errorf("in-toto predicate type %q is not an SBOM", statement.PredicateType)
+	}
+	return statement, nil
+}
+
+// readEnvelope checks if the stream holds a DSSE envelope and, if it does,
+// returns it parsed and the signatures verified with verifiers. If there are
+// verifiers, the document must be signed by at least one of them.
+func readEnvelope(rs io.ReadSeeker, verifiers []dsse.Verifier) (*dsse.Envelope, []dsse.Acceptance, error) {
+	isEnvelope, err := peekWrapper(rs, dsse.IsEnvelope)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !isEnvelope {
+		if len(verifiers) > 0 {
+			return nil, nil, errors.New("document is not signed")
+		}
+		return nil, nil, nil
+	}
+
+	data, err := io.ReadAll(rs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading DSSE envelope: %w", err)
+	}
+	envelope, err := dsse.ParseEnvelope(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(verifiers) == 0 {
+		return envelope, nil, nil
+	}
+	accepted, err := envelope.Verify(verifiers...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("verifying document signature: %w", err)
+	}
+	return envelope, accepted, nil
 }
 
 // ParseStreamWithOptions returns a document from a ioreader
@@ -224,7 +450,7 @@ func (r *Reader) detectFormat(rs io.ReadSeeker) (formats.Format, error) {
 // Retrieve reads a document from the configured storage backend using the
 // default options.
 func (r *Reader) Retrieve(id string) (*sbom.Document, error) {
-	return r.RetrieveWithOptions(id, defaultOptions)
+	return r.RetrieveWithOptions(id, r.Options)
 }
 
 // RetrieveWithOptions retrieves a document from the configured storage backend
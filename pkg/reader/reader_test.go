@@ -1,17 +1,30 @@
 package reader_test
 
 import (
+	"archive/tar"
+	"archive/zip"
 	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/protobom/protobom/pkg/compression"
+	"github.com/protobom/protobom/pkg/dsse"
 	"github.com/protobom/protobom/pkg/formats"
+	"github.com/protobom/protobom/pkg/intoto"
+	"github.com/protobom/protobom/pkg/mod"
 	"github.com/protobom/protobom/pkg/native"
 	"github.com/protobom/protobom/pkg/native/nativefakes"
 	"github.com/protobom/protobom/pkg/native/unserializers"
@@ -19,6 +32,7 @@ import (
 	"github.com/protobom/protobom/pkg/reader/readerfakes"
 	"github.com/protobom/protobom/pkg/sbom"
 	"github.com/protobom/protobom/pkg/storage"
+	"github.com/protobom/protobom/pkg/transform"
 )
 
 // A note about Unserializers and reader behavior:
@@ -503,3 +517,478 @@ func TestReaderSourceData(t *testing.T) {
 	require.Equal(t, "f042095476ef416fae33e9a76a4e406ff337cfc15a6f694894e6ff0070adb089", doc.Metadata.SourceData.Hashes[int32(sbom.HashAlgorithm_SHA256)])
 	require.Equal(t, "71b04d63bc55dc78b91dfb376484a20a4e410fd58db893ed6e20637ccb495f7bf83b1aa76ab377bd9a6ef96d0d19f8cfa834d152dbf4880c2400be9a89dea429", doc.Metadata.SourceData.Hashes[int32(sbom.HashAlgorithm_SHA512)])
 }
+
+func TestReaderCompressed(t *testing.T) {
+	t.Parallel()
+	data, err := os.ReadFile("../formats/testdata/nginx.spdx.json")
+	require.NoError(t, err)
+
+	reader.RegisterUnserializer(formats.SPDX23JSON, unserializers.NewSPDX23())
+	opts := &reader.Options{
+		UnserializeOptions: &native.UnserializeOptions{TrackSource: true},
+	}
+	plain, err := reader.New().ParseStreamWithOptions(bytes.NewReader(data), opts)
+	require.NoError(t, err)
+
+	for _, c := range []compression.Compression{compression.Gzip, compression.Zstd} {
+		t.Run(string(c), func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			zw, err := compression.NewWriter(c, &buf)
+			require.NoError(t, err)
+			_, err = zw.Write(data)
+			require.NoError(t, err)
+			require.NoError(t, zw.Close())
+
+			doc, err := reader.New().ParseStreamWithOptions(bytes.NewReader(buf.Bytes()), opts)
+			require.NoError(t, err)
+			require.Len(t, doc.GetNodeList().GetNodes(), len(plain.GetNodeList().GetNodes()))
+
+			sd := doc.GetMetadata().GetSourceData()
+			require.Equal(t, string(formats.SPDX23JSON), sd.GetFormat())
+			require.Equal(t, string(c), sd.GetCompression())
+			require.Equal(t, plain.GetMetadata().GetSourceData().GetHashes(), sd.GetHashes())
+			require.Equal(t, int64(len(data)), sd.GetSize())
+			require.Equal(t, int64(buf.Len()), sd.GetCompressedSize())
+			require.Len(t, sd.GetCompressedHashes(), 3)
+			require.NotEqual(t, sd.GetHashes(), sd.GetCompressedHashes())
+
+			// The compressed size only counts the data after the stream position
+			prefixed := bytes.NewReader(append([]byte("prefix"), buf.Bytes()...))
+			_, err = prefixed.Seek(int64(len("prefix")), io.SeekStart)
+			require.NoError(t, err)
+			doc, err = reader.New().ParseStreamWithOptions(prefixed, opts)
+			require.NoError(t, err)
+			require.Equal(t, int64(buf.Len()), doc.GetMetadata().GetSourceData().GetCompressedSize())
+			require.Equal(t, sd.GetCompressedHashes(), doc.GetMetadata().GetSourceData().GetCompressedHashes())
+		})
+	}
+}
+
+func TestReaderDecompressionLimit(t *testing.T) {
+	t.Parallel()
+	data, err := os.ReadFile("../formats/testdata/nginx.spdx.json")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	zw, err := compression.NewWriter(compression.Gzip, &buf)
+	require.NoError(t, err)
+	_, err = zw.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	reader.RegisterUnserializer(formats.SPDX23JSON, unserializers.NewSPDX23())
+	for _, tc := range []struct {
+		name  string
+		limit int64
+		fails bool
+	}{
+		{"default limit", 0, false},
+		{"exact limit", int64(len(data)), false},
+		{"no limit", -1, false},
+		{"exceeded", int64(len(data)) - 1, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := reader.New().ParseStreamWithOptions(bytes.NewReader(buf.Bytes()), &reader.Options{
+				UnserializeOptions:  &native.UnserializeOptions{},
+				MaxDecompressedSize: tc.limit,
+			})
+			if tc.fails {
+				require.ErrorIs(t, err, reader.ErrDecompressedSizeExceeded)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+// detectingUnserializer is an unserializer that contributes its own format
+// detection logic.
+type detectingUnserializer struct {
+	nativefakes.FakeUnserializer
+	format formats.Format
+}
+
+func (d *detectingUnserializer) Detect(data *formats.SniffData) []formats.Candidate {
+	if data.Fields["customSBOM"] == "" {
+		return nil
+	}
+	return []formats.Candidate{{Format: d.format, Confidence: formats.ConfidenceHigh, Reason: "customSBOM field found"}}
+}
+
+func TestRegisterUnserializerDetector(t *testing.T) {
+	t.Parallel()
+	format := formats.Format("application/vnd.custom+json;version=1")
+	u := &detectingUnserializer{format: format}
+	u.UnserializeReturns(&sbom.Document{Metadata: &sbom.Metadata{Id: "custom"}}, nil)
+
+	reader.RegisterUnserializer(format, u)
+	defer reader.UnregisterUnserializer(format)
+
+	doc, err := reader.New().ParseStreamWithOptions(
+		bytes.NewReader([]byte(`{"customSBOM": "yes"}`)),
+		&reader.Options{UnserializeOptions: &native.UnserializeOptions{}},
+	)
+	require.NoError(t, err)
+	require.Equal(t, "custom", doc.GetMetadata().GetId())
+	require.Equal(t, 1, u.UnserializeCallCount())
+
+	reader.UnregisterUnserializer(format)
+	_, err = reader.New().ParseStreamWithOptions(
+		bytes.NewReader([]byte(`{"customSBOM": "yes"}`)),
+		&reader.Options{UnserializeOptions: &native.UnserializeOptions{}},
+	)
+	require.Error(t, err)
+}
+
+// multiTestFiles returns the SBOMs used to test the multi-document functions
+func multiTestFiles(t *testing.T) map[string][]byte {
+	t.Helper()
+	reader.RegisterUnserializer(formats.SPDX23JSON, unserializers.NewSPDX23())
+	reader.RegisterUnserializer(formats.CDX17JSON, unserializers.NewCDX("1.7", formats.JSON))
+
+	files := map[string][]byte{
+		"README.md": []byte("# Not an SBOM\n"),
+	}
+	for name, src := range map[string]string{
+		"nginx.spdx.json":  "../formats/testdata/nginx.spdx.json",
+		"sub/app.cdx.json": "../formats/testdata/minimal.cdx.1.7.json",
+	} {
+		data, err := os.ReadFile(src)
+		require.NoError(t, err)
+		var compact bytes.Buffer
+		require.NoError(t, json.Compact(&compact, data))
+		files[name] = compact.Bytes()
+	}
+	return files
+}
+
+func checkMultiDocs(t *testing.T, docs []*sbom.Document, uriPrefix string) {
+	t.Helper()
+	require.Len(t, docs, 2)
+	for _, doc := range docs {
+		uri := doc.GetMetadata().GetSourceData().GetUri()
+		require.True(t, strings.HasPrefix(uri, uriPrefix), uri)
+		for _, n := range doc.GetNodeList().GetNodes() {
+			found := false
+			for _, p := range n.GetProperties() {
+				if p.GetName() == reader.SourceProperty {
+					require.Equal(t, uri, p.GetData())
+					found = true
+				}
+			}
+			require.True(t, found, "node %s not tagged with its source", n.GetId())
+		}
+	}
+}
+
+func TestParseMulti(t *testing.T) {
+	t.Parallel()
+	files := multiTestFiles(t)
+	opts := &reader.Options{UnserializeOptions: &native.UnserializeOptions{TrackSource: true}}
+	r := reader.New()
+
+	t.Run("directory", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		for name, data := range files {
+			require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name), data, 0o600))
+		}
+		docs, err := r.ParseMultiWithOptions(dir, opts)
+		require.NoError(t, err)
+		checkMultiDocs(t, docs, "file://"+dir)
+	})
+
+	t.Run("tar.gz", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "sboms.tar.gz")
+		var buf bytes.Buffer
+		zw, err := compression.NewWriter(compression.Gzip, &buf)
+		require.NoError(t, err)
+		tw := tar.NewWriter(zw)
+		for name, data := range files {
+			require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(data)), Typeflag: tar.TypeReg}))
+			_, err := tw.Write(data)
+			require.NoError(t, err)
+		}
+		require.NoError(t, tw.Close())
+		require.NoError(t, zw.Close())
+		require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o600))
+
+		docs, err := r.ParseMultiWithOptions(path, opts)
+		require.NoError(t, err)
+		checkMultiDocs(t, docs, "file://"+path+"!/")
+	})
+
+	t.Run("zip", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "sboms.zip")
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		for name, data := range files {
+			w, err := zw.Create(name)
+			require.NoError(t, err)
+			_, err = w.Write(data)
+			require.NoError(t, err)
+		}
+		require.NoError(t, zw.Close())
+		require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o600))
+
+		docs, err := r.ParseMultiWithOptions(path, opts)
+		require.NoError(t, err)
+		checkMultiDocs(t, docs, "file://"+path+"!/")
+	})
+
+	t.Run("decompression limit", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		limited := &reader.Options{
+			UnserializeOptions:  &native.UnserializeOptions{},
+			MaxDecompressedSize: int64(len(files["nginx.spdx.json"])) - 1,
+		}
+
+		var tarBuf bytes.Buffer
+		zw, err := compression.NewWriter(compression.Gzip, &tarBuf)
+		require.NoError(t, err)
+		tw := tar.NewWriter(zw)
+		data := files["nginx.spdx.json"]
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: "nginx.spdx.json", Mode: 0o644, Size: int64(len(data)), Typeflag: tar.TypeReg}))
+		_, err = tw.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, tw.Close())
+		require.NoError(t, zw.Close())
+		tarPath := filepath.Join(dir, "sboms.tar.gz")
+		require.NoError(t, os.WriteFile(tarPath, tarBuf.Bytes(), 0o600))
+
+		var zipBuf bytes.Buffer
+		zipw := zip.NewWriter(&zipBuf)
+		w, err := zipw.Create("nginx.spdx.json")
+		require.NoError(t, err)
+		_, err = w.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, zipw.Close())
+		zipPath := filepath.Join(dir, "sboms.zip")
+		require.NoError(t, os.WriteFile(zipPath, zipBuf.Bytes(), 0o600))
+
+		for _, p := range []string{tarPath, zipPath} {
+			_, err := r.ParseMultiWithOptions(p, limited)
+			require.ErrorIs(t, err, reader.ErrDecompressedSizeExceeded, p)
+
+			docs, err := r.ParseMultiWithOptions(p, opts)
+			require.NoError(t, err, p)
+			require.Len(t, docs, 1, p)
+		}
+	})
+
+	t.Run("json lines", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "sboms.jsonl")
+		lines := append(append(files["nginx.spdx.json"], '\n', '\n'), files["sub/app.cdx.json"]...)
+		require.NoError(t, os.WriteFile(path, lines, 0o600))
+
+		docs, err := r.ParseMultiWithOptions(path, opts)
+		require.NoError(t, err)
+		checkMultiDocs(t, docs, "file://"+path+"#L")
+		require.Equal(t, "file://"+path+"#L3", docs[1].GetMetadata().GetSourceData().GetUri())
+	})
+
+	t.Run("single file", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "nginx.spdx.json")
+		require.NoError(t, os.WriteFile(path, files["nginx.spdx.json"], 0o600))
+		docs, err := r.ParseMultiWithOptions(path, opts)
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+	})
+
+	t.Run("merged", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		for name, data := range files {
+			require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name), data, 0o600))
+		}
+		docs, err := r.ParseDirWithOptions(dir, opts)
+		require.NoError(t, err)
+		merged := sbom.MergeDocuments(docs...)
+		require.Len(t, merged.GetNodeList().GetNodes(),
+			len(docs[0].GetNodeList().GetNodes())+len(docs[1].GetNodeList().GetNodes()))
+		require.Len(t, merged.GetNodeList().GetRootElements(),
+			len(docs[0].GetNodeList().GetRootElements())+len(docs[1].GetNodeList().GetRootElements()))
+	})
+}
+
+func TestReaderInTotoStatement(t *testing.T) {
+	t.Parallel()
+	data, err := os.ReadFile("../formats/testdata/nginx.spdx.json")
+	require.NoError(t, err)
+
+	reader.RegisterUnserializer(formats.SPDX23JSON, unserializers.NewSPDX23())
+	statement, err := json.Marshal(&intoto.Statement{
+		Type: intoto.StatementType,
+		Subject: []intoto.Subject{
+			{Name: "nginx", Digest: map[string]string{"sha256": "abcdef", "gitCommit": "0123"}},
+		},
+		PredicateType: intoto.PredicateTypeSPDX + "/v2.3",
+		Predicate:     data,
+	})
+	require.NoError(t, err)
+
+	opts := &reader.Options{
+		UnserializeOptions: &native.UnserializeOptions{TrackSource: true},
+	}
+	plain, err := reader.New().ParseStreamWithOptions(bytes.NewReader(data), opts)
+	require.NoError(t, err)
+
+	doc, err := reader.New().ParseStreamWithOptions(bytes.NewReader(statement), opts)
+	require.NoError(t, err)
+	require.Len(t, doc.GetNodeList().GetNodes(), len(plain.GetNodeList().GetNodes()))
+	require.Len(t, doc.GetMetadata().GetSubjects(), 1)
+	require.Equal(t, "nginx", doc.GetMetadata().GetSubjects()[0].GetName())
+	require.Equal(t, map[int32]string{int32(sbom.HashAlgorithm_SHA256): "abcdef"}, doc.GetMetadata().GetSubjects()[0].GetHashes())
+	require.Equal(t, intoto.PredicateTypeSPDX+"/v2.3", doc.GetMetadata().GetSourceData().GetPredicateType())
+	require.Equal(t, string(formats.SPDX23JSON), doc.GetMetadata().GetSourceData().GetFormat())
+
+	// Statements with other predicates are rejected
+	other, err := json.Marshal(&intoto.Statement{
+		Type:          intoto.StatementType,
+		Subject:       []intoto.Subject{{Name: "nginx", Digest: map[string]string{"sha256": "abcdef"}}},
+		PredicateType: "https://slsa.dev/provenance/v1",
+		Predicate:     json.RawMessage(`{}`),
+	})
+	require.NoError(t, err)
+	_, err = reader.New().ParseStreamWithOptions(bytes.NewReader(other), opts)
+	require.Error(t, err)
+}
+
+func TestReaderSigned(t *testing.T) {
+	t.Parallel()
+	data, err := os.ReadFile("../formats/testdata/nginx.spdx.json")
+	require.NoError(t, err)
+	reader.RegisterUnserializer(formats.SPDX23JSON, unserializers.NewSPDX23())
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	signer, err := dsse.NewSigner(ecKey)
+	require.NoError(t, err)
+	verifier, err := dsse.NewVerifier(signer.Public())
+	require.NoError(t, err)
+	verifier.Name = "builder@example.com"
+
+	envelope, err := dsse.Sign(string(formats.SPDX23JSON), data, signer)
+	require.NoError(t, err)
+	signed, err := json.Marshal(envelope)
+	require.NoError(t, err)
+
+	opts := &reader.Options{
+		UnserializeOptions: &native.UnserializeOptions{TrackSource: true},
+		Verifiers:          []dsse.Verifier{verifier},
+	}
+	doc, err := reader.New().ParseStreamWithOptions(bytes.NewReader(signed), opts)
+	require.NoError(t, err)
+	require.NotEmpty(t, doc.GetNodeList().GetNodes())
+	require.Equal(t, "builder@example.com", doc.GetMetadata().GetSourceData().GetSignerIdentity())
+	require.Equal(t, signer.KeyID(), doc.GetMetadata().GetSourceData().GetSignerKeyId())
+
+	// Without verifiers the envelope is unwrapped but no signer is recorded
+	doc, err = reader.New().ParseStreamWithOptions(bytes.NewReader(signed), &reader.Options{
+		UnserializeOptions: &native.UnserializeOptions{TrackSource: true},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, doc.GetNodeList().GetNodes())
+	require.Empty(t, doc.GetMetadata().GetSourceData().GetSignerKeyId())
+
+	// Unsigned documents are rejected when there are verifiers
+	_, err = reader.New().ParseStreamWithOptions(bytes.NewReader(data), opts)
+	require.Error(t, err)
+
+	// Signatures made with other keys are rejected
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	other, err := dsse.NewVerifier(otherKey.Public())
+	require.NoError(t, err)
+	_, err = reader.New().ParseStreamWithOptions(bytes.NewReader(signed), &reader.Options{
+		UnserializeOptions: &native.UnserializeOptions{},
+		Verifiers:          []dsse.Verifier{other},
+	})
+	require.ErrorIs(t, err, dsse.ErrUnverified)
+}
+
+func TestReaderTransformed(t *testing.T) {
+	t.Parallel()
+	reader.RegisterUnserializer(formats.SPDX23JSON, unserializers.NewSPDX23())
+
+	order := []string{}
+	enrich := transform.Func(func(doc *sbom.Document) error {
+		order = append(order, "enrich")
+		for _, n := range doc.GetNodeList().GetNodes() {
+			n.Properties = append(n.Properties, &sbom.Property{Name: "reviewed", Data: "true"})
+		}
+		return nil
+	})
+	count := transform.Func(func(doc *sbom.Document) error {
+		order = append(order, "count")
+		for _, n := range doc.GetNodeList().GetNodes() {
+			require.Len(t, n.GetProperties(), 1)
+		}
+		return nil
+	})
+
+	r := reader.New()
+	doc, err := r.ParseFileWithOptions("../formats/testdata/nginx.spdx.json", &reader.Options{
+		UnserializeOptions: &native.UnserializeOptions{},
+		Transformers:       []transform.Transformer{enrich, count},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"enrich", "count"}, order)
+	require.NotEmpty(t, doc.GetNodeList().GetNodes())
+
+	_, err = r.ParseFileWithOptions("../formats/testdata/nginx.spdx.json", &reader.Options{
+		UnserializeOptions: &native.UnserializeOptions{},
+		Transformers: []transform.Transformer{
+			transform.Func(func(*sbom.Document) error { return errors.New("boom") }),
+		},
+	})
+	require.ErrorContains(t, err, "boom")
+}
+
+func TestNewOptionsIsolated(t *testing.T) {
+	t.Parallel()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	verifier, err := dsse.NewVerifier(key.Public())
+	require.NoError(t, err)
+
+	signed := reader.New(reader.WithVerifier(verifier), reader.WithMod(mod.CYCLONEDX_MULTIROOT_HEADLESS))
+	require.Len(t, signed.Options.Verifiers, 1)
+
+	// Options set in a reader must not leak into readers created later
+	r := reader.New()
+	require.Empty(t, r.Options.Verifiers)
+	require.False(t, r.Options.UnserializeOptions.IsModEnabled(mod.CYCLONEDX_MULTIROOT_HEADLESS))
+	require.True(t, r.Options.UnserializeOptions.IsModEnabled(mod.SPDX_READ_ANNOTATIONS_TO_PROPERTIES))
+
+	reader.RegisterUnserializer(formats.SPDX23JSON, unserializers.NewSPDX23())
+	_, err = r.ParseFile("../formats/testdata/nginx.spdx.json")
+	require.NoError(t, err)
+}
+
+func TestWithTransformerIsolated(t *testing.T) {
+	t.Parallel()
+	calls := 0
+	count := transform.Func(func(*sbom.Document) error {
+		calls++
+		return nil
+	})
+	reader.New(reader.WithTransformer(count))
+	reader.New(reader.WithTransformer(count))
+
+	reader.RegisterUnserializer(formats.SPDX23JSON, unserializers.NewSPDX23())
+	r := reader.New()
+	require.Empty(t, r.Options.Transformers)
+	_, err := r.ParseFile("../formats/testdata/nginx.spdx.json")
+	require.NoError(t, err)
+	require.Zero(t, calls)
+}
@@ -0,0 +1,422 @@
+package sbom
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// CPEAny is the logical value of CPE attributes matching any value
+	CPEAny = "*"
+
+	// CPENotApplicable is the logical value of CPE attributes that do not
+	// apply to the product.
+	CPENotApplicable = "-"
+
+	cpe23Prefix = "cpe:2.3:"
+	cpe22Prefix = "cpe:/"
+)
+
+// CPE is a Common Platform Enumeration name. The attributes hold their values
+// as in the CPE 2.3 formatted string binding: lowercase, with special
+// characters escaped with a backslash and unescaped * and ? as wildcards.
+// Attributes not set in the name are CPEAny.
+//
+// See NISTIR 7695 (naming) and NISTIR 7696 (matching) for the specification.
+type CPE struct {
+	Part      string
+	Vendor    string
+	Product   string
+	Version   string
+	Update    string
+	Edition   string
+	Language  string
+	SwEdition string
+	TargetSw  string
+	TargetHw  string
+	Other     string
+}
+
+// cpeRelation is the result of comparing the attributes of two CPE names
+type cpeRelation int
+
+const (
+	cpeDisjoint cpeRelation = iota
+	cpeSubset
+	cpeSuperset
+	cpeEqual
+	cpeUndefined
+)
+
+// ParseCPE parses a CPE name in either the 2.3 formatted string binding
+// (cpe:2.3:a:vendor:product:...) or the 2.2 URI binding (cpe:/a:vendor:product).
+func ParseCPE(s string) (*CPE, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case len(s) >= len(cpe23Prefix) && strings.EqualFold(s[:len(cpe23Prefix)], cpe23Prefix):
+		return parseCPE23(s[len(cpe23Prefix):])
+	case len(s) >= len(cpe22Prefix) && strings.EqualFold(s[:len(cpe22Prefix)], cpe22Prefix):
+		return parseCPE22(s[len(cpe22Prefix):])
+	default:
+		return nil, fmt.Errorf("%q is not a CPE 2.2 or 2.3 name", s)
+	}
+}
+
+// attributes returns pointers to the attributes of the name in binding order
+func (c *CPE) attributes() []*string {
+	return []*string{
+		&c.Part, &c.Vendor, &c.Product, &c.Version, &c.Update, &c.Edition,
+		&c.Language, &c.SwEdition, &c.TargetSw, &c.TargetHw, &c.Other,
+	}
+}
+
+// parseCPE23 parses the components of a formatted string after its prefix.
+// Missing trailing components are set to CPEAny.
+func parseCPE23(s string) (*CPE, error) {
+	components := []string{}
+	var current strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 == len(s) {
+				return nil, errors.New("CPE ends with an escape character")
+			}
+			current.WriteByte(s[i])
+			current.WriteByte(s[i+1])
+			i++
+		case ':':
+			components = append(components, current.String())
+			current.Reset()
+		default:
+			current.WriteByte(s[i])
+		}
+	}
+	components = append(components, current.String())
+
+	c := &CPE{}
+	attrs := c.attributes()
+	if len(components) > len(attrs) {
+		return nil, fmt.Errorf("CPE has %d components, expected %d", len(components), len(attrs))
+	}
+	for i, attr := range attrs {
+		*attr = CPEAny
+		if i >= len(components) {
+			continue
+		}
+		if components[i] == "" {
+			return nil, fmt.Errorf("CPE component %d is empty", i+1)
+		}
+		*attr = normalizeCPEValue(components[i])
+	}
+	return c, c.validatePart()
+}
+
+// parseCPE22 parses the components of a URI after its prefix, unpacking the
+// extended attributes in the edition component.
+func parseCPE22(s string) (*CPE, error) {
+	components := strings.Split(s, ":")
+	if len(components) > 7 {
+		return nil, fmt.Errorf("CPE URI has %d components, expected up to 7", len(components))
+	}
+
+	c := &CPE{}
+	attrs := c.attributes()
+	for _, attr := range attrs {
+		*attr = CPEAny
+	}
+	for i, component := range components {
+		if i == 5 && strings.HasPrefix(component, "~") {
+			// The packed values are the edition and the extended attributes
+			packed := strings.Split(component, "~")
+			if len(packed) != 6 {
+				return nil, fmt.Errorf("invalid packed edition %q", component)
+			}
+			for j, attr := range []*string{&c.Edition, &c.SwEdition, &c.TargetSw, &c.TargetHw, &c.Other} {
+				v, err := decodeCPE22Value(packed[j+1])
+				if err != nil {
+					return nil, err
+				}
+				*attr = v
+			}
+			continue
+		}
+		v, err := decodeCPE22Value(component)
+		if err != nil {
+			return nil, err
+		}
+		*attrs[i] = v
+	}
+	return c, c.validatePart()
+}
+
+// validatePart checks the part is an application, operating system or
+// hardware device.
+func (c *CPE) validatePart() error {
+	switch c.Part {
+	case "a", "o", "h", CPEAny:
+		return nil
+	default:
+		return fmt.Errorf("invalid CPE part %q", c.Part)
+	}
+}
+
+// decodeCPE22Value converts a URI component to its formatted string value
+func decodeCPE22Value(s string) (string, error) {
+	switch s {
+	case "":
+		return CPEAny, nil
+	case CPENotApplicable:
+		return CPENotApplicable, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '%' {
+			b.WriteString(escapeCPEChar(s[i]))
+			continue
+		}
+		if i+2 >= len(s) {
+			return "", fmt.Errorf("invalid percent encoding in %q", s)
+		}
+		n, err := strconv.ParseUint(s[i+1:i+3], 16, 8)
+		if err != nil {
+			return "", fmt.Errorf("invalid percent encoding in %q", s)
+		}
+		c := byte(n)
+		i += 2
+		switch c {
+		case 0x01:
+			b.WriteByte('?')
+		case 0x02:
+			b.WriteByte('*')
+		default:
+			b.WriteString(escapeCPEChar(c))
+		}
+	}
+	return strings.ToLower(b.String()), nil
+}
+
+// normalizeCPEValue lowercases a formatted string value and escapes only the
+// characters that need it, so equal values compare equal as strings.
+func normalizeCPEValue(s string) string {
+	if s == CPEAny || s == CPENotApplicable {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && i+1 < len(s):
+			b.WriteString(escapeCPEChar(s[i+1]))
+			i++
+		case s[i] == '*' || s[i] == '?':
+			b.WriteByte(s[i])
+		default:
+			b.WriteString(escapeCPEChar(s[i]))
+		}
+	}
+	return strings.ToLower(b.String())
+}
+
+// escapeCPEChar returns the character as written in a formatted string value
+func escapeCPEChar(c byte) string {
+	if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '_' || c == '-' || c == '.' {
+		return string(c)
+	}
+	return `\` + string(c)
+}
+
+// String returns the name in the CPE 2.3 formatted string binding
+func (c *CPE) String() string {
+	values := []string{}
+	for _, attr := range c.attributes() {
+		if *attr == "" {
+			values = append(values, CPEAny)
+			continue
+		}
+		values = append(values, *attr)
+	}
+	return cpe23Prefix + strings.Join(values, ":")
+}
+
+// URI returns the name in the CPE 2.2 URI binding. The extended attributes
+// of CPE 2.3 are packed in the edition component when set.
+func (c *CPE) URI() string {
+	edition := encodeCPE22Value(c.Edition)
+	if !isCPEAny(c.SwEdition) || !isCPEAny(c.TargetSw) || !isCPEAny(c.TargetHw) || !isCPEAny(c.Other) {
+		edition = "~" + strings.Join([]string{
+			edition, encodeCPE22Value(c.SwEdition), encodeCPE22Value(c.TargetSw),
+			encodeCPE22Value(c.TargetHw), encodeCPE22Value(c.Other),
+		}, "~")
+	}
+
+	components := []string{
+		encodeCPE22Value(c.Part), encodeCPE22Value(c.Vendor), encodeCPE22Value(c.Product),
+		encodeCPE22Value(c.Version), encodeCPE22Value(c.Update), edition,
+		encodeCPE22Value(c.Language),
+	}
+	return strings.TrimRight(cpe22Prefix+strings.Join(components, ":"), ":")
+}
+
+// encodeCPE22Value converts a formatted string value to a URI component
+func encodeCPE22Value(s string) string {
+	switch s {
+	case "", CPEAny:
+		return ""
+	case CPENotApplicable:
+		return CPENotApplicable
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s):
+			i++
+			if e := escapeCPEChar(s[i]); len(e) == 1 {
+				b.WriteString(e)
+			} else {
+				fmt.Fprintf(&b, "%%%02x", s[i])
+			}
+		case c == '?':
+			b.WriteString("%01")
+		case c == '*':
+			b.WriteString("%02")
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// isCPEAny returns true if the attribute value is the logical value ANY
+func isCPEAny(s string) bool {
+	return s == "" || s == CPEAny
+}
+
+// Matches returns true if the name, used as a pattern, matches the target
+// name. This is the CPE name matching of NISTIR 7696: every attribute of the
+// pattern must be a superset of or equal to the target attribute. Pattern
+// values can use * (any number of characters) and ? (one character) as
+// wildcards.
+func (c *CPE) Matches(target *CPE) bool {
+	if target == nil {
+		return false
+	}
+	targetAttrs := target.attributes()
+	for i, attr := range c.attributes() {
+		switch compareCPEValues(*attr, *targetAttrs[i]) {
+		case cpeSuperset, cpeEqual:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// compareCPEValues compares the value of an attribute in a source name with
+// the one in the target name.
+func compareCPEValues(source, target string) cpeRelation {
+	if source == "" {
+		source = CPEAny
+	}
+	if target == "" {
+		target = CPEAny
+	}
+
+	switch {
+	case source == CPEAny && target == CPEAny:
+		return cpeEqual
+	case source == CPEAny:
+		return cpeSuperset
+	case source == CPENotApplicable && target == CPENotApplicable:
+		return cpeEqual
+	case target == CPEAny:
+		return cpeSubset
+	case source == CPENotApplicable || target == CPENotApplicable:
+		return cpeDisjoint
+	case hasCPEWildcard(target):
+		return cpeUndefined
+	case hasCPEWildcard(source):
+		if matchCPEWildcards(source, unescapeCPEValue(target)) {
+			return cpeSuperset
+		}
+		return cpeDisjoint
+	case source == target:
+		return cpeEqual
+	default:
+		return cpeDisjoint
+	}
+}
+
+// hasCPEWildcard returns true if the value has unescaped wildcards
+func hasCPEWildcard(s string) bool {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '*', '?':
+			return true
+		}
+	}
+	return false
+}
+
+// matchCPEWildcards reports whether the unescaped value matches the pattern
+// with wildcards: "*" matches any sequence of characters and "?" matches
+// one or no character. Escaped wildcards in the pattern are literals.
+func matchCPEWildcards(pattern, value string) bool {
+	// matched[j] records if the pattern read so far matches value[:j]
+	matched := make([]bool, len(value)+1)
+	next := make([]bool, len(value)+1)
+	matched[0] = true
+	for i := 0; i < len(pattern); i++ {
+		c, wildcard := pattern[i], pattern[i] == '*' || pattern[i] == '?'
+		if c == '\\' && i+1 < len(pattern) {
+			i++
+			c, wildcard = pattern[i], false
+		}
+		for j := range next {
+			prev := j > 0 && matched[j-1]
+			switch {
+			case wildcard && c == '*':
+				next[j] = matched[j] || (j > 0 && next[j-1])
+			case wildcard:
+				next[j] = matched[j] || prev
+			default:
+				next[j] = prev && value[j-1] == c
+			}
+		}
+		matched, next = next, matched
+	}
+	return matched[len(value)]
+}
+
+// unescapeCPEValue removes the escape characters of a value
+func unescapeCPEValue(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// cpeMatches returns true if the target CPE matches the pattern. Both can
+// be in either binding, names that cannot be parsed only match when they are
+// identical.
+func cpeMatches(pattern, target string) bool {
+	p, err := ParseCPE(pattern)
+	if err != nil {
+		return pattern != "" && pattern == target
+	}
+	t, err := ParseCPE(target)
+	if err != nil {
+		return false
+	}
+	return p.Matches(t)
+}
@@ -0,0 +1,169 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCPE(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		input   string
+		cpe23   string
+		cpe22   string
+		mustErr bool
+	}{
+		{
+			input: "cpe:2.3:a:nginx:nginx:1.21.1:*:*:*:*:*:*:*",
+			cpe23: "cpe:2.3:a:nginx:nginx:1.21.1:*:*:*:*:*:*:*",
+			cpe22: "cpe:/a:nginx:nginx:1.21.1",
+		},
+		{
+			input: "cpe:/a:Microsoft:Internet_Explorer:8.0.6001:beta",
+			cpe23: "cpe:2.3:a:microsoft:internet_explorer:8.0.6001:beta:*:*:*:*:*:*",
+			cpe22: "cpe:/a:microsoft:internet_explorer:8.0.6001:beta",
+		},
+		{
+			input: "cpe:/a:hp:insight_diagnostics:7.4.0.1570:-:~~online~win2003~x64~",
+			cpe23: "cpe:2.3:a:hp:insight_diagnostics:7.4.0.1570:-:*:*:online:win2003:x64:*",
+			cpe22: "cpe:/a:hp:insight_diagnostics:7.4.0.1570:-:~~online~win2003~x64~",
+		},
+		{
+			input: "cpe:/a:foo%5cbar:big%24money_manager_2010::::en",
+			cpe23: `cpe:2.3:a:foo\\bar:big\$money_manager_2010:*:*:*:en:*:*:*:*`,
+			cpe22: "cpe:/a:foo%5cbar:big%24money_manager_2010::::en",
+		},
+		{
+			input: `cpe:2.3:a:example:foo\:bar:1.\0:*:*:*:*:*:*:*`,
+			cpe23: `cpe:2.3:a:example:foo\:bar:1.0:*:*:*:*:*:*:*`,
+			cpe22: "cpe:/a:example:foo%3abar:1.0",
+		},
+		{
+			input: "cpe:2.3:a:apache:tomcat:8.*",
+			cpe23: "cpe:2.3:a:apache:tomcat:8.*:*:*:*:*:*:*:*",
+			cpe22: "cpe:/a:apache:tomcat:8.%02",
+		},
+		{input: "cpe:2.3:x:vendor:product", mustErr: true},
+		{input: "cpe:2.3:a:vendor::product", mustErr: true},
+		{input: "cpe:2.3:a:b:c:d:e:f:g:h:i:j:k:l", mustErr: true},
+		{input: `cpe:2.3:a:vendor:product\`, mustErr: true},
+		{input: "cpe:/a:vendor:product%zz", mustErr: true},
+		{input: "pkg:npm/left-pad", mustErr: true},
+	} {
+		c, err := ParseCPE(tc.input)
+		if tc.mustErr {
+			require.Error(t, err, tc.input)
+			continue
+		}
+		require.NoError(t, err, tc.input)
+		require.Equal(t, tc.cpe23, c.String(), tc.input)
+		require.Equal(t, tc.cpe22, c.URI(), tc.input)
+
+		// Converting back and forth must produce the same name
+		c2, err := ParseCPE(c.URI())
+		require.NoError(t, err)
+		require.Equal(t, c, c2, tc.input)
+	}
+}
+
+func TestCPEMatches(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		pattern string
+		target  string
+		matches bool
+	}{
+		{"cpe:2.3:a:nginx:nginx:1.21.1:*:*:*:*:*:*:*", "cpe:2.3:a:nginx:nginx:1.21.1:*:*:*:*:*:*:*", true},
+		{"cpe:2.3:a:nginx:nginx:*:*:*:*:*:*:*:*", "cpe:2.3:a:nginx:nginx:1.21.1:*:*:*:*:*:*:*", true},
+		{"cpe:2.3:a:nginx:nginx", "cpe:/a:nginx:nginx:1.21.1", true},
+		{"cpe:2.3:a:NGINX:Nginx:1.21.1", "cpe:2.3:a:nginx:nginx:1.21.1:*:*:*:*:*:*:*", true},
+		{"cpe:2.3:a:nginx:nginx:1.21.1:*:*:*:*:*:*:*", "cpe:2.3:a:nginx:nginx:*:*:*:*:*:*:*:*", false},
+		{"cpe:2.3:a:nginx:nginx:1.21.1", "cpe:2.3:a:nginx:nginx:1.21.2", false},
+		{"cpe:2.3:a:apache:tomcat:8.*", "cpe:2.3:a:apache:tomcat:8.5.31", true},
+		{"cpe:2.3:a:apache:tomcat:8.*", "cpe:2.3:a:apache:tomcat:9.0.1", false},
+		{"cpe:2.3:a:apache:tomcat:8.5.?", "cpe:2.3:a:apache:tomcat:8.5.1", true},
+		{"cpe:2.3:a:apache:tomcat:8.5.?", "cpe:2.3:a:apache:tomcat:8.5.31", false},
+		{"cpe:2.3:a:apache:tomcat:8.5.31", "cpe:2.3:a:apache:tomcat:8.*", false},
+		{"cpe:2.3:a:apache:tomcat:*:-", "cpe:2.3:a:apache:tomcat:8.5.31:-", true},
+		{"cpe:2.3:a:apache:tomcat:*:-", "cpe:2.3:a:apache:tomcat:8.5.31:beta", false},
+		{"cpe:2.3:a:apache:tomcat:*:*", "cpe:2.3:a:apache:tomcat:8.5.31:-", true},
+		{"cpe:2.3:o:apache:tomcat", "cpe:2.3:a:apache:tomcat", false},
+	} {
+		p, err := ParseCPE(tc.pattern)
+		require.NoError(t, err)
+		target, err := ParseCPE(tc.target)
+		require.NoError(t, err)
+		require.Equal(t, tc.matches, p.Matches(target), "%s ~ %s", tc.pattern, tc.target)
+	}
+}
+
+func TestMatchCPEWildcards(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		pattern string
+		value   string
+		matches bool
+	}{
+		{"8.*", "8.5.31", true},
+		{"8.*", "8.", true},
+		{"8.*", "9.0", false},
+		{"*.31", "8.5.31", true},
+		{"8*5*1", "8.5.31", true},
+		{"8.5.?", "8.5.1", true},
+		{"8.5.?", "8.5.", true},
+		{"8.5.?", "8.5.31", false},
+		{"??5", "8.5", true},
+		{`1\*`, "1*", true},
+		{`1\*`, "12", false},
+		{`a\?`, "a?", true},
+		{"*", "", true},
+		{"", "", true},
+		{"", "a", false},
+	} {
+		require.Equal(t, tc.matches, matchCPEWildcards(tc.pattern, tc.value), "%s ~ %s", tc.pattern, tc.value)
+	}
+}
+
+func TestGetNodesByCPE(t *testing.T) {
+	t.Parallel()
+	nl := &NodeList{
+		Nodes: []*Node{
+			{Id: "nginx", Identifiers: map[int32]string{int32(SoftwareIdentifierType_CPE23): "cpe:2.3:a:nginx:nginx:1.21.1:*:*:*:*:*:*:*"}},
+			{Id: "tomcat-8", Identifiers: map[int32]string{int32(SoftwareIdentifierType_CPE22): "cpe:/a:apache:tomcat:8.5.31"}},
+			{Id: "tomcat-9", Identifiers: map[int32]string{
+				int32(SoftwareIdentifierType_CPE22): "cpe:/a:apache:tomcat:9.0.1",
+				int32(SoftwareIdentifierType_CPE23): "cpe:2.3:a:apache:tomcat:9.0.1:*:*:*:*:*:*:*",
+			}},
+			{Id: "invalid", Identifiers: map[int32]string{int32(SoftwareIdentifierType_CPE23): "not a cpe"}},
+			{Id: "no-cpe"},
+		},
+	}
+
+	ids := func(nodes []*Node) []string {
+		ret := []string{}
+		for _, n := range nodes {
+			ret = append(ret, n.Id)
+		}
+		return ret
+	}
+
+	res, err := nl.GetNodesByCPE("cpe:2.3:a:apache:tomcat:*:*:*:*:*:*:*:*")
+	require.NoError(t, err)
+	require.Equal(t, []string{"tomcat-8", "tomcat-9"}, ids(res))
+
+	res, err = nl.GetNodesByCPE("cpe:/a:apache:tomcat:8.%02")
+	require.NoError(t, err)
+	require.Equal(t, []string{"tomcat-8"}, ids(res))
+
+	res, err = nl.GetNodesByCPE("cpe:2.3:a:nginx")
+	require.NoError(t, err)
+	require.Equal(t, []string{"nginx"}, ids(res))
+
+	_, err = nl.GetNodesByCPE("not a cpe")
+	require.Error(t, err)
+
+	// Identifier lookups use the value as a pattern
+	require.Equal(t, []string{"tomcat-9"}, ids(nl.GetNodesByIdentifier("cpe23", "cpe:2.3:a:apache:tomcat")))
+	require.Equal(t, []string{"invalid"}, ids(nl.GetNodesByIdentifier("cpe23", "not a cpe")))
+}
@@ -0,0 +1,342 @@
+package sbom
+
+import (
+	"container/heap"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// This file adds functions to detect and break cycles in the NodeList graph
+// and to compute a topological ordering of its nodes.
+
+// CycleError is returned when an operation that requires an acyclic graph
+// finds cycles in the NodeList.
+type CycleError struct {
+	Cycles []NodePath
+}
+
+func (e *CycleError) Error() string {
+	cycles := make([]string, 0, len(e.Cycles))
+	for _, c := range e.Cycles {
+		cycles = append(cycles, strings.Join(append(slices.Clone(c), c[0]), " -> "))
+	}
+	return fmt.Sprintf("graph has %d cycle(s): %s", len(e.Cycles), strings.Join(cycles, ", "))
+}
+
+// adjacencyIndex holds the deduplicated, sorted destinations of each node
+type adjacencyIndex map[string][]string
+
+// indexAdjacency returns the nodes each node points to through edges of the
+// specified types. If no types are specified, edges of all types are
+// considered. Destinations not found in the NodeList are ignored.
+func (nl *NodeList) indexAdjacency(edgeTypes ...Edge_Type) adjacencyIndex {
+	opts := &TraversalOptions{EdgeTypes: edgeTypes}
+	nodeIdx := nl.indexNodes()
+	sets := map[string]map[string]struct{}{}
+	for _, e := range nl.Edges {
+		if !opts.followsType(e.Type) {
+			continue
+		}
+		if _, ok := nodeIdx[e.From]; !ok {
+			continue
+		}
+		if _, ok := sets[e.From]; !ok {
+			sets[e.From] = map[string]struct{}{}
+		}
+		for _, to := range e.To {
+			if _, ok := nodeIdx[to]; ok {
+				sets[e.From][to] = struct{}{}
+			}
+		}
+	}
+
+	index := adjacencyIndex{}
+	for from, tos := range sets {
+		for to := range tos {
+			index[from] = append(index[from], to)
+		}
+		slices.Sort(index[from])
+	}
+	return index
+}
+
+// sortedNodeIDs returns the identifiers of the NodeList nodes sorted
+func (nl *NodeList) sortedNodeIDs() []string {
+	ids := make([]string, 0, len(nl.Nodes))
+	seen := map[string]struct{}{}
+	for _, n := range nl.Nodes {
+		if _, ok := seen[n.Id]; ok {
+			continue
+		}
+		seen[n.Id] = struct{}{}
+		ids = append(ids, n.Id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// stronglyConnected returns the strongly connected components of the graph
+// using Tarjan's algorithm.
+func (nl *NodeList) stronglyConnected(adj adjacencyIndex) [][]string {
+	index := 0
+	indexes := map[string]int{}
+	lowlinks := map[string]int{}
+	onStack := map[string]bool{}
+	stack := []string{}
+	components := [][]string{}
+
+	var connect func(id string)
+	connect = func(id string) {
+		indexes[id] = index
+		lowlinks[id] = index
+		index++
+		stack = append(stack, id)
+		onStack[id] = true
+
+		for _, to := range adj[id] {
+			if _, ok := indexes[to]; !ok {
+				connect(to)
+				lowlinks[id] = min(lowlinks[id], lowlinks[to])
+			} else if onStack[to] {
+				lowlinks[id] = min(lowlinks[id], indexes[to])
+			}
+		}
+
+		if lowlinks[id] != indexes[id] {
+			return
+		}
+
+		component := []string{}
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == id {
+				break
+			}
+		}
+		slices.Sort(component)
+		components = append(components, component)
+	}
+
+	for _, id := range nl.sortedNodeIDs() {
+		if _, ok := indexes[id]; !ok {
+			connect(id)
+		}
+	}
+	return components
+}
+
+// componentCycle returns a cycle running through the first node of a
+// strongly connected component. It walks the component breadth-first until
+// it finds a node pointing back to the start.
+func componentCycle(adj adjacencyIndex, component []string) NodePath {
+	start := component[0]
+	members := map[string]struct{}{}
+	for _, id := range component {
+		members[id] = struct{}{}
+	}
+
+	parents := map[string]string{start: ""}
+	queue := []string{start}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, to := range adj[current] {
+			if to == start {
+				path := NodePath{}
+				for id := current; id != ""; id = parents[id] {
+					path = append(path, id)
+				}
+				slices.Reverse(path)
+				return path
+			}
+			if _, ok := members[to]; !ok {
+				continue
+			}
+			if _, ok := parents[to]; ok {
+				continue
+			}
+			parents[to] = current
+			queue = append(queue, to)
+		}
+	}
+	return nil
+}
+
+// Cycles returns the cycles found in the NodeList graph considering only the
+// edges of the specified types (or all edges if no types are specified).
+//
+// Each cycle is returned as a NodePath where the last node has an edge that
+// points back to the first one. One cycle is reported for each group of
+// interdependent nodes (strongly connected component) in the graph, nodes
+// with edges pointing to themselves are reported as single-element cycles.
+func (nl *NodeList) Cycles(edgeTypes ...Edge_Type) []NodePath {
+	adj := nl.indexAdjacency(edgeTypes...)
+	ret := []NodePath{}
+	for _, component := range nl.stronglyConnected(adj) {
+		if len(component) == 1 {
+			if slices.Contains(adj[component[0]], component[0]) {
+				ret = append(ret, NodePath{component[0]})
+			}
+			continue
+		}
+		ret = append(ret, componentCycle(adj, component))
+	}
+	slices.SortFunc(ret, func(a, b NodePath) int {
+		return strings.Compare(a[0], b[0])
+	})
+	return ret
+}
+
+// HasCycles returns true if the NodeList graph contains cycles when following
+// the edges of the specified types (or all edges if no types are specified).
+func (nl *NodeList) HasCycles(edgeTypes ...Edge_Type) bool {
+	return len(nl.Cycles(edgeTypes...)) > 0
+}
+
+// idHeap is a min-heap of node identifiers used to make the topological
+// sort deterministic.
+type idHeap []string
+
+func (h idHeap) Len() int           { return len(h) }
+func (h idHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h idHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *idHeap) Push(x any)        { *h = append(*h, x.(string)) } //nolint:forcetypeassert,errcheck // Only strings are pushed
+func (h *idHeap) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
+
+// TopologicalSort returns the nodes of the NodeList ordered so that for every
+// edge of the specified types (or all edges when none are specified) the node
+// where the edge originates comes before all of its destinations. Nodes not
+// constrained by any edge are ordered by their identifier, so the result is
+// deterministic for the same graph.
+//
+// For dependency edges, the reverse of this order is a valid build order. If
+// the graph has cycles, a *CycleError is returned listing them.
+func (nl *NodeList) TopologicalSort(edgeTypes ...Edge_Type) ([]*Node, error) {
+	adj := nl.indexAdjacency(edgeTypes...)
+	nodeIdx := nl.indexNodes()
+
+	inDegree := map[string]int{}
+	for _, tos := range adj {
+		for _, to := range tos {
+			inDegree[to]++
+		}
+	}
+
+	ready := &idHeap{}
+	for _, id := range nl.sortedNodeIDs() {
+		if inDegree[id] == 0 {
+			*ready = append(*ready, id)
+		}
+	}
+	heap.Init(ready)
+
+	ret := make([]*Node, 0, len(nodeIdx))
+	for ready.Len() > 0 {
+		id := heap.Pop(ready).(string) //nolint:forcetypeassert,errcheck // Only strings are pushed
+		ret = append(ret, nodeIdx[id])
+		for _, to := range adj[id] {
+			inDegree[to]--
+			if inDegree[to] == 0 {
+				heap.Push(ready, to)
+			}
+		}
+	}
+
+	if len(ret) != len(nodeIdx) {
+		return nil, &CycleError{Cycles: nl.Cycles(edgeTypes...)}
+	}
+	return ret, nil
+}
+
+// BreakCycles removes the relationships that close cycles in the NodeList
+// graph, considering only edges of the specified types (or all edges if no
+// types are specified). The graph is walked depth-first from the root
+// elements and then from the rest of the nodes in identifier order, removing
+// every edge that points back to a node in the current path.
+//
+// The removed relationships are returned as a list of edges, each with a
+// single destination. After calling BreakCycles, TopologicalSort is
+// guaranteed to succeed for the same edge types.
+func (nl *NodeList) BreakCycles(edgeTypes ...Edge_Type) []*Edge {
+	opts := &TraversalOptions{EdgeTypes: edgeTypes}
+	edgeIdx := nl.indexEdges()
+	nodeIdx := nl.indexNodes()
+
+	const (
+		unvisited = iota
+		inPath
+		done
+	)
+	state := map[string]int{}
+	removed := []*Edge{}
+	emptied := map[*Edge]struct{}{}
+
+	var visit func(id string)
+	visit = func(id string) {
+		state[id] = inPath
+
+		types := make([]Edge_Type, 0, len(edgeIdx[id]))
+		for t := range edgeIdx[id] {
+			if opts.followsType(t) {
+				types = append(types, t)
+			}
+		}
+		slices.Sort(types)
+
+		for _, t := range types {
+			for _, e := range edgeIdx[id][t] {
+				kept := make([]string, 0, len(e.To))
+				for _, to := range e.To {
+					if _, ok := nodeIdx[to]; !ok {
+						kept = append(kept, to)
+						continue
+					}
+					switch state[to] {
+					case inPath:
+						removed = append(removed, &Edge{Type: e.Type, From: e.From, To: []string{to}})
+						continue
+					case unvisited:
+						visit(to)
+					}
+					kept = append(kept, to)
+				}
+				if len(kept) == 0 && len(e.To) > 0 {
+					emptied[e] = struct{}{}
+				}
+				e.To = kept
+			}
+		}
+		state[id] = done
+	}
+
+	starts := slices.Clone(nl.RootElements)
+	starts = append(starts, nl.sortedNodeIDs()...)
+	for _, id := range starts {
+		if _, ok := nodeIdx[id]; !ok {
+			continue
+		}
+		if state[id] == unvisited {
+			visit(id)
+		}
+	}
+
+	// Drop the edges left without destinations
+	if len(emptied) > 0 {
+		nl.Edges = slices.DeleteFunc(nl.Edges, func(e *Edge) bool {
+			_, ok := emptied[e]
+			return ok
+		})
+	}
+
+	return removed
+}
@@ -0,0 +1,103 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func cyclicTestGraph() *NodeList {
+	//  root -> a -> b -> c
+	//          ^         |
+	//          +---------+
+	//  d -> d (self reference, contains)
+	return &NodeList{
+		Nodes: []*Node{{Id: "root"}, {Id: "a"}, {Id: "b"}, {Id: "c"}, {Id: "d"}},
+		Edges: []*Edge{
+			{Type: Edge_dependsOn, From: "root", To: []string{"a", "d"}},
+			{Type: Edge_dependsOn, From: "a", To: []string{"b"}},
+			{Type: Edge_dependsOn, From: "b", To: []string{"c"}},
+			{Type: Edge_dependsOn, From: "c", To: []string{"a"}},
+			{Type: Edge_contains, From: "d", To: []string{"d"}},
+		},
+		RootElements: []string{"root"},
+	}
+}
+
+func TestCycles(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		sut      *NodeList
+		types    []Edge_Type
+		expected []NodePath
+	}{
+		{"all types", cyclicTestGraph(), nil, []NodePath{{"a", "b", "c"}, {"d"}}},
+		{"dependencies", cyclicTestGraph(), []Edge_Type{Edge_dependsOn}, []NodePath{{"a", "b", "c"}}},
+		{"no matching edges", cyclicTestGraph(), []Edge_Type{Edge_devDependency}, []NodePath{}},
+		{"acyclic", traversalTestGraph(), nil, []NodePath{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tc.expected, tc.sut.Cycles(tc.types...))
+			require.Equal(t, len(tc.expected) > 0, tc.sut.HasCycles(tc.types...))
+		})
+	}
+}
+
+func TestTopologicalSort(t *testing.T) {
+	t.Parallel()
+	t.Run("acyclic", func(t *testing.T) {
+		t.Parallel()
+		nodes, err := traversalTestGraph().TopologicalSort()
+		require.NoError(t, err)
+		ids := []string{}
+		for _, n := range nodes {
+			ids = append(ids, n.Id)
+		}
+		require.Equal(t, []string{"root", "lib-a", "file-a", "tool", "lib-b"}, ids)
+	})
+
+	t.Run("cyclic", func(t *testing.T) {
+		t.Parallel()
+		_, err := cyclicTestGraph().TopologicalSort()
+		require.Error(t, err)
+		var cerr *CycleError
+		require.ErrorAs(t, err, &cerr)
+		require.Len(t, cerr.Cycles, 2)
+	})
+
+	t.Run("cycle outside edge types", func(t *testing.T) {
+		t.Parallel()
+		nodes, err := cyclicTestGraph().TopologicalSort(Edge_contains)
+		require.Error(t, err)
+		require.Nil(t, nodes)
+
+		sut := cyclicTestGraph()
+		sut.Edges = sut.Edges[:4]
+		_, err = sut.TopologicalSort(Edge_contains)
+		require.NoError(t, err)
+	})
+}
+
+func TestBreakCycles(t *testing.T) {
+	t.Parallel()
+	sut := cyclicTestGraph()
+	removed := sut.BreakCycles()
+	require.Len(t, removed, 2)
+	require.Equal(t, "c", removed[0].From)
+	require.Equal(t, []string{"a"}, removed[0].To)
+	require.Equal(t, "d", removed[1].From)
+	require.Equal(t, []string{"d"}, removed[1].To)
+
+	require.False(t, sut.HasCycles())
+	require.Len(t, sut.Edges, 3)
+	_, err := sut.TopologicalSort()
+	require.NoError(t, err)
+
+	// Breaking only one type leaves the rest alone
+	sut = cyclicTestGraph()
+	removed = sut.BreakCycles(Edge_contains)
+	require.Len(t, removed, 1)
+	require.True(t, sut.HasCycles(Edge_dependsOn))
+}
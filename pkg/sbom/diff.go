@@ -1,6 +1,8 @@
 package sbom
 
 import (
+	"slices"
+	"strings"
 	"time"
 
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
@@ -12,6 +14,96 @@ type NodeDiff struct {
 	DiffCount int
 }
 
+// NodeListDiff captures the differences between two node lists. Nodes are
+// matched by their ID. Edges are flattened so that each one relates a
+// single pair of nodes.
+type NodeListDiff struct {
+	AddedNodes   []*Node
+	RemovedNodes []*Node
+
+	// ChangedNodes holds the diff of the nodes present in both lists,
+	// keyed by node ID.
+	ChangedNodes map[string]*NodeDiff
+	AddedEdges   []*Edge
+	RemovedEdges []*Edge
+}
+
+// Empty returns true when the diff has no changes
+func (d *NodeListDiff) Empty() bool {
+	return len(d.AddedNodes) == 0 && len(d.RemovedNodes) == 0 && len(d.ChangedNodes) == 0 &&
+		len(d.AddedEdges) == 0 && len(d.RemovedEdges) == 0
+}
+
+// Diff compares the node list with nl2 and returns the nodes and edges
+// added, removed and changed in nl2. Nodes and edges are sorted by ID.
+func (nl *NodeList) Diff(nl2 *NodeList) *NodeListDiff {
+	if nl == nil {
+		nl = NewNodeList()
+	}
+	if nl2 == nil {
+		nl2 = NewNodeList()
+	}
+
+	d := &NodeListDiff{
+		AddedNodes:   []*Node{},
+		RemovedNodes: []*Node{},
+		ChangedNodes: map[string]*NodeDiff{},
+		AddedEdges:   []*Edge{},
+		RemovedEdges: []*Edge{},
+	}
+
+	nodes1 := nl.indexNodes()
+	nodes2 := nl2.indexNodes()
+	for _, n := range nl2.GetNodes() {
+		n1, ok := nodes1[n.Id]
+		if !ok {
+			d.AddedNodes = append(d.AddedNodes, n)
+			continue
+		}
+		if nd := n1.Diff(n); nd != nil {
+			d.ChangedNodes[n.Id] = nd
+		}
+	}
+	for _, n := range nl.GetNodes() {
+		if _, ok := nodes2[n.Id]; !ok {
+			d.RemovedNodes = append(d.RemovedNodes, n)
+		}
+	}
+
+	edges1, edges2 := flatEdges(nl), flatEdges(nl2)
+	for k, e := range edges2 {
+		if _, ok := edges1[k]; !ok {
+			d.AddedEdges = append(d.AddedEdges, e)
+		}
+	}
+	for k, e := range edges1 {
+		if _, ok := edges2[k]; !ok {
+			d.RemovedEdges = append(d.RemovedEdges, e)
+		}
+	}
+
+	sortNodes := func(a, b *Node) int { return strings.Compare(a.Id, b.Id) }
+	slices.SortFunc(d.AddedNodes, sortNodes)
+	slices.SortFunc(d.RemovedNodes, sortNodes)
+	sortEdges := func(a, b *Edge) int { return strings.Compare(a.flatString(), b.flatString()) }
+	slices.SortFunc(d.AddedEdges, sortEdges)
+	slices.SortFunc(d.RemovedEdges, sortEdges)
+	return d
+}
+
+// flatEdges returns the edges of a node list split to relate one pair of
+// nodes each, keyed by their flat string.
+func flatEdges(nl *NodeList) map[string]*Edge {
+	ret := map[string]*Edge{}
+	for _, e := range nl.GetEdges() {
+		for _, to := range e.To {
+			fe := &Edge{Type: e.Type, From: e.From, To: []string{to}}
+			ret[fe.flatString()] = fe
+		}
+	}
+	return ret
+}
+
 // Diff analyses a node and returns a a new node populated with all fields
 // that are different in n2 from n. If no changes are found, Diff returns nil
 func (n *Node) Diff(n2 *Node) *NodeDiff {
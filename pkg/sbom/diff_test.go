@@ -613,3 +613,42 @@ func TestDiffIntStrMap(t *testing.T) {
 		})
 	}
 }
+
+func TestNodeListDiff(t *testing.T) {
+	nl1 := &NodeList{
+		Nodes: []*Node{
+			{Id: "a", Name: "a"},
+			{Id: "b", Name: "b"},
+			{Id: "c", Name: "c"},
+		},
+		Edges: []*Edge{
+			{Type: Edge_dependsOn, From: "a", To: []string{"b", "c"}},
+		},
+	}
+	nl2 := &NodeList{
+		Nodes: []*Node{
+			{Id: "a", Name: "a"},
+			{Id: "b", Name: "b", Version: "2.0"},
+			{Id: "d", Name: "d"},
+		},
+		Edges: []*Edge{
+			{Type: Edge_dependsOn, From: "a", To: []string{"b", "d"}},
+		},
+	}
+
+	d := nl1.Diff(nl2)
+	require.False(t, d.Empty())
+	require.Len(t, d.AddedNodes, 1)
+	require.Equal(t, "d", d.AddedNodes[0].Id)
+	require.Len(t, d.RemovedNodes, 1)
+	require.Equal(t, "c", d.RemovedNodes[0].Id)
+	require.Len(t, d.ChangedNodes, 1)
+	require.Equal(t, "2.0", d.ChangedNodes["b"].Added.Version)
+	require.Len(t, d.AddedEdges, 1)
+	require.Equal(t, []string{"d"}, d.AddedEdges[0].To)
+	require.Len(t, d.RemovedEdges, 1)
+	require.Equal(t, []string{"c"}, d.RemovedEdges[0].To)
+
+	require.True(t, nl1.Diff(nl1.Copy()).Empty())
+	require.Len(t, (*NodeList)(nil).Diff(nl1).AddedNodes, 3)
+}
@@ -0,0 +1,46 @@
+package sbom
+
+// MergeDocuments combines the graphs of several documents into a new one. The
+// nodes, edges and root elements of all the documents are added to the new
+// document NodeList, nodes with the same ID are combined into one. The tools
+// and authors of the merged documents are added to the new metadata.
+//
+// The returned document does not share data with the original documents and
+// its metadata is left empty except for the tools and authors lists.
+func MergeDocuments(docs ...*Document) *Document {
+	ret := NewDocument()
+	seenTools := map[string]struct{}{}
+	seenAuthors := map[string]struct{}{}
+	for _, d := range docs {
+		if d == nil {
+			continue
+		}
+
+		if d.GetNodeList() != nil {
+			ret.NodeList.Add(d.GetNodeList().Copy())
+		}
+
+		for _, t := range d.GetMetadata().GetTools() {
+			key := t.GetName() + "@" + t.GetVersion() + "@" + t.GetVendor()
+			if _, ok := seenTools[key]; ok {
+				continue
+			}
+			seenTools[key] = struct{}{}
+			ret.Metadata.Tools = append(ret.Metadata.Tools, &Tool{
+				Name:    t.GetName(),
+				Version: t.GetVersion(),
+				Vendor:  t.GetVendor(),
+			})
+		}
+
+		for _, a := range d.GetMetadata().GetAuthors() {
+			key := a.flatString()
+			if _, ok := seenAuthors[key]; ok {
+				continue
+			}
+			seenAuthors[key] = struct{}{}
+			ret.Metadata.Authors = append(ret.Metadata.Authors, a.Copy())
+		}
+	}
+	return ret
+}
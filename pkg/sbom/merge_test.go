@@ -0,0 +1,37 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeDocuments(t *testing.T) {
+	t.Parallel()
+	doc1 := &Document{
+		Metadata: &Metadata{Tools: []*Tool{{Name: "syft", Version: "1.0"}}},
+		NodeList: &NodeList{
+			Nodes:        []*Node{{Id: "a", Name: "a"}, {Id: "shared", Name: "shared"}},
+			Edges:        []*Edge{{Type: Edge_dependsOn, From: "a", To: []string{"shared"}}},
+			RootElements: []string{"a"},
+		},
+	}
+	doc2 := &Document{
+		Metadata: &Metadata{Tools: []*Tool{{Name: "syft", Version: "1.0"}, {Name: "bom"}}},
+		NodeList: &NodeList{
+			Nodes:        []*Node{{Id: "b", Name: "b"}, {Id: "shared", Name: "shared", Version: "2"}},
+			Edges:        []*Edge{{Type: Edge_dependsOn, From: "b", To: []string{"shared"}}},
+			RootElements: []string{"b"},
+		},
+	}
+
+	merged := MergeDocuments(doc1, nil, doc2)
+	require.Len(t, merged.NodeList.Nodes, 3)
+	require.Len(t, merged.NodeList.Edges, 2)
+	require.Equal(t, []string{"a", "b"}, merged.NodeList.RootElements)
+	require.Len(t, merged.Metadata.Tools, 2)
+	require.Equal(t, "2", merged.NodeList.GetNodeByID("shared").Version)
+
+	// The original documents are not modified
+	require.Empty(t, doc1.NodeList.Nodes[1].Version)
+}
@@ -375,6 +375,12 @@ func (n *Node) Purl() PackageURL {
 	return ""
 }
 
+// NormalizedPurl returns the node's Package URL in its canonical form, used
+// to match nodes by purl. See [PackageURL.Normalize].
+func (n *Node) NormalizedPurl() PackageURL {
+	return n.Purl().Normalize()
+}
+
 // HashesMatch checks if the provided test-hashes (th) match those of the node.
 // It only considers common algorithms between the node and the test hashes.
 //
@@ -66,6 +66,22 @@ func (nl *NodeList) indexEdges() edgeIndex {
 	return index
 }
 
+// indexEdgesByDestination returns the edges of the nodeList indexed by their
+// To elements and type. An edge pointing to more than one node is indexed
+// under each of its destinations.
+func (nl *NodeList) indexEdgesByDestination() edgeIndex {
+	index := edgeIndex{}
+	for i := range nl.Edges {
+		for _, to := range nl.Edges[i].To {
+			if _, ok := index[to]; !ok {
+				index[to] = map[Edge_Type][]*Edge{}
+			}
+			index[to][nl.Edges[i].Type] = append(index[to][nl.Edges[i].Type], nl.Edges[i])
+		}
+	}
+	return index
+}
+
 // indexRootElements returns an index of the NodeList's top level elements by ID
 func (nl *NodeList) indexRootElements() rootElementsIndex {
 	index := rootElementsIndex{}
@@ -91,12 +107,12 @@ func (nl *NodeList) indexNodesByHash() hashIndex {
 	return ret
 }
 
-// Returns an indexed map of nodes by their package URLs. Note that more than
-// one node may have the same purl.
-func (nl *NodeList) indexNodesByPurl() map[PackageURL][]*Node {
-	ret := map[PackageURL][]*Node{}
+// Returns an indexed map of nodes by their normalized package URLs. Note that
+// more than one node may have the same purl.
+func (nl *NodeList) indexNodesByPurl() purlIndex {
+	ret := purlIndex{}
 	for _, n := range nl.Nodes {
-		nodePurl := n.Purl()
+		nodePurl := n.NormalizedPurl()
 		if nodePurl == "" {
 			continue
 		}
@@ -106,6 +122,24 @@ func (nl *NodeList) indexNodesByPurl() map[PackageURL][]*Node {
 	return ret
 }
 
+// lookup returns the nodes in the index whose purl matches p. Purls without
+// wildcards are looked up by their canonical form, only patterns with
+// wildcards are compared against every entry in the index.
+func (idx purlIndex) lookup(p PackageURL) []*Node {
+	parts, err := p.Parse()
+	if err != nil || !parts.hasWildcards() {
+		return idx[p.Normalize()]
+	}
+
+	ret := []*Node{}
+	for ip, nodes := range idx {
+		if ip.Matches(p, nil) {
+			ret = append(ret, nodes...)
+		}
+	}
+	return ret
+}
+
 // cleanEdges is a utility function that removes broken
 // connection and orphaned edges
 func (nl *NodeList) cleanEdges() {
@@ -505,6 +539,8 @@ func (nl *NodeList) GetNodeByID(id string) *Node {
 
 // GetMatchingNode looks up a node in the NodeList (nl) that matches the software described the provided.
 // Matching is performed based on hashes and, if necessary, by Package URL (PURL).
+// Purls are compared in their canonical form and the purl of the target node
+// can use wildcards (see [PackageURL.Matches]).
 // This function guarantees a single-node match. If more than one node matches, an ErrorMoreThanOneMatch is returned.
 //
 // If the target node has hashes, it first looks for nodes with matching hashes.
@@ -550,20 +586,19 @@ func (nl *NodeList) GetMatchingNode(node *Node) (*Node, error) {
 		}
 	case 0:
 		// No matches by hash, try to match by purl
-		// TODO(puerco): Purls should be normalized to match correctly,
-		// even more: ensuring correct globing of qualifiers.
 		if testPurl == "" {
 			return nil, nil
 		}
-		pindex := nl.indexNodesByPurl()
-		if _, ok := pindex[testPurl]; !ok {
+		found := nl.indexNodesByPurl().lookup(testPurl)
+		switch len(found) {
+		case 0:
 			return nil, nil
+		case 1:
+			return found[0], nil
+		default:
+			// If there is more than one matching, it's a tie. Error.
+			return nil, ErrorMoreThanOneMatch
 		}
-		// If there is more than one matching, it's a tie. Error.
-		if len(pindex[testPurl]) == 1 {
-			return pindex[testPurl][0], nil
-		}
-		return nil, ErrorMoreThanOneMatch
 	default:
 		// Multiple hash matches, look to see if there is a single one where
 		// the purl matches to break the ambiguity:
@@ -571,12 +606,11 @@ func (nl *NodeList) GetMatchingNode(node *Node) (*Node, error) {
 			return nil, ErrorMoreThanOneMatch
 		}
 
-		foundByPurl := []*Node{}
+		hashMatches := &NodeList{Nodes: make([]*Node, 0, len(foundNodes))}
 		for _, n := range foundNodes {
-			if tp := n.Purl(); tp != "" && tp == testPurl {
-				foundByPurl = append(foundByPurl, n)
-			}
+			hashMatches.Nodes = append(hashMatches.Nodes, n)
 		}
+		foundByPurl := hashMatches.indexNodesByPurl().lookup(testPurl)
 
 		if len(foundByPurl) == 1 {
 			return foundByPurl[0], nil
@@ -589,7 +623,9 @@ func (nl *NodeList) GetMatchingNode(node *Node) (*Node, error) {
 // GetNodesByIdentifier returns a list of nodes that match the provided identifier type (t) and value (v).
 // For example, the identifier type (t) can be "purl," and its value (v) can be "pkg:deb/debian/libpam-modules@1.4.0-9+deb11u1?arch=i386".
 // The function may return an empty list if no nodes match the given identifier.
-// Matching is based on simple string comparison.
+// Purls are matched in their canonical form and support wildcards (see
+// [PackageURL.Matches]), CPEs are matched using the value as a pattern (see
+// [CPE.Matches]) and other identifiers are compared as plain strings.
 func (nl *NodeList) GetNodesByIdentifier(t, v string) []*Node {
 	ret := []*Node{}
 	idType := SoftwareIdentifierTypeFromString(t)
@@ -598,13 +634,59 @@ func (nl *NodeList) GetNodesByIdentifier(t, v string) []*Node {
 			continue
 		}
 
-		if _, ok := nl.Nodes[i].Identifiers[int32(idType)]; ok && nl.Nodes[i].Identifiers[int32(idType)] == v {
+		id, ok := nl.Nodes[i].Identifiers[int32(idType)]
+		if !ok {
+			continue
+		}
+		switch {
+		case id == v,
+			idType == SoftwareIdentifierType_PURL && PackageURL(id).Matches(PackageURL(v), nil),
+			(idType == SoftwareIdentifierType_CPE22 || idType == SoftwareIdentifierType_CPE23) && cpeMatches(v, id):
 			ret = append(ret, nl.Nodes[i])
 		}
 	}
 	return ret
 }
 
+// GetNodesByPurl returns the nodes with a package URL matching the pattern.
+// The options relax the comparison, for example to match any version of a
+// package. See [PackageURL.Matches] for the wildcards supported in the pattern.
+func (nl *NodeList) GetNodesByPurl(pattern PackageURL, opts *PurlMatchOptions) []*Node {
+	ret := []*Node{}
+	for _, n := range nl.Nodes {
+		if p := n.Purl(); p != "" && p.Matches(pattern, opts) {
+			ret = append(ret, n)
+		}
+	}
+	return ret
+}
+
+// GetNodesByCPE returns the nodes with a CPE matching the pattern. The
+// pattern and the CPEs of the nodes can use either the 2.2 or 2.3 binding,
+// nodes with CPEs that cannot be parsed are skipped. See [CPE.Matches] for
+// how names are matched.
+func (nl *NodeList) GetNodesByCPE(pattern string) ([]*Node, error) {
+	p, err := ParseCPE(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("parsing CPE pattern: %w", err)
+	}
+
+	ret := []*Node{}
+	for _, n := range nl.Nodes {
+		for _, t := range []SoftwareIdentifierType{SoftwareIdentifierType_CPE23, SoftwareIdentifierType_CPE22} {
+			id, ok := n.Identifiers[int32(t)]
+			if !ok {
+				continue
+			}
+			if c, err := ParseCPE(id); err == nil && p.Matches(c) {
+				ret = append(ret, n)
+				break
+			}
+		}
+	}
+	return ret, nil
+}
+
 // GetRootNodes returns a list of the document root nodes.
 func (nl *NodeList) GetRootNodes() []*Node {
 	ret := []*Node{}
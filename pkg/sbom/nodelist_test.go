@@ -639,6 +639,16 @@ func TestGetNodesByIdentifier(t *testing.T) {
 			}}},
 			"cpe23", "cpe:2.3:a:nginx:nginx:1.21.1:*:*:*:*:*:*:*",
 		},
+		{
+			&NodeList{
+				Nodes: []*Node{
+					{Id: "left-pad", Identifiers: map[int32]string{int32(SoftwareIdentifierType_PURL): "pkg:NPM/Left-Pad@1.3.0"}},
+					{Id: "right-pad", Identifiers: map[int32]string{int32(SoftwareIdentifierType_PURL): "pkg:npm/right-pad@1.3.0"}},
+				},
+			},
+			[]*Node{{Id: "left-pad", Identifiers: map[int32]string{int32(SoftwareIdentifierType_PURL): "pkg:NPM/Left-Pad@1.3.0"}}},
+			"purl", "pkg:npm/left-pad@*",
+		},
 	} {
 		res := tc.sut.GetNodesByIdentifier(tc.idType, tc.idValue)
 		require.Equal(t, tc.expected, res)
@@ -878,6 +888,23 @@ func TestIndexByPurl(t *testing.T) {
 			expectedLength: 1,
 			mustEqual:      true,
 		},
+		"2 nodes, equivalent purls": {
+			sut: &NodeList{
+				Nodes: []*Node{
+					{
+						Id: "zstd-1", Name: "libzstd1",
+						Identifiers: map[int32]string{int32(SoftwareIdentifierType_PURL): "pkg:deb/debian/libzstd1@1.3.8?arch=amd64&upstream=libzstd"},
+					},
+					{
+						Id: "zstd-2", Name: "libzstd1",
+						Identifiers: map[int32]string{int32(SoftwareIdentifierType_PURL): "pkg:/DEB/Debian/libzstd1@1.3.8?upstream=libzstd&arch=amd64"},
+					},
+				},
+			},
+			expected:       purlIndex{},
+			expectedLength: 1,
+			mustEqual:      true,
+		},
 	} {
 		res := tc.sut.indexNodesByPurl()
 		require.Len(t, res, tc.expectedLength, label)
@@ -1013,33 +1040,41 @@ func TestGetMatchingNode(t *testing.T) {
 			},
 			exptectedId: "node2",
 		},
-		/* this one needs to be implemented
 		"rearranged purls should match": {
 			sut: &NodeList{
 				Nodes: []*Node{
 					{
-						Id: "node1",
-						Identifiers: []*Identifier{
-							{
-								Type:  "purl",
-								Value: "pkg:deb/libzstd1@1.3.8+dfsg-3+deb10u2?arch=amd64&upstream=libzstd",
-							},
-						},
+						Id:          "node1",
+						Identifiers: map[int32]string{int32(SoftwareIdentifierType_PURL): "pkg:deb/libzstd1@1.3.8+dfsg-3+deb10u2?arch=amd64&upstream=libzstd"},
 					},
 				},
 			},
 			node: &Node{
-				Hashes: map[string]string{"sha1": "0b13c24e584ef7075f3d4fd3a9f8872c9fffa1b1"},
-				Identifiers: []*Identifier{
-					{
-						Type:  "purl",
-						Value: "pkg:deb/libzstd1@1.3.8+dfsg-3+deb10u2?upstream=libzstd&arch=amd64",
-					},
+				Hashes:      map[int32]string{int32(HashAlgorithm_SHA1): "0b13c24e584ef7075f3d4fd3a9f8872c9fffa1b1"},
+				Identifiers: map[int32]string{int32(SoftwareIdentifierType_PURL): "pkg:deb/libzstd1@1.3.8+dfsg-3+deb10u2?upstream=libzstd&arch=amd64"},
+			},
+			exptectedId: "node1",
+		},
+		"purl with version wildcard": {
+			sut: &NodeList{
+				Nodes: []*Node{
+					{Id: "node1", Identifiers: map[int32]string{int32(SoftwareIdentifierType_PURL): "pkg:apk/wolfi/bash@4.0.1?arch=x86_64"}},
+					{Id: "node2", Identifiers: map[int32]string{int32(SoftwareIdentifierType_PURL): "pkg:apk/wolfi/nginx@1.21.1?arch=x86_64"}},
 				},
 			},
+			node:        &Node{Identifiers: map[int32]string{int32(SoftwareIdentifierType_PURL): "pkg:apk/wolfi/bash@*?arch=*"}},
 			exptectedId: "node1",
 		},
-		*/
+		"purl wildcard matching two nodes": {
+			sut: &NodeList{
+				Nodes: []*Node{
+					{Id: "node1", Identifiers: map[int32]string{int32(SoftwareIdentifierType_PURL): "pkg:apk/wolfi/bash@4.0.1"}},
+					{Id: "node2", Identifiers: map[int32]string{int32(SoftwareIdentifierType_PURL): "pkg:apk/wolfi/bash@5.2"}},
+				},
+			},
+			node:        &Node{Identifiers: map[int32]string{int32(SoftwareIdentifierType_PURL): "pkg:apk/wolfi/bash@*"}},
+			shouldError: true,
+		},
 	} {
 		res, err := tc.sut.GetMatchingNode(tc.node)
 		if tc.shouldError {
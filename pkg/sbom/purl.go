@@ -0,0 +1,280 @@
+package sbom
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// PurlWildcard matches any value when used as the version or as the value of
+// a qualifier in a package URL passed to PackageURL.Matches.
+const PurlWildcard = "*"
+
+// PackageURLParts are the components of a parsed package URL. All the values
+// are unescaped. See https://github.com/package-url/purl-spec for details.
+type PackageURLParts struct {
+	Type       string
+	Namespace  string
+	Name       string
+	Version    string
+	Qualifiers map[string]string
+	Subpath    string
+}
+
+// PurlMatchOptions relax the comparison of package URLs
+type PurlMatchOptions struct {
+	// AnyVersion matches package URLs regardless of their version
+	AnyVersion bool
+
+	// IgnoreQualifiers matches package URLs regardless of their qualifiers
+	IgnoreQualifiers bool
+
+	// IgnoreSubpath matches package URLs regardless of their subpath
+	IgnoreSubpath bool
+}
+
+// purlTypeRule captures the type specific normalization of package URLs
+type purlTypeRule struct {
+	lowerNamespace bool
+	lowerName      bool
+	dashName       bool
+}
+
+// purlTypeRules are the normalization rules of the types where the purl
+// spec defines the namespace or the name as case insensitive.
+var purlTypeRules = map[string]purlTypeRule{
+	"alpm":      {lowerNamespace: true, lowerName: true},
+	"apk":       {lowerNamespace: true, lowerName: true},
+	"bitbucket": {lowerNamespace: true, lowerName: true},
+	"composer":  {lowerNamespace: true, lowerName: true},
+	"deb":       {lowerNamespace: true, lowerName: true},
+	"github":    {lowerNamespace: true, lowerName: true},
+	"hex":       {lowerNamespace: true, lowerName: true},
+	"npm":       {lowerNamespace: true, lowerName: true},
+	"pypi":      {lowerName: true, dashName: true},
+	"rpm":       {lowerNamespace: true},
+}
+
+// Parse splits the package URL in its components and normalizes them
+// following the rules of the purl type.
+func (p PackageURL) Parse() (*PackageURLParts, error) {
+	s := strings.TrimSpace(string(p))
+	if s == "" {
+		return nil, errors.New("package URL is empty")
+	}
+
+	remainder, subpath, _ := strings.Cut(s, "#")
+	remainder, query, _ := strings.Cut(remainder, "?")
+
+	scheme, remainder, ok := strings.Cut(remainder, ":")
+	if !ok || !strings.EqualFold(scheme, "pkg") {
+		return nil, fmt.Errorf("package URL %q does not start with pkg:", s)
+	}
+
+	// Some libraries add slashes after the scheme when parsing
+	remainder = strings.Trim(remainder, "/")
+	purlType, remainder, ok := strings.Cut(remainder, "/")
+	if !ok || purlType == "" {
+		return nil, fmt.Errorf("package URL %q has no type", s)
+	}
+
+	parts := &PackageURLParts{
+		Type:       strings.ToLower(purlType),
+		Qualifiers: map[string]string{},
+	}
+
+	// The version separator is the last @ after the last slash, scoped npm
+	// packages can have it at the start of the namespace.
+	if i := strings.LastIndex(remainder, "@"); i > strings.LastIndex(remainder, "/") {
+		v, err := url.PathUnescape(remainder[i+1:])
+		if err != nil {
+			return nil, fmt.Errorf("unescaping version: %w", err)
+		}
+		parts.Version = v
+		remainder = remainder[:i]
+	}
+
+	segments := []string{}
+	for _, seg := range strings.Split(strings.Trim(remainder, "/"), "/") {
+		if seg == "" {
+			continue
+		}
+		unescaped, err := url.PathUnescape(seg)
+		if err != nil {
+			return nil, fmt.Errorf("unescaping package URL path: %w", err)
+		}
+		segments = append(segments, unescaped)
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("package URL %q has no name", s)
+	}
+	parts.Name = segments[len(segments)-1]
+	parts.Namespace = strings.Join(segments[:len(segments)-1], "/")
+
+	if query != "" {
+		for _, pair := range strings.Split(query, "&") {
+			k, v, _ := strings.Cut(pair, "=")
+			if k == "" || v == "" {
+				continue
+			}
+			uv, err := url.PathUnescape(v)
+			if err != nil {
+				return nil, fmt.Errorf("unescaping qualifier %s: %w", k, err)
+			}
+			parts.Qualifiers[strings.ToLower(k)] = uv
+		}
+	}
+
+	subpathSegments := []string{}
+	for _, seg := range strings.Split(subpath, "/") {
+		if seg == "" || seg == "." || seg == ".." {
+			continue
+		}
+		unescaped, err := url.PathUnescape(seg)
+		if err != nil {
+			return nil, fmt.Errorf("unescaping subpath: %w", err)
+		}
+		subpathSegments = append(subpathSegments, unescaped)
+	}
+	parts.Subpath = strings.Join(subpathSegments, "/")
+
+	if rule, ok := purlTypeRules[parts.Type]; ok {
+		if rule.lowerNamespace {
+			parts.Namespace = strings.ToLower(parts.Namespace)
+		}
+		if rule.lowerName {
+			parts.Name = strings.ToLower(parts.Name)
+		}
+		if rule.dashName {
+			parts.Name = strings.ReplaceAll(parts.Name, "_", "-")
+		}
+	}
+	return parts, nil
+}
+
+// String renders the package URL in its canonical form: qualifiers sorted
+// by key and the components percent-encoded.
+func (pp *PackageURLParts) String() PackageURL {
+	var b strings.Builder
+	b.WriteString("pkg:")
+	b.WriteString(pp.Type)
+	b.WriteString("/")
+	if pp.Namespace != "" {
+		for _, seg := range strings.Split(pp.Namespace, "/") {
+			b.WriteString(escapePurl(seg, false))
+			b.WriteString("/")
+		}
+	}
+	b.WriteString(escapePurl(pp.Name, false))
+	if pp.Version != "" {
+		b.WriteString("@")
+		b.WriteString(escapePurl(pp.Version, false))
+	}
+
+	keys := slices.Sorted(maps.Keys(pp.Qualifiers))
+	sep := "?"
+	for _, k := range keys {
+		if pp.Qualifiers[k] == "" {
+			continue
+		}
+		b.WriteString(sep + k + "=" + escapePurl(pp.Qualifiers[k], true))
+		sep = "&"
+	}
+
+	if pp.Subpath != "" {
+		segments := strings.Split(pp.Subpath, "/")
+		for i := range segments {
+			segments[i] = escapePurl(segments[i], false)
+		}
+		b.WriteString("#" + strings.Join(segments, "/"))
+	}
+	return PackageURL(b.String())
+}
+
+// escapePurl percent-encodes the characters not allowed in the components
+// of package URLs. Slashes are only kept in qualifier values.
+func escapePurl(s string, qualifier bool) string {
+	var b strings.Builder
+	for i := range len(s) {
+		c := s[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+			b.WriteByte(c)
+		case strings.IndexByte("-._~:+*", c) >= 0:
+			b.WriteByte(c)
+		case c == '/' && qualifier:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// hasWildcards returns true if the version or a qualifier value is a wildcard
+func (pp *PackageURLParts) hasWildcards() bool {
+	if pp.Version == PurlWildcard {
+		return true
+	}
+	for _, v := range pp.Qualifiers {
+		if v == PurlWildcard {
+			return true
+		}
+	}
+	return false
+}
+
+// Normalize returns the canonical form of the package URL. If the purl cannot
+// be parsed, it is returned unchanged.
+func (p PackageURL) Normalize() PackageURL {
+	parts, err := p.Parse()
+	if err != nil {
+		return p
+	}
+	return parts.String()
+}
+
+// Matches compares the package URL with a pattern. Both are normalized
+// before comparing them. The pattern can use "*" as its version to match
+// any version, or as the value of a qualifier to match any value as long as
+// the qualifier is present. Purls that cannot be parsed only match when
+// they are identical.
+func (p PackageURL) Matches(pattern PackageURL, opts *PurlMatchOptions) bool {
+	if opts == nil {
+		opts = &PurlMatchOptions{}
+	}
+	target, err := p.Parse()
+	if err != nil {
+		return p != "" && p == pattern
+	}
+	pp, err := pattern.Parse()
+	if err != nil {
+		return false
+	}
+
+	if target.Type != pp.Type || target.Namespace != pp.Namespace || target.Name != pp.Name {
+		return false
+	}
+	if !opts.AnyVersion && pp.Version != PurlWildcard && target.Version != pp.Version {
+		return false
+	}
+	if !opts.IgnoreSubpath && target.Subpath != pp.Subpath {
+		return false
+	}
+	if opts.IgnoreQualifiers {
+		return true
+	}
+	if len(target.Qualifiers) != len(pp.Qualifiers) {
+		return false
+	}
+	for k, v := range pp.Qualifiers {
+		tv, ok := target.Qualifiers[k]
+		if !ok || (v != PurlWildcard && tv != v) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,119 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurlParse(t *testing.T) {
+	t.Parallel()
+	parts, err := PackageURL("pkg:npm/%40angular/core@16.2.0?b=2&A=1#dist//./lib").Parse()
+	require.NoError(t, err)
+	require.Equal(t, &PackageURLParts{
+		Type:       "npm",
+		Namespace:  "@angular",
+		Name:       "core",
+		Version:    "16.2.0",
+		Qualifiers: map[string]string{"a": "1", "b": "2"},
+		Subpath:    "dist/lib",
+	}, parts)
+
+	// Unescaped @ in the npm scope
+	parts, err = PackageURL("pkg:npm/@angular/core").Parse()
+	require.NoError(t, err)
+	require.Equal(t, "@angular", parts.Namespace)
+	require.Empty(t, parts.Version)
+
+	for _, p := range []PackageURL{"", "pkg:", "pkg:npm", "pkg:npm/", "https://example.com/", "pkg:npm/a%zz"} {
+		_, err := p.Parse()
+		require.Error(t, err, string(p))
+	}
+}
+
+func TestPurlNormalize(t *testing.T) {
+	t.Parallel()
+	for p, expected := range map[PackageURL]PackageURL{
+		"pkg:golang/github.com/protobom/protobom@v0.5.0":              "pkg:golang/github.com/protobom/protobom@v0.5.0",
+		"pkg:/deb/debian/bash@5.1?distro=bookworm&arch=amd64":         "pkg:deb/debian/bash@5.1?arch=amd64&distro=bookworm",
+		"pkg://DEB/Debian/Bash@5.1":                                   "pkg:deb/debian/bash@5.1",
+		"pkg:npm/@Angular/Core@16.2.0":                                "pkg:npm/%40angular/core@16.2.0",
+		"pkg:pypi/Django_Rest_Framework@3.14.0":                       "pkg:pypi/django-rest-framework@3.14.0",
+		"pkg:rpm/Fedora/Curl@7.50.3-1.fc25?Arch=i386&epoch=":          "pkg:rpm/fedora/Curl@7.50.3-1.fc25?arch=i386",
+		"pkg:maven/org.apache/Commons-Lang3@3.12.0?type=jar":          "pkg:maven/org.apache/Commons-Lang3@3.12.0?type=jar",
+		"pkg:docker/library/nginx@sha256%3Aabc?repository_url=a.io/b": "pkg:docker/library/nginx@sha256:abc?repository_url=a.io/b",
+		"pkg:github/Protobom/Protobom#/cmd/../protobom":               "pkg:github/protobom/protobom#cmd/protobom",
+		"pkg:generic/name%20with%20spaces@1.0":                        "pkg:generic/name%20with%20spaces@1.0",
+		"not a purl":                                                  "not a purl",
+	} {
+		require.Equal(t, expected, p.Normalize(), string(p))
+	}
+}
+
+func TestNodeNormalizedPurl(t *testing.T) {
+	t.Parallel()
+	n := &Node{Identifiers: map[int32]string{int32(SoftwareIdentifierType_PURL): "pkg:pypi/Acquisition@4.13"}}
+	require.Equal(t, PackageURL("pkg:pypi/Acquisition@4.13"), n.Purl())
+	require.Equal(t, PackageURL("pkg:pypi/acquisition@4.13"), n.NormalizedPurl())
+
+	n.Type = Node_FILE
+	require.Empty(t, n.NormalizedPurl())
+}
+
+func TestPurlMatches(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		purl    PackageURL
+		pattern PackageURL
+		opts    *PurlMatchOptions
+		matches bool
+	}{
+		{"pkg:apk/wolfi/bash@4.0.1", "pkg:/apk/Wolfi/bash@4.0.1", nil, true},
+		{"pkg:apk/wolfi/bash@4.0.1", "pkg:apk/wolfi/bash@4.0.2", nil, false},
+		{"pkg:apk/wolfi/bash@4.0.1", "pkg:apk/wolfi/bash@*", nil, true},
+		{"pkg:apk/wolfi/bash", "pkg:apk/wolfi/bash@*", nil, true},
+		{"pkg:apk/wolfi/bash@4.0.1", "pkg:apk/wolfi/bash", &PurlMatchOptions{AnyVersion: true}, true},
+		{"pkg:apk/wolfi/bash@4.0.1", "pkg:apk/wolfi/zsh", &PurlMatchOptions{AnyVersion: true}, false},
+		{"pkg:apk/wolfi/bash@4.0.1?arch=x86_64&distro=wolfi", "pkg:apk/wolfi/bash@4.0.1?distro=wolfi&arch=x86_64", nil, true},
+		{"pkg:apk/wolfi/bash@4.0.1?arch=x86_64", "pkg:apk/wolfi/bash@4.0.1", nil, false},
+		{"pkg:apk/wolfi/bash@4.0.1?arch=x86_64", "pkg:apk/wolfi/bash@4.0.1", &PurlMatchOptions{IgnoreQualifiers: true}, true},
+		{"pkg:apk/wolfi/bash@4.0.1?arch=x86_64", "pkg:apk/wolfi/bash@4.0.1?arch=*", nil, true},
+		{"pkg:apk/wolfi/bash@4.0.1", "pkg:apk/wolfi/bash@4.0.1?arch=*", nil, false},
+		{"pkg:apk/wolfi/bash@4.0.1?arch=x86_64", "pkg:apk/wolfi/bash@4.0.1?arch=aarch64", nil, false},
+		{"pkg:github/protobom/protobom@v1#cmd", "pkg:github/protobom/protobom@v1", nil, false},
+		{"pkg:github/protobom/protobom@v1#cmd", "pkg:github/protobom/protobom@v1", &PurlMatchOptions{IgnoreSubpath: true}, true},
+		{"not a purl", "not a purl", nil, true},
+		{"", "", nil, false},
+		{"pkg:apk/wolfi/bash@4.0.1", "not a purl", nil, false},
+	} {
+		require.Equal(t, tc.matches, tc.purl.Matches(tc.pattern, tc.opts), "%s ~ %s", tc.purl, tc.pattern)
+	}
+}
+
+func TestGetNodesByPurl(t *testing.T) {
+	t.Parallel()
+	purl := func(p string) map[int32]string {
+		return map[int32]string{int32(SoftwareIdentifierType_PURL): p}
+	}
+	nl := &NodeList{
+		Nodes: []*Node{
+			{Id: "bash-4", Identifiers: purl("pkg:apk/wolfi/bash@4.0.1?arch=x86_64")},
+			{Id: "bash-5", Identifiers: purl("pkg:apk/wolfi/bash@5.2?arch=aarch64")},
+			{Id: "nginx", Identifiers: purl("pkg:apk/wolfi/nginx@1.21.1")},
+			{Id: "file", Type: Node_FILE, Identifiers: purl("pkg:apk/wolfi/bash@4.0.1")},
+		},
+	}
+
+	ids := func(nodes []*Node) []string {
+		ret := []string{}
+		for _, n := range nodes {
+			ret = append(ret, n.Id)
+		}
+		return ret
+	}
+
+	require.Equal(t, []string{"bash-4"}, ids(nl.GetNodesByPurl("pkg:apk/wolfi/bash@4.0.1?arch=x86_64", nil)))
+	require.Equal(t, []string{"bash-4", "bash-5"}, ids(nl.GetNodesByPurl("pkg:apk/wolfi/bash", &PurlMatchOptions{AnyVersion: true, IgnoreQualifiers: true})))
+	require.Equal(t, []string{"bash-5"}, ids(nl.GetNodesByPurl("pkg:apk/wolfi/bash@*?arch=aarch64", nil)))
+	require.Empty(t, nl.GetNodesByPurl("pkg:apk/wolfi/zsh@*", nil))
+}
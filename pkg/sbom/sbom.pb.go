@@ -1288,7 +1288,9 @@ type Metadata struct {
 	// Types categorizing the document based on its purpose or stage in the software development lifecycle.
 	DocumentTypes []*DocumentType `protobuf:"bytes,8,rep,name=documentTypes,proto3" json:"documentTypes,omitempty"`
 	// Field for preserving original format information and additional metadata
-	SourceData    *SourceData `protobuf:"bytes,9,opt,name=source_data,json=sourceData,proto3" json:"source_data,omitempty"`
+	SourceData *SourceData `protobuf:"bytes,9,opt,name=source_data,json=sourceData,proto3" json:"source_data,omitempty"`
+	// Artifacts described by the document, such as the subjects of the in-toto statement the document was read from.
+	Subjects      []*Subject `protobuf:"bytes,10,rep,name=subjects,proto3" json:"subjects,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1386,6 +1388,13 @@ func (x *Metadata) GetSourceData() *SourceData {
 	return nil
 }
 
+func (x *Metadata) GetSubjects() []*Subject {
+	if x != nil {
+		return x.Subjects
+	}
+	return nil
+}
+
 // Node represents a central element within the Software Bill of Materials (SBOM) graph,
 // serving as a vertex that captures vital information about a software component.
 // Each Node in the SBOM graph signifies a distinct software component, forming the vertices of the graph.
@@ -1895,7 +1904,19 @@ type SourceData struct {
 	// The original size of the SBOM document in bytes.
 	Size int64 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
 	// The original URI of the SBOM document.
-	Uri           *string `protobuf:"bytes,4,opt,name=uri,proto3,oneof" json:"uri,omitempty"`
+	Uri *string `protobuf:"bytes,4,opt,name=uri,proto3,oneof" json:"uri,omitempty"`
+	// The compression of the original SBOM document (e.g., gzip or zstd). Empty if the document was not compressed.
+	Compression string `protobuf:"bytes,5,opt,name=compression,proto3" json:"compression,omitempty"`
+	// The hashes of the compressed SBOM document. When the document is compressed, hashes and size refer to the uncompressed data.
+	CompressedHashes map[int32]string `protobuf:"bytes,6,rep,name=compressed_hashes,json=compressedHashes,proto3" json:"compressed_hashes,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The size of the compressed SBOM document in bytes.
+	CompressedSize int64 `protobuf:"varint,7,opt,name=compressed_size,json=compressedSize,proto3" json:"compressed_size,omitempty"`
+	// The predicate type of the in-toto statement that wrapped the SBOM document. Empty if the document was not an attestation.
+	PredicateType string `protobuf:"bytes,8,opt,name=predicate_type,json=predicateType,proto3" json:"predicate_type,omitempty"`
+	// The identity of the signer of the DSSE envelope the SBOM document was read from, set only when its signature was verified.
+	SignerIdentity string `protobuf:"bytes,9,opt,name=signer_identity,json=signerIdentity,proto3" json:"signer_identity,omitempty"`
+	// The ID of the key that verified the signature of the DSSE envelope the SBOM document was read from.
+	SignerKeyId   string `protobuf:"bytes,10,opt,name=signer_key_id,json=signerKeyId,proto3" json:"signer_key_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1958,6 +1979,103 @@ func (x *SourceData) GetUri() string {
 	return ""
 }
 
+func (x *SourceData) GetCompression() string {
+	if x != nil {
+		return x.Compression
+	}
+	return ""
+}
+
+func (x *SourceData) GetCompressedHashes() map[int32]string {
+	if x != nil {
+		return x.CompressedHashes
+	}
+	return nil
+}
+
+func (x *SourceData) GetCompressedSize() int64 {
+	if x != nil {
+		return x.CompressedSize
+	}
+	return 0
+}
+
+func (x *SourceData) GetPredicateType() string {
+	if x != nil {
+		return x.PredicateType
+	}
+	return ""
+}
+
+func (x *SourceData) GetSignerIdentity() string {
+	if x != nil {
+		return x.SignerIdentity
+	}
+	return ""
+}
+
+func (x *SourceData) GetSignerKeyId() string {
+	if x != nil {
+		return x.SignerKeyId
+	}
+	return ""
+}
+
+// Subject identifies a software artifact described by the SBOM document, identified by its digests.
+type Subject struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the artifact.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Hashes of the artifact, keyed by HashAlgorithm.
+	Hashes        map[int32]string `protobuf:"bytes,2,rep,name=hashes,proto3" json:"hashes,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Subject) Reset() {
+	*x = Subject{}
+	mi := &file_sbom_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Subject) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Subject) ProtoMessage() {}
+
+func (x *Subject) ProtoReflect() protoreflect.Message {
+	mi := &file_sbom_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Subject.ProtoReflect.Descriptor instead.
+func (*Subject) Descriptor() ([]byte, []int) {
+	return file_sbom_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Subject) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Subject) GetHashes() map[int32]string {
+	if x != nil {
+		return x.Hashes
+	}
+	return nil
+}
+
 // Tool represents a software tool used in the creation or processing of the Software Bill of Materials (SBOM) document.
 type Tool struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1973,7 +2091,7 @@ type Tool struct {
 
 func (x *Tool) Reset() {
 	*x = Tool{}
-	mi := &file_sbom_proto_msgTypes[10]
+	mi := &file_sbom_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1985,7 +2103,7 @@ func (x *Tool) String() string {
 func (*Tool) ProtoMessage() {}
 
 func (x *Tool) ProtoReflect() protoreflect.Message {
-	mi := &file_sbom_proto_msgTypes[10]
+	mi := &file_sbom_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1998,7 +2116,7 @@ func (x *Tool) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Tool.ProtoReflect.Descriptor instead.
 func (*Tool) Descriptor() ([]byte, []int) {
-	return file_sbom_proto_rawDescGZIP(), []int{10}
+	return file_sbom_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *Tool) GetName() string {
@@ -2180,7 +2298,7 @@ const file_sbom_proto_rawDesc = "" +
 	"\x17VULNERABILITY_ASSERTION\x109\x12#\n" +
 	"\x1fVULNERABILITY_DISCLOSURE_REPORT\x10:\x12+\n" +
 	"'VULNERABILITY_EXPLOITABILITY_ASSESSMENT\x10;\x12\v\n" +
-	"\aWEBSITE\x10<J\x04\b\x02\x10\x03J\x04\b\x05\x10\x06\"\xfd\x02\n" +
+	"\aWEBSITE\x10<J\x04\b\x02\x10\x03J\x04\b\x05\x10\x06\"\xb5\x03\n" +
 	"\bMetadata\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
 	"\aversion\x18\x02 \x01(\tR\aversion\x12\x12\n" +
@@ -2191,7 +2309,9 @@ const file_sbom_proto_rawDesc = "" +
 	"\acomment\x18\a \x01(\tR\acomment\x12E\n" +
 	"\rdocumentTypes\x18\b \x03(\v2\x1f.protobom.protobom.DocumentTypeR\rdocumentTypes\x12>\n" +
 	"\vsource_data\x18\t \x01(\v2\x1d.protobom.protobom.SourceDataR\n" +
-	"sourceData\"\xe7\n" +
+	"sourceData\x126\n" +
+	"\bsubjects\x18\n" +
+	" \x03(\v2\x1a.protobom.protobom.SubjectR\bsubjects\"\xe7\n" +
 	"\n" +
 	"\x04Node\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x124\n" +
@@ -2249,17 +2369,33 @@ const file_sbom_proto_rawDesc = "" +
 	"\bcontacts\x18\x06 \x03(\v2\x19.protobom.protobom.PersonR\bcontacts\"2\n" +
 	"\bProperty\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
-	"\x04data\x18\x02 \x01(\tR\x04data\"\xd5\x01\n" +
+	"\x04data\x18\x02 \x01(\tR\x04data\"\xbb\x04\n" +
 	"\n" +
 	"SourceData\x12\x16\n" +
 	"\x06format\x18\x01 \x01(\tR\x06format\x12A\n" +
 	"\x06hashes\x18\x02 \x03(\v2).protobom.protobom.SourceData.HashesEntryR\x06hashes\x12\x12\n" +
 	"\x04size\x18\x03 \x01(\x03R\x04size\x12\x15\n" +
-	"\x03uri\x18\x04 \x01(\tH\x00R\x03uri\x88\x01\x01\x1a9\n" +
+	"\x03uri\x18\x04 \x01(\tH\x00R\x03uri\x88\x01\x01\x12 \n" +
+	"\vcompression\x18\x05 \x01(\tR\vcompression\x12`\n" +
+	"\x11compressed_hashes\x18\x06 \x03(\v23.protobom.protobom.SourceData.CompressedHashesEntryR\x10compressedHashes\x12'\n" +
+	"\x0fcompressed_size\x18\a \x01(\x03R\x0ecompressedSize\x12%\n" +
+	"\x0epredicate_type\x18\b \x01(\tR\rpredicateType\x12'\n" +
+	"\x0fsigner_identity\x18\t \x01(\tR\x0esignerIdentity\x12\"\n" +
+	"\rsigner_key_id\x18\n" +
+	" \x01(\tR\vsignerKeyId\x1a9\n" +
 	"\vHashesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\x05R\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aC\n" +
+	"\x15CompressedHashesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x05R\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x06\n" +
-	"\x04_uri\"L\n" +
+	"\x04_uri\"\x98\x01\n" +
+	"\aSubject\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12>\n" +
+	"\x06hashes\x18\x02 \x03(\v2&.protobom.protobom.Subject.HashesEntryR\x06hashes\x1a9\n" +
+	"\vHashesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x05R\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"L\n" +
 	"\x04Tool\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
 	"\aversion\x18\x02 \x01(\tR\aversion\x12\x16\n" +
@@ -2346,7 +2482,7 @@ func file_sbom_proto_rawDescGZIP() []byte {
 }
 
 var file_sbom_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
-var file_sbom_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_sbom_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_sbom_proto_goTypes = []any{
 	(HashAlgorithm)(0),                           // 0: protobom.protobom.HashAlgorithm
 	(Purpose)(0),                                 // 1: protobom.protobom.Purpose
@@ -2365,45 +2501,51 @@ var file_sbom_proto_goTypes = []any{
 	(*Person)(nil),                               // 14: protobom.protobom.Person
 	(*Property)(nil),                             // 15: protobom.protobom.Property
 	(*SourceData)(nil),                           // 16: protobom.protobom.SourceData
-	(*Tool)(nil),                                 // 17: protobom.protobom.Tool
-	nil,                                          // 18: protobom.protobom.ExternalReference.HashesEntry
-	nil,                                          // 19: protobom.protobom.Node.IdentifiersEntry
-	nil,                                          // 20: protobom.protobom.Node.HashesEntry
-	nil,                                          // 21: protobom.protobom.SourceData.HashesEntry
-	(*timestamppb.Timestamp)(nil),                // 22: google.protobuf.Timestamp
+	(*Subject)(nil),                              // 17: protobom.protobom.Subject
+	(*Tool)(nil),                                 // 18: protobom.protobom.Tool
+	nil,                                          // 19: protobom.protobom.ExternalReference.HashesEntry
+	nil,                                          // 20: protobom.protobom.Node.IdentifiersEntry
+	nil,                                          // 21: protobom.protobom.Node.HashesEntry
+	nil,                                          // 22: protobom.protobom.SourceData.HashesEntry
+	nil,                                          // 23: protobom.protobom.SourceData.CompressedHashesEntry
+	nil,                                          // 24: protobom.protobom.Subject.HashesEntry
+	(*timestamppb.Timestamp)(nil),                // 25: google.protobuf.Timestamp
 }
 var file_sbom_proto_depIdxs = []int32{
 	11, // 0: protobom.protobom.Document.metadata:type_name -> protobom.protobom.Metadata
 	13, // 1: protobom.protobom.Document.node_list:type_name -> protobom.protobom.NodeList
 	3,  // 2: protobom.protobom.DocumentType.type:type_name -> protobom.protobom.DocumentType.SBOMType
 	4,  // 3: protobom.protobom.Edge.type:type_name -> protobom.protobom.Edge.Type
-	18, // 4: protobom.protobom.ExternalReference.hashes:type_name -> protobom.protobom.ExternalReference.HashesEntry
+	19, // 4: protobom.protobom.ExternalReference.hashes:type_name -> protobom.protobom.ExternalReference.HashesEntry
 	5,  // 5: protobom.protobom.ExternalReference.type:type_name -> protobom.protobom.ExternalReference.ExternalReferenceType
-	22, // 6: protobom.protobom.Metadata.date:type_name -> google.protobuf.Timestamp
-	17, // 7: protobom.protobom.Metadata.tools:type_name -> protobom.protobom.Tool
+	25, // 6: protobom.protobom.Metadata.date:type_name -> google.protobuf.Timestamp
+	18, // 7: protobom.protobom.Metadata.tools:type_name -> protobom.protobom.Tool
 	14, // 8: protobom.protobom.Metadata.authors:type_name -> protobom.protobom.Person
 	8,  // 9: protobom.protobom.Metadata.documentTypes:type_name -> protobom.protobom.DocumentType
 	16, // 10: protobom.protobom.Metadata.source_data:type_name -> protobom.protobom.SourceData
-	6,  // 11: protobom.protobom.Node.type:type_name -> protobom.protobom.Node.NodeType
-	14, // 12: protobom.protobom.Node.suppliers:type_name -> protobom.protobom.Person
-	14, // 13: protobom.protobom.Node.originators:type_name -> protobom.protobom.Person
-	22, // 14: protobom.protobom.Node.release_date:type_name -> google.protobuf.Timestamp
-	22, // 15: protobom.protobom.Node.build_date:type_name -> google.protobuf.Timestamp
-	22, // 16: protobom.protobom.Node.valid_until_date:type_name -> google.protobuf.Timestamp
-	10, // 17: protobom.protobom.Node.external_references:type_name -> protobom.protobom.ExternalReference
-	19, // 18: protobom.protobom.Node.identifiers:type_name -> protobom.protobom.Node.IdentifiersEntry
-	20, // 19: protobom.protobom.Node.hashes:type_name -> protobom.protobom.Node.HashesEntry
-	1,  // 20: protobom.protobom.Node.primary_purpose:type_name -> protobom.protobom.Purpose
-	15, // 21: protobom.protobom.Node.properties:type_name -> protobom.protobom.Property
-	12, // 22: protobom.protobom.NodeList.nodes:type_name -> protobom.protobom.Node
-	9,  // 23: protobom.protobom.NodeList.edges:type_name -> protobom.protobom.Edge
-	14, // 24: protobom.protobom.Person.contacts:type_name -> protobom.protobom.Person
-	21, // 25: protobom.protobom.SourceData.hashes:type_name -> protobom.protobom.SourceData.HashesEntry
-	26, // [26:26] is the sub-list for method output_type
-	26, // [26:26] is the sub-list for method input_type
-	26, // [26:26] is the sub-list for extension type_name
-	26, // [26:26] is the sub-list for extension extendee
-	0,  // [0:26] is the sub-list for field type_name
+	17, // 11: protobom.protobom.Metadata.subjects:type_name -> protobom.protobom.Subject
+	6,  // 12: protobom.protobom.Node.type:type_name -> protobom.protobom.Node.NodeType
+	14, // 13: protobom.protobom.Node.suppliers:type_name -> protobom.protobom.Person
+	14, // 14: protobom.protobom.Node.originators:type_name -> protobom.protobom.Person
+	25, // 15: protobom.protobom.Node.release_date:type_name -> google.protobuf.Timestamp
+	25, // 16: protobom.protobom.Node.build_date:type_name -> google.protobuf.Timestamp
+	25, // 17: protobom.protobom.Node.valid_until_date:type_name -> google.protobuf.Timestamp
+	10, // 18: protobom.protobom.Node.external_references:type_name -> protobom.protobom.ExternalReference
+	20, // 19: protobom.protobom.Node.identifiers:type_name -> protobom.protobom.Node.IdentifiersEntry
+	21, // 20: protobom.protobom.Node.hashes:type_name -> protobom.protobom.Node.HashesEntry
+	1,  // 21: protobom.protobom.Node.primary_purpose:type_name -> protobom.protobom.Purpose
+	15, // 22: protobom.protobom.Node.properties:type_name -> protobom.protobom.Property
+	12, // 23: protobom.protobom.NodeList.nodes:type_name -> protobom.protobom.Node
+	9,  // 24: protobom.protobom.NodeList.edges:type_name -> protobom.protobom.Edge
+	14, // 25: protobom.protobom.Person.contacts:type_name -> protobom.protobom.Person
+	22, // 26: protobom.protobom.SourceData.hashes:type_name -> protobom.protobom.SourceData.HashesEntry
+	23, // 27: protobom.protobom.SourceData.compressed_hashes:type_name -> protobom.protobom.SourceData.CompressedHashesEntry
+	24, // 28: protobom.protobom.Subject.hashes:type_name -> protobom.protobom.Subject.HashesEntry
+	29, // [29:29] is the sub-list for method output_type
+	29, // [29:29] is the sub-list for method input_type
+	29, // [29:29] is the sub-list for extension type_name
+	29, // [29:29] is the sub-list for extension extendee
+	0,  // [0:29] is the sub-list for field type_name
 }
 
 func init() { file_sbom_proto_init() }
@@ -2419,7 +2561,7 @@ func file_sbom_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_sbom_proto_rawDesc), len(file_sbom_proto_rawDesc)),
 			NumEnums:      7,
-			NumMessages:   15,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
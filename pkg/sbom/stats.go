@@ -0,0 +1,188 @@
+package sbom
+
+import "slices"
+
+// NodeListStats captures metrics about the structure of a NodeList graph.
+// The stats are computed by NodeList.Stats.
+type NodeListStats struct {
+	// Nodes is the total number of nodes in the NodeList
+	Nodes int
+
+	// Edges is the total number of relationships in the graph. An Edge
+	// pointing to several nodes counts once for each destination.
+	Edges int
+
+	// RootElements is the number of top level elements
+	RootElements int
+
+	// NodesByType counts the nodes of each type (package or file)
+	NodesByType map[Node_NodeType]int
+
+	// NodesByPurlType counts the nodes by the type of their package URL.
+	// Nodes without a purl are not counted.
+	NodesByPurlType map[string]int
+
+	// EdgesByType counts the relationships of each type
+	EdgesByType map[Edge_Type]int
+
+	// MaxDepth is the number of hops between the root elements and the node
+	// farthest away from them.
+	MaxDepth int
+
+	// AverageDepth is the mean distance from the root elements of all the
+	// nodes reachable from them (the roots included, at depth zero).
+	AverageDepth float64
+
+	// FanIn is the distribution of incoming relationships: it maps the
+	// number of incoming relationships to the number of nodes that have it.
+	FanIn map[int]int
+
+	// FanOut is the distribution of outgoing relationships: it maps the
+	// number of outgoing relationships to the number of nodes that have it.
+	FanOut map[int]int
+
+	// MaxFanIn is the highest number of incoming relationships of a node
+	MaxFanIn int
+
+	// MaxFanOut is the highest number of outgoing relationships of a node
+	MaxFanOut int
+
+	// Orphans lists the IDs of nodes which are not root elements and have
+	// no incoming relationships.
+	Orphans []string
+
+	// Unreachable lists the IDs of nodes that cannot be reached from any
+	// of the root elements. Orphans are always unreachable.
+	Unreachable []string
+
+	// Duplicates lists nodes that share the same name@version, keyed by it.
+	Duplicates map[string][]string
+}
+
+// purlType returns the type of a package URL or an empty string if it
+// cannot be determined.
+func purlType(p PackageURL) string {
+	parts, err := p.Parse()
+	if err != nil {
+		return ""
+	}
+	return parts.Type
+}
+
+// Stats computes metrics about the NodeList graph: node and relationship
+// counts, depth from the root elements, the fan-in and fan-out
+// distributions, orphaned nodes and duplicated name@version entries.
+func (nl *NodeList) Stats() *NodeListStats {
+	stats := &NodeListStats{
+		Nodes:           len(nl.Nodes),
+		RootElements:    len(nl.RootElements),
+		NodesByType:     map[Node_NodeType]int{},
+		NodesByPurlType: map[string]int{},
+		EdgesByType:     map[Edge_Type]int{},
+		FanIn:           map[int]int{},
+		FanOut:          map[int]int{},
+		Orphans:         []string{},
+		Unreachable:     []string{},
+		Duplicates:      map[string][]string{},
+	}
+
+	nodeIdx := nl.indexNodes()
+	byNameVersion := map[string][]string{}
+	for _, n := range nl.Nodes {
+		stats.NodesByType[n.Type]++
+		if t := purlType(n.Purl()); t != "" {
+			stats.NodesByPurlType[t]++
+		}
+		if n.Name != "" {
+			key := n.Name + "@" + n.Version
+			byNameVersion[key] = append(byNameVersion[key], n.Id)
+		}
+	}
+
+	for key, ids := range byNameVersion {
+		if len(ids) > 1 {
+			stats.Duplicates[key] = ids
+		}
+	}
+
+	// Count the relationships once, even if repeated in several edges
+	fanIn := map[string]int{}
+	fanOut := map[string]int{}
+	seen := map[string]struct{}{}
+	for _, e := range nl.Edges {
+		if _, ok := nodeIdx[e.From]; !ok {
+			continue
+		}
+		for _, to := range e.To {
+			if _, ok := nodeIdx[to]; !ok {
+				continue
+			}
+			key := e.From + "+++" + e.Type.String() + "+++" + to
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			stats.Edges++
+			stats.EdgesByType[e.Type]++
+			fanOut[e.From]++
+			fanIn[to]++
+		}
+	}
+
+	roots := nl.indexRootElements()
+	for id := range nodeIdx {
+		stats.FanIn[fanIn[id]]++
+		stats.FanOut[fanOut[id]]++
+		stats.MaxFanIn = max(stats.MaxFanIn, fanIn[id])
+		stats.MaxFanOut = max(stats.MaxFanOut, fanOut[id])
+
+		if _, ok := roots[id]; !ok && fanIn[id] == 0 {
+			stats.Orphans = append(stats.Orphans, id)
+		}
+	}
+	slices.Sort(stats.Orphans)
+
+	// Compute the depth of the nodes walking the graph from all the
+	// roots at the same time, each node gets its distance to the closest.
+	depth := map[string]int{}
+	queue := []string{}
+	for _, id := range nl.RootElements {
+		if _, ok := nodeIdx[id]; !ok {
+			continue
+		}
+		if _, ok := depth[id]; ok {
+			continue
+		}
+		depth[id] = 0
+		queue = append(queue, id)
+	}
+
+	adj := nl.indexAdjacency()
+	total := 0
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		total += depth[current]
+		stats.MaxDepth = max(stats.MaxDepth, depth[current])
+		for _, to := range adj[current] {
+			if _, ok := depth[to]; ok {
+				continue
+			}
+			depth[to] = depth[current] + 1
+			queue = append(queue, to)
+		}
+	}
+
+	if len(depth) > 0 {
+		stats.AverageDepth = float64(total) / float64(len(depth))
+	}
+
+	for id := range nodeIdx {
+		if _, ok := depth[id]; !ok {
+			stats.Unreachable = append(stats.Unreachable, id)
+		}
+	}
+	slices.Sort(stats.Unreachable)
+
+	return stats
+}
@@ -0,0 +1,81 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurlType(t *testing.T) {
+	t.Parallel()
+	for p, expected := range map[PackageURL]string{
+		"pkg:golang/github.com/protobom/protobom@v0.5.0": "golang",
+		"pkg:/deb/debian/bash@5.1":                       "deb",
+		"pkg:NPM/left-pad@1.0":                           "npm",
+		"pkg:nope":                                       "",
+		"":                                               "",
+		"https://example.com/":                           "",
+	} {
+		require.Equal(t, expected, purlType(p), string(p))
+	}
+}
+
+func TestStats(t *testing.T) {
+	t.Parallel()
+	purl := func(p string) map[int32]string {
+		return map[int32]string{int32(SoftwareIdentifierType_PURL): p}
+	}
+	//  root -> a -> b -> c
+	//  root -> file
+	//  orphan -> c
+	//  island (no edges)
+	sut := &NodeList{
+		Nodes: []*Node{
+			{Id: "root", Name: "app", Version: "1"},
+			{Id: "a", Name: "a", Version: "1", Identifiers: purl("pkg:npm/a@1")},
+			{Id: "b", Name: "b", Version: "1", Identifiers: purl("pkg:npm/b@1")},
+			{Id: "c", Name: "a", Version: "1", Identifiers: purl("pkg:golang/a@1")},
+			{Id: "file", Type: Node_FILE, Name: "file.txt"},
+			{Id: "orphan", Name: "orphan"},
+			{Id: "island"},
+		},
+		Edges: []*Edge{
+			{Type: Edge_dependsOn, From: "root", To: []string{"a"}},
+			{Type: Edge_dependsOn, From: "a", To: []string{"b", "missing"}},
+			{Type: Edge_dependsOn, From: "a", To: []string{"b"}},
+			{Type: Edge_runtimeDependency, From: "b", To: []string{"c"}},
+			{Type: Edge_contains, From: "root", To: []string{"file"}},
+			{Type: Edge_dependsOn, From: "orphan", To: []string{"c"}},
+		},
+		RootElements: []string{"root"},
+	}
+
+	stats := sut.Stats()
+	require.Equal(t, 7, stats.Nodes)
+	require.Equal(t, 5, stats.Edges)
+	require.Equal(t, 1, stats.RootElements)
+	require.Equal(t, map[Node_NodeType]int{Node_PACKAGE: 6, Node_FILE: 1}, stats.NodesByType)
+	require.Equal(t, map[string]int{"npm": 2, "golang": 1}, stats.NodesByPurlType)
+	require.Equal(t, map[Edge_Type]int{
+		Edge_dependsOn: 3, Edge_runtimeDependency: 1, Edge_contains: 1,
+	}, stats.EdgesByType)
+	require.Equal(t, 3, stats.MaxDepth)
+	// root:0 a:1 file:1 b:2 c:3
+	require.InDelta(t, 7.0/5.0, stats.AverageDepth, 0.0001)
+	require.Equal(t, map[int]int{0: 3, 1: 3, 2: 1}, stats.FanIn)
+	require.Equal(t, map[int]int{0: 3, 1: 3, 2: 1}, stats.FanOut)
+	require.Equal(t, 2, stats.MaxFanIn)
+	require.Equal(t, 2, stats.MaxFanOut)
+	require.Equal(t, []string{"island", "orphan"}, stats.Orphans)
+	require.Equal(t, []string{"island", "orphan"}, stats.Unreachable)
+	require.Equal(t, map[string][]string{"a@1": {"a", "c"}}, stats.Duplicates)
+}
+
+func TestStatsEmpty(t *testing.T) {
+	t.Parallel()
+	stats := NewNodeList().Stats()
+	require.Zero(t, stats.Nodes)
+	require.Zero(t, stats.MaxDepth)
+	require.Zero(t, stats.AverageDepth)
+	require.Empty(t, stats.Orphans)
+}
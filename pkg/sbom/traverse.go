@@ -0,0 +1,454 @@
+package sbom
+
+import (
+	"slices"
+)
+
+// This file implements the edge-type-aware traversal functions of the
+// NodeList. In contrast to NodeGraph, NodeSiblings and NodeDescendants
+// which follow every edge, these variants can be limited to a set of edge
+// types, walk the graph in reverse and stop at a maximum depth.
+
+// TraversalDirection indicates which way edges are followed when walking
+// the NodeList graph.
+type TraversalDirection int
+
+const (
+	// TraverseForward follows edges from their From node to their To nodes.
+	TraverseForward TraversalDirection = iota
+
+	// TraverseReverse follows edges backwards, from a destination node to
+	// the node where the edge originates.
+	TraverseReverse
+)
+
+// TraversalOptions controls how the graph is walked by the traversal
+// functions. The zero value follows all edge types forward, without a depth
+// limit, and treats root elements as boundaries, just like NodeGraph.
+type TraversalOptions struct {
+	// EdgeTypes is an allowlist of the edge types to follow. When empty,
+	// edges of all types are followed.
+	EdgeTypes []Edge_Type
+
+	// Direction determines if edges are followed forward or in reverse.
+	Direction TraversalDirection
+
+	// MaxDepth limits the number of hops away from the starting node. A
+	// value of zero (or less) means the graph is walked without limit.
+	MaxDepth int
+
+	// CrossRoots makes the traversal walk through the NodeList root
+	// elements. By default, root elements other than the starting node
+	// are considered boundaries and are not visited.
+	CrossRoots bool
+
+	// MaxPaths limits the number of paths returned by the functions that
+	// enumerate paths, as their number can grow exponentially with the
+	// size of the graph. When zero, DefaultMaxPaths applies. A negative
+	// value returns every path.
+	MaxPaths int
+}
+
+// DefaultMaxPaths is the number of paths returned by PathsFromRoots when the
+// options don't set MaxPaths.
+const DefaultMaxPaths = 1000
+
+// NodePath is a list of node identifiers describing a route through the
+// graph. The first element is the node where the traversal started, the
+// last one is the node reached.
+type NodePath []string
+
+// Target returns the identifier of the last node in the path.
+func (p NodePath) Target() string {
+	if len(p) == 0 {
+		return ""
+	}
+	return p[len(p)-1]
+}
+
+// followsType returns true if the options allow traversing edges of type t
+func (opts *TraversalOptions) followsType(t Edge_Type) bool {
+	if len(opts.EdgeTypes) == 0 {
+		return true
+	}
+	return slices.Contains(opts.EdgeTypes, t)
+}
+
+// traversal captures the result of walking the graph from a node
+type traversal struct {
+	// nodes lists the reached nodes in the order they were visited,
+	// starting with the node where the traversal began.
+	nodes []*Node
+
+	// edges holds the relationships followed during the walk
+	edges []*Edge
+
+	// parents records the node from where each node was first reached
+	parents map[string]string
+}
+
+// paths returns the path from the starting node to each of the reached
+// nodes, in the order they were visited.
+func (t *traversal) paths() []NodePath {
+	ret := make([]NodePath, 0, len(t.nodes))
+	for _, n := range t.nodes[1:] {
+		path := NodePath{n.Id}
+		for id := t.parents[n.Id]; id != ""; id = t.parents[id] {
+			path = append(path, id)
+		}
+		slices.Reverse(path)
+		ret = append(ret, path)
+	}
+	return ret
+}
+
+// traverse walks the NodeList graph breadth-first starting at the node
+// identified by id, following the edges allowed by opts. It returns nil if
+// the starting node is not found.
+//
+// Each reached node records the first (and thus shortest) path that leads
+// to it. The returned edges are those followed while expanding the visited
+// nodes, always in their original direction.
+func (nl *NodeList) traverse(id string, opts *TraversalOptions) *traversal {
+	if opts == nil {
+		opts = &TraversalOptions{}
+	}
+
+	nodeIdx := nl.indexNodes()
+	start := nodeIdx[id]
+	if start == nil {
+		return nil
+	}
+
+	var edgeIdx edgeIndex
+	if opts.Direction == TraverseReverse {
+		edgeIdx = nl.indexEdgesByDestination()
+	} else {
+		edgeIdx = nl.indexEdges()
+	}
+
+	boundaries := rootElementsIndex{}
+	if !opts.CrossRoots {
+		boundaries = nl.indexRootElements()
+	}
+
+	res := &traversal{
+		nodes:   []*Node{start},
+		edges:   []*Edge{},
+		parents: map[string]string{id: ""},
+	}
+	depth := map[string]int{id: 0}
+
+	// Followed edges are regrouped by origin and type as they are found
+	followed := map[string]*Edge{}
+	followedTos := map[string]map[string]struct{}{}
+	recordEdge := func(from, to string, t Edge_Type) {
+		key := from + "+++" + t.String()
+		if _, ok := followed[key]; !ok {
+			followed[key] = &Edge{Type: t, From: from, To: []string{}}
+			followedTos[key] = map[string]struct{}{}
+			res.edges = append(res.edges, followed[key])
+		}
+		if _, ok := followedTos[key][to]; ok {
+			return
+		}
+		followedTos[key][to] = struct{}{}
+		followed[key].To = append(followed[key].To, to)
+	}
+
+	queue := []string{id}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if opts.MaxDepth > 0 && depth[current] >= opts.MaxDepth {
+			continue
+		}
+
+		// Sort the edge types to make the walk deterministic
+		types := make([]Edge_Type, 0, len(edgeIdx[current]))
+		for t := range edgeIdx[current] {
+			if opts.followsType(t) {
+				types = append(types, t)
+			}
+		}
+		slices.Sort(types)
+
+		for _, t := range types {
+			for _, e := range edgeIdx[current][t] {
+				next := e.To
+				if opts.Direction == TraverseReverse {
+					next = []string{e.From}
+				}
+
+				for _, nextID := range next {
+					if _, ok := boundaries[nextID]; ok && nextID != id {
+						continue
+					}
+
+					if nodeIdx[nextID] == nil {
+						continue
+					}
+
+					if opts.Direction == TraverseReverse {
+						recordEdge(nextID, current, t)
+					} else {
+						recordEdge(current, nextID, t)
+					}
+
+					if _, ok := res.parents[nextID]; ok {
+						continue
+					}
+
+					res.parents[nextID] = current
+					depth[nextID] = depth[current] + 1
+					res.nodes = append(res.nodes, nodeIdx[nextID])
+					queue = append(queue, nextID)
+				}
+			}
+		}
+	}
+
+	return res
+}
+
+// NodeGraphWithOptions returns a new NodeList with the graph fragment reachable
+// from the node identified by id, following only the edges allowed by the
+// traversal options. Edges in the returned NodeList preserve their original
+// direction, even when the graph is walked in reverse.
+//
+// Along with the NodeList, the function returns the path from the starting
+// node to each of the reached nodes. If the node is not found, an empty
+// NodeList is returned.
+func (nl *NodeList) NodeGraphWithOptions(id string, opts *TraversalOptions) (*NodeList, []NodePath) {
+	t := nl.traverse(id, opts)
+	if t == nil {
+		return NewNodeList(), nil
+	}
+
+	return &NodeList{
+		Nodes:        t.nodes,
+		Edges:        t.edges,
+		RootElements: []string{id},
+	}, t.paths()
+}
+
+// NodeSiblingsWithOptions is the traversal variant of NodeSiblings. It returns
+// a NodeList with the node identified by id at the root and the nodes directly
+// related to it through the edge types and direction in the options. The
+// MaxDepth option is ignored as siblings are always one hop away.
+func (nl *NodeList) NodeSiblingsWithOptions(id string, opts *TraversalOptions) (*NodeList, []NodePath) {
+	siblingOpts := TraversalOptions{}
+	if opts != nil {
+		siblingOpts = *opts
+	}
+	siblingOpts.MaxDepth = 1
+
+	return nl.NodeGraphWithOptions(id, &siblingOpts)
+}
+
+// NodeDescendantsWithOptions is the traversal variant of NodeDescendants. Like
+// its counterpart, the resulting NodeList flattens the graph: all the reached
+// nodes are related to the starting node by a single edge. When walking
+// forward, the edge is of type Edge_ancestor. When traversing in reverse the
+// reached nodes are ancestors of the starting node so they are related to it
+// with an Edge_descendant relationship.
+//
+// The structure of the original graph is preserved in the returned paths.
+func (nl *NodeList) NodeDescendantsWithOptions(id string, opts *TraversalOptions) (*NodeList, []NodePath) {
+	t := nl.traverse(id, opts)
+	if t == nil {
+		return NewNodeList(), nil
+	}
+
+	edgeType := Edge_ancestor
+	if opts != nil && opts.Direction == TraverseReverse {
+		edgeType = Edge_descendant
+	}
+
+	ret := &NodeList{
+		Nodes:        t.nodes,
+		Edges:        []*Edge{},
+		RootElements: []string{id},
+	}
+
+	if len(t.nodes) > 1 {
+		edge := &Edge{Type: edgeType, From: id, To: make([]string, 0, len(t.nodes)-1)}
+		for _, n := range t.nodes[1:] {
+			edge.To = append(edge.To, n.Id)
+		}
+		ret.Edges = append(ret.Edges, edge)
+	}
+
+	return ret, t.paths()
+}
+
+// Dependents returns all the nodes in the NodeList that depend directly or
+// transitively on the node identified by id, that is, every node from which
+// id can be reached following the graph edges of any type. Root elements are
+// included in the results. The node itself is not part of the returned list.
+func (nl *NodeList) Dependents(id string) []*Node {
+	return nl.DependentsWithOptions(id, &TraversalOptions{CrossRoots: true})
+}
+
+// DependentsWithOptions is the variant of Dependents that takes a set of
+// traversal options to restrict the edge types followed or the depth of the
+// search. The graph is always walked in reverse, the Direction option is
+// ignored.
+func (nl *NodeList) DependentsWithOptions(id string, opts *TraversalOptions) []*Node {
+	reverseOpts := TraversalOptions{}
+	if opts != nil {
+		reverseOpts = *opts
+	}
+	reverseOpts.Direction = TraverseReverse
+
+	t := nl.traverse(id, &reverseOpts)
+	if t == nil {
+		return []*Node{}
+	}
+	return t.nodes[1:]
+}
+
+// distancesTo returns the number of hops from each node that can reach
+// the target to it, walking the edges backwards from the target. Only the
+// edge types and depth allowed by the options are considered and, unless
+// CrossRoots is set, the search stops at root elements. It returns nil if
+// the target does not exist.
+func (nl *NodeList) distancesTo(id string, opts *TraversalOptions) map[string]int {
+	nodeIdx := nl.indexNodes()
+	if nodeIdx[id] == nil {
+		return nil
+	}
+
+	roots := nl.indexRootElements()
+	edgeIdx := nl.indexEdgesByDestination()
+
+	distances := map[string]int{id: 0}
+	queue := []string{id}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if _, ok := roots[current]; ok && !opts.CrossRoots && current != id {
+			continue
+		}
+		if opts.MaxDepth > 0 && distances[current] >= opts.MaxDepth {
+			continue
+		}
+
+		for t, edges := range edgeIdx[current] {
+			if !opts.followsType(t) {
+				continue
+			}
+			for _, e := range edges {
+				if _, ok := distances[e.From]; ok || nodeIdx[e.From] == nil {
+					continue
+				}
+				distances[e.From] = distances[current] + 1
+				queue = append(queue, e.From)
+			}
+		}
+	}
+	return distances
+}
+
+// PathsFromRoots returns every path that leads from the NodeList root
+// elements to the node identified by id following edges of any type. Each
+// path starts at a root element and ends at id. Paths never visit the same
+// node twice so cycles in the graph are not a problem.
+//
+// If the node is itself a root element, a path with just the node is
+// included in the results. At most DefaultMaxPaths paths are returned.
+func (nl *NodeList) PathsFromRoots(id string) []NodePath {
+	return nl.PathsFromRootsWithOptions(id, nil)
+}
+
+// PathsFromRootsWithOptions is the variant of PathsFromRoots that takes a set
+// of traversal options. EdgeTypes restricts the relationships followed and
+// MaxDepth limits the length (in hops) of the returned paths. Unless
+// CrossRoots is set, paths going through a root element other than the one
+// where they start are not returned. MaxPaths caps the number of paths
+// returned. The Direction option is ignored.
+func (nl *NodeList) PathsFromRootsWithOptions(id string, opts *TraversalOptions) []NodePath {
+	if opts == nil {
+		opts = &TraversalOptions{}
+	}
+	maxPaths := opts.MaxPaths
+	if maxPaths == 0 {
+		maxPaths = DefaultMaxPaths
+	}
+
+	// First, find the nodes that can reach the target and how far they are
+	// from it. The walk down from the roots only enters nodes that can
+	// still complete a path, so branches that do not lead to the target,
+	// or only do so past MaxDepth, are never explored.
+	distances := nl.distancesTo(id, opts)
+	if distances == nil {
+		return []NodePath{}
+	}
+
+	roots := nl.indexRootElements()
+	edgeIdx := nl.indexEdges()
+	ret := []NodePath{}
+
+	// walk extends the path depth-first until it reaches the target
+	var walk func(path NodePath, onPath map[string]struct{})
+	walk = func(path NodePath, onPath map[string]struct{}) {
+		if maxPaths > 0 && len(ret) >= maxPaths {
+			return
+		}
+		current := path[len(path)-1]
+		if current == id {
+			ret = append(ret, slices.Clone(path))
+			return
+		}
+
+		if opts.MaxDepth > 0 && len(path)-1 >= opts.MaxDepth {
+			return
+		}
+
+		types := make([]Edge_Type, 0, len(edgeIdx[current]))
+		for et := range edgeIdx[current] {
+			if opts.followsType(et) {
+				types = append(types, et)
+			}
+		}
+		slices.Sort(types)
+
+		// Several edges may point to the same node, only follow each once
+		seen := map[string]struct{}{}
+		for _, et := range types {
+			for _, e := range edgeIdx[current][et] {
+				for _, to := range e.To {
+					d, ok := distances[to]
+					if !ok || (opts.MaxDepth > 0 && len(path)+d > opts.MaxDepth) {
+						continue
+					}
+					if _, ok := onPath[to]; ok {
+						continue
+					}
+					if _, ok := seen[to]; ok {
+						continue
+					}
+					if _, ok := roots[to]; ok && !opts.CrossRoots && to != id {
+						continue
+					}
+					seen[to] = struct{}{}
+
+					onPath[to] = struct{}{}
+					walk(append(path, to), onPath)
+					delete(onPath, to)
+				}
+			}
+		}
+	}
+
+	for _, rootID := range nl.RootElements {
+		if _, ok := distances[rootID]; !ok {
+			continue
+		}
+		walk(NodePath{rootID}, map[string]struct{}{rootID: {}})
+	}
+
+	return ret
+}
@@ -0,0 +1,410 @@
+package sbom
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// traversalTestGraph returns a graph with mixed edge types:
+//
+//	root --dependsOn--> lib-a --runtimeDependency--> lib-b
+//	  |                   \
+//	  |                    --contains--> file-a
+//	  --devDependency--> tool --dependsOn--> lib-b
+func traversalTestGraph() *NodeList {
+	return &NodeList{
+		Nodes: []*Node{
+			{Id: "root"}, {Id: "lib-a"}, {Id: "lib-b"}, {Id: "file-a"}, {Id: "tool"},
+		},
+		Edges: []*Edge{
+			{Type: Edge_dependsOn, From: "root", To: []string{"lib-a"}},
+			{Type: Edge_devDependency, From: "root", To: []string{"tool"}},
+			{Type: Edge_runtimeDependency, From: "lib-a", To: []string{"lib-b"}},
+			{Type: Edge_contains, From: "lib-a", To: []string{"file-a"}},
+			{Type: Edge_dependsOn, From: "tool", To: []string{"lib-b"}},
+		},
+		RootElements: []string{"root"},
+	}
+}
+
+func TestNodeGraphWithOptions(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name          string
+		id            string
+		opts          *TraversalOptions
+		expectedNodes []string
+		expectedEdges int
+		expectedPaths map[string]NodePath
+	}{
+		{
+			name:          "nil options follow everything",
+			id:            "root",
+			opts:          nil,
+			expectedNodes: []string{"root", "lib-a", "lib-b", "file-a", "tool"},
+			expectedEdges: 5,
+			expectedPaths: map[string]NodePath{
+				"lib-a":  {"root", "lib-a"},
+				"tool":   {"root", "tool"},
+				"file-a": {"root", "lib-a", "file-a"},
+				"lib-b":  {"root", "lib-a", "lib-b"},
+			},
+		},
+		{
+			name: "dependency edges only",
+			id:   "root",
+			opts: &TraversalOptions{
+				EdgeTypes: []Edge_Type{Edge_dependsOn, Edge_runtimeDependency},
+			},
+			expectedNodes: []string{"root", "lib-a", "lib-b"},
+			expectedEdges: 2,
+			expectedPaths: map[string]NodePath{
+				"lib-a": {"root", "lib-a"},
+				"lib-b": {"root", "lib-a", "lib-b"},
+			},
+		},
+		{
+			name:          "depth limit",
+			id:            "root",
+			opts:          &TraversalOptions{MaxDepth: 1},
+			expectedNodes: []string{"root", "lib-a", "tool"},
+			expectedEdges: 2,
+			expectedPaths: map[string]NodePath{
+				"lib-a": {"root", "lib-a"},
+				"tool":  {"root", "tool"},
+			},
+		},
+		{
+			name:          "reverse",
+			id:            "lib-b",
+			opts:          &TraversalOptions{Direction: TraverseReverse},
+			expectedNodes: []string{"lib-b", "tool", "lib-a"},
+			expectedEdges: 2,
+			expectedPaths: map[string]NodePath{
+				"lib-a": {"lib-b", "lib-a"},
+				"tool":  {"lib-b", "tool"},
+			},
+		},
+		{
+			name:          "reverse crossing roots",
+			id:            "lib-b",
+			opts:          &TraversalOptions{Direction: TraverseReverse, CrossRoots: true},
+			expectedNodes: []string{"lib-b", "tool", "lib-a", "root"},
+			expectedEdges: 4,
+			expectedPaths: map[string]NodePath{
+				"lib-a": {"lib-b", "lib-a"},
+				"tool":  {"lib-b", "tool"},
+				"root":  {"lib-b", "tool", "root"},
+			},
+		},
+		{
+			name: "reverse filtered by type",
+			id:   "lib-b",
+			opts: &TraversalOptions{
+				Direction: TraverseReverse, CrossRoots: true,
+				EdgeTypes: []Edge_Type{Edge_runtimeDependency, Edge_dependsOn},
+			},
+			expectedNodes: []string{"lib-b", "tool", "lib-a", "root"},
+			expectedEdges: 3,
+			expectedPaths: map[string]NodePath{
+				"lib-a": {"lib-b", "lib-a"},
+				"tool":  {"lib-b", "tool"},
+				"root":  {"lib-b", "lib-a", "root"},
+			},
+		},
+		{
+			name:          "node not found",
+			id:            "nope",
+			expectedNodes: []string{},
+			expectedPaths: map[string]NodePath{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			nl, paths := traversalTestGraph().NodeGraphWithOptions(tc.id, tc.opts)
+			require.NotNil(t, nl)
+
+			ids := []string{}
+			for _, n := range nl.Nodes {
+				ids = append(ids, n.Id)
+			}
+			require.ElementsMatch(t, tc.expectedNodes, ids)
+			require.Len(t, nl.Edges, tc.expectedEdges)
+
+			require.Len(t, paths, len(tc.expectedPaths))
+			for _, p := range paths {
+				require.Equal(t, tc.expectedPaths[p.Target()], p)
+			}
+		})
+	}
+}
+
+func TestNodeGraphWithOptionsMatchesNodeGraph(t *testing.T) {
+	t.Parallel()
+	sut := traversalTestGraph()
+	for _, id := range []string{"root", "lib-a", "tool"} {
+		nl, _ := sut.NodeGraphWithOptions(id, nil)
+		require.True(t, sut.NodeGraph(id).Equal(nl), id)
+	}
+}
+
+func TestNodeSiblingsWithOptions(t *testing.T) {
+	t.Parallel()
+	nl, paths := traversalTestGraph().NodeSiblingsWithOptions("lib-a", &TraversalOptions{
+		EdgeTypes: []Edge_Type{Edge_contains},
+		MaxDepth:  10,
+	})
+	require.Len(t, nl.Nodes, 2)
+	require.Equal(t, []string{"lib-a"}, nl.RootElements)
+	require.Len(t, nl.Edges, 1)
+	require.Equal(t, []NodePath{{"lib-a", "file-a"}}, paths)
+}
+
+func TestNodeDescendantsWithOptions(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name        string
+		id          string
+		opts        *TraversalOptions
+		expectedLen int
+		edgeType    Edge_Type
+	}{
+		{"forward", "root", nil, 5, Edge_ancestor},
+		{"forward-types", "root", &TraversalOptions{EdgeTypes: []Edge_Type{Edge_devDependency}}, 2, Edge_ancestor},
+		{"reverse", "file-a", &TraversalOptions{Direction: TraverseReverse, CrossRoots: true}, 3, Edge_descendant},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			nl, paths := traversalTestGraph().NodeDescendantsWithOptions(tc.id, tc.opts)
+			require.Len(t, nl.Nodes, tc.expectedLen)
+			require.Len(t, paths, tc.expectedLen-1)
+			require.Len(t, nl.Edges, 1)
+			require.Equal(t, tc.edgeType, nl.Edges[0].Type)
+			require.Len(t, nl.Edges[0].To, tc.expectedLen-1)
+		})
+	}
+}
+
+func TestDependents(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		id       string
+		opts     *TraversalOptions
+		expected []string
+	}{
+		{"leaf", "lib-b", nil, []string{"lib-a", "tool", "root"}},
+		{"file", "file-a", nil, []string{"lib-a", "root"}},
+		{"root", "root", nil, []string{}},
+		{"not-found", "nope", nil, []string{}},
+		{
+			"runtime only", "lib-b",
+			&TraversalOptions{CrossRoots: true, EdgeTypes: []Edge_Type{Edge_runtimeDependency}},
+			[]string{"lib-a"},
+		},
+		{"depth", "lib-b", &TraversalOptions{CrossRoots: true, MaxDepth: 1}, []string{"lib-a", "tool"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var res []*Node
+			if tc.opts == nil {
+				res = traversalTestGraph().Dependents(tc.id)
+			} else {
+				res = traversalTestGraph().DependentsWithOptions(tc.id, tc.opts)
+			}
+			ids := []string{}
+			for _, n := range res {
+				ids = append(ids, n.Id)
+			}
+			require.ElementsMatch(t, tc.expected, ids)
+		})
+	}
+}
+
+func TestPathsFromRoots(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name     string
+		sut      *NodeList
+		id       string
+		opts     *TraversalOptions
+		expected []NodePath
+	}{
+		{
+			name: "two paths",
+			sut:  traversalTestGraph(),
+			id:   "lib-b",
+			expected: []NodePath{
+				{"root", "lib-a", "lib-b"},
+				{"root", "tool", "lib-b"},
+			},
+		},
+		{
+			name:     "filtered types",
+			sut:      traversalTestGraph(),
+			id:       "lib-b",
+			opts:     &TraversalOptions{EdgeTypes: []Edge_Type{Edge_dependsOn, Edge_runtimeDependency}},
+			expected: []NodePath{{"root", "lib-a", "lib-b"}},
+		},
+		{
+			name:     "node is root",
+			sut:      traversalTestGraph(),
+			id:       "root",
+			expected: []NodePath{{"root"}},
+		},
+		{
+			name:     "not found",
+			sut:      traversalTestGraph(),
+			id:       "nope",
+			expected: []NodePath{},
+		},
+		{
+			name: "cycle",
+			sut: &NodeList{
+				Nodes: []*Node{{Id: "root"}, {Id: "a"}, {Id: "b"}},
+				Edges: []*Edge{
+					{Type: Edge_dependsOn, From: "root", To: []string{"a"}},
+					{Type: Edge_dependsOn, From: "a", To: []string{"b"}},
+					{Type: Edge_dependsOn, From: "b", To: []string{"a"}},
+				},
+				RootElements: []string{"root"},
+			},
+			id:       "b",
+			expected: []NodePath{{"root", "a", "b"}},
+		},
+		{
+			name: "other roots are boundaries",
+			sut: &NodeList{
+				Nodes: []*Node{{Id: "root1"}, {Id: "root2"}, {Id: "a"}},
+				Edges: []*Edge{
+					{Type: Edge_dependsOn, From: "root1", To: []string{"root2"}},
+					{Type: Edge_dependsOn, From: "root2", To: []string{"a"}},
+				},
+				RootElements: []string{"root1", "root2"},
+			},
+			id:       "a",
+			expected: []NodePath{{"root2", "a"}},
+		},
+		{
+			name: "crossing roots",
+			sut: &NodeList{
+				Nodes: []*Node{{Id: "root1"}, {Id: "root2"}, {Id: "a"}},
+				Edges: []*Edge{
+					{Type: Edge_dependsOn, From: "root1", To: []string{"root2"}},
+					{Type: Edge_dependsOn, From: "root2", To: []string{"a"}},
+				},
+				RootElements: []string{"root1", "root2"},
+			},
+			id:       "a",
+			opts:     &TraversalOptions{CrossRoots: true},
+			expected: []NodePath{{"root1", "root2", "a"}, {"root2", "a"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			require.ElementsMatch(t, tc.expected, tc.sut.PathsFromRootsWithOptions(tc.id, tc.opts))
+		})
+	}
+}
+
+func TestDependentsLargeGraph(t *testing.T) {
+	t.Parallel()
+	// Build a long chain with a wide fan-in at the end to check the
+	// reverse walk scales to graphs of 100k+ edges.
+	const size = 50_000
+	nl := NewNodeList()
+	nl.AddRootNode(&Node{Id: "n0"})
+	for i := 1; i <= size; i++ {
+		nl.AddNode(&Node{Id: fmt.Sprintf("n%d", i)})
+		nl.AddEdge(&Edge{Type: Edge_dependsOn, From: fmt.Sprintf("n%d", i-1), To: []string{fmt.Sprintf("n%d", i)}})
+	}
+	fanIn := &Edge{Type: Edge_dependsOn, From: "n0", To: []string{}}
+	for i := 1; i <= size; i++ {
+		fanIn.To = append(fanIn.To, fmt.Sprintf("n%d", i))
+	}
+	nl.AddEdge(fanIn)
+
+	require.Len(t, nl.Dependents(fmt.Sprintf("n%d", size)), size)
+	require.Len(t, nl.PathsFromRoots("n3"), 3)
+}
+
+func TestPathsFromRootsLargeGraph(t *testing.T) {
+	t.Parallel()
+	// Every node depends on all the nodes after it, so the number of
+	// paths to the last one doubles with each node in the graph.
+	const size = 200
+	nl := NewNodeList()
+	nl.AddRootNode(&Node{Id: "n0"})
+	for i := 1; i <= size; i++ {
+		nl.AddNode(&Node{Id: fmt.Sprintf("n%d", i)})
+	}
+	for i := 0; i < size; i++ {
+		e := &Edge{Type: Edge_dependsOn, From: fmt.Sprintf("n%d", i), To: []string{}}
+		for j := i + 1; j <= size; j++ {
+			e.To = append(e.To, fmt.Sprintf("n%d", j))
+		}
+		nl.AddEdge(e)
+	}
+
+	target := fmt.Sprintf("n%d", size)
+	paths := nl.PathsFromRoots(target)
+	require.Len(t, paths, DefaultMaxPaths)
+	for _, p := range paths {
+		require.Equal(t, "n0", p[0])
+		require.Equal(t, target, p.Target())
+	}
+
+	require.Len(t, nl.PathsFromRootsWithOptions(target, &TraversalOptions{MaxPaths: 10}), 10)
+	require.Len(t, nl.PathsFromRootsWithOptions("n4", &TraversalOptions{MaxPaths: -1}), 8)
+}
+
+func TestPathsFromRootsDeadEnds(t *testing.T) {
+	t.Parallel()
+	// The root reaches the target directly and through a deep lattice of
+	// fully connected layers. A walk entering the lattice would explore
+	// width^layers partial paths.
+	const width, layers = 20, 12
+	nl := NewNodeList()
+	nl.AddRootNode(&Node{Id: "root"})
+	nl.AddNode(&Node{Id: "target"})
+	nl.AddEdge(&Edge{Type: Edge_dependsOn, From: "root", To: []string{"target"}})
+
+	layer := func(l int) []string {
+		ids := []string{}
+		for i := range width {
+			ids = append(ids, fmt.Sprintf("l%d-%d", l, i))
+		}
+		return ids
+	}
+	for l := range layers {
+		for _, id := range layer(l) {
+			nl.AddNode(&Node{Id: id})
+			if l+1 < layers {
+				nl.AddEdge(&Edge{Type: Edge_dependsOn, From: id, To: layer(l + 1)})
+			}
+		}
+	}
+	nl.AddEdge(&Edge{Type: Edge_dependsOn, From: "root", To: layer(0)})
+
+	// The lattice is a dead end when none of its nodes lead to the target
+	paths := nl.PathsFromRootsWithOptions("target", &TraversalOptions{MaxPaths: -1})
+	require.Equal(t, []NodePath{{"root", "target"}}, paths)
+
+	// Or when the paths through it are longer than MaxDepth
+	for _, id := range layer(layers - 1) {
+		nl.AddEdge(&Edge{Type: Edge_dependsOn, From: id, To: []string{"target"}})
+	}
+	paths = nl.PathsFromRootsWithOptions("target", &TraversalOptions{MaxPaths: -1, MaxDepth: layers})
+	require.Equal(t, []NodePath{{"root", "target"}}, paths)
+
+	// Or when they go through another root element
+	nl.AddRootNode(&Node{Id: "other-root"})
+	nl.AddEdge(&Edge{Type: Edge_dependsOn, From: "other-root", To: []string{"target"}})
+	for _, id := range layer(layers - 1) {
+		nl.GetEdgeByType(id, Edge_dependsOn).To = []string{"other-root"}
+	}
+	paths = nl.PathsFromRootsWithOptions("target", &TraversalOptions{MaxPaths: -1})
+	require.Equal(t, []NodePath{{"root", "target"}, {"other-root", "target"}}, paths)
+}
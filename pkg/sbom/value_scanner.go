@@ -94,6 +94,14 @@ func (x *SourceData) Scan(src any) error {
 	return scan(src, x)
 }
 
+func (x *Subject) Value() (driver.Value, error) {
+	return value(x)
+}
+
+func (x *Subject) Scan(src any) error {
+	return scan(src, x)
+}
+
 func (x *Tool) Value() (driver.Value, error) {
 	return value(x)
 }
@@ -0,0 +1,20 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+)
+
+// NewGateway returns an HTTP handler that translates HTTP/JSON requests to
+// calls to the conversion service reachable through conn. The routes are
+// defined in api/service.yaml.
+func NewGateway(ctx context.Context, conn *grpc.ClientConn, opts ...runtime.ServeMuxOption) (*runtime.ServeMux, error) {
+	mux := runtime.NewServeMux(opts...)
+	if err := RegisterConversionServiceHandler(ctx, mux, conn); err != nil {
+		return nil, fmt.Errorf("registering service handler: %w", err)
+	}
+	return mux, nil
+}
@@ -0,0 +1,258 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2026 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+// Package service implements a gRPC service to convert, compare and store
+// SBOMs using the protobom libraries. The service definition is generated
+// from api/service.proto, which also defines an HTTP/JSON gateway.
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sort"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/protobom/protobom/pkg/formats"
+	"github.com/protobom/protobom/pkg/native"
+	"github.com/protobom/protobom/pkg/reader"
+	"github.com/protobom/protobom/pkg/sbom"
+	"github.com/protobom/protobom/pkg/storage"
+	"github.com/protobom/protobom/pkg/writer"
+)
+
+// DefaultMaxDocumentSize is the largest native document accepted by
+// default by the streaming methods (256 MiB).
+const DefaultMaxDocumentSize = 256 << 20
+
+type ServerOptions struct {
+	// MaxDocumentSize is the maximum size in bytes of the native
+	// documents streamed to the service. Compressed documents must not
+	// exceed it once decompressed either.
+	MaxDocumentSize int
+
+	// TrackSource records the source data of parsed documents
+	TrackSource bool
+}
+
+// Server implements the ConversionService. Documents are read and written
+// with the registered unserializers and serializers. Store and Retrieve
+// use the storage backend, if it is nil they return Unimplemented.
+type Server struct {
+	UnimplementedConversionServiceServer
+	Options ServerOptions
+	Storage storage.Backend
+}
+
+// NewServer returns a new server storing documents in backend, which
+// can be nil.
+func NewServer(backend storage.Backend) *Server {
+	return &Server{
+		Options: ServerOptions{
+			MaxDocumentSize: DefaultMaxDocumentSize,
+		},
+		Storage: backend,
+	}
+}
+
+// Register adds the server to the gRPC server s
+func (s *Server) Register(gs grpc.ServiceRegistrar) {
+	RegisterConversionServiceServer(gs, s)
+}
+
+// chunk is implemented by the messages of the streaming methods
+type chunk[T any] interface {
+	*T
+	GetData() []byte
+}
+
+// receive reads all the chunks of a client stream. It returns the data and
+// the first message received, which carries the request options.
+func receive[Req, Res any, C chunk[Req]](s *Server, stream grpc.ClientStreamingServer[Req, Res]) (first C, data []byte, err error) {
+	var buf bytes.Buffer
+	for i := 0; ; i++ {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return first, nil, err
+		}
+		if i == 0 {
+			first = msg
+		}
+		part := C(msg).GetData()
+		if buf.Len()+len(part) > s.Options.MaxDocumentSize {
+			return first, nil, status.Errorf(codes.ResourceExhausted, "document exceeds %d bytes", s.Options.MaxDocumentSize)
+		}
+		buf.Write(part)
+	}
+	if buf.Len() == 0 {
+		return first, nil, status.Error(codes.InvalidArgument, "no document data received")
+	}
+	return first, buf.Bytes(), nil
+}
+
+// parse reads a native document. Compressed documents are also limited to
+// MaxDocumentSize once decompressed.
+func (s *Server) parse(data []byte, format string) (*sbom.Document, error) {
+	doc, err := reader.New().ParseStreamWithOptions(bytes.NewReader(data), &reader.Options{
+		Format: formats.Format(format),
+		UnserializeOptions: &native.UnserializeOptions{
+			TrackSource: s.Options.TrackSource,
+		},
+		MaxDecompressedSize: int64(s.Options.MaxDocumentSize),
+	})
+	if errors.Is(err, reader.ErrDecompressedSizeExceeded) {
+		return nil, status.Errorf(codes.ResourceExhausted, "decompressed document exceeds %d bytes", s.Options.MaxDocumentSize)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "parsing document: %v", err)
+	}
+	return doc, nil
+}
+
+// render writes a document in a native format
+func (s *Server) render(doc *sbom.Document, format string) ([]byte, error) {
+	if format == "" {
+		return nil, status.Error(codes.InvalidArgument, "no output format specified")
+	}
+	if doc == nil {
+		return nil, status.Error(codes.InvalidArgument, "no document to render")
+	}
+
+	var buf bytes.Buffer
+	if err := writer.New().WriteStreamWithOptions(doc, &buf, &writer.Options{
+		Format:           formats.Format(format),
+		RenderOptions:    &native.RenderOptions{},
+		SerializeOptions: &native.SerializeOptions{},
+	}); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "rendering document: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Convert reads a streamed native document and renders it in another format
+func (s *Server) Convert(stream grpc.ClientStreamingServer[ConvertRequest, ConvertResponse]) error {
+	req, data, err := receive(s, stream)
+	if err != nil {
+		return err
+	}
+
+	doc, err := s.parse(data, req.GetInputFormat())
+	if err != nil {
+		return err
+	}
+
+	out, err := s.render(doc, req.GetOutputFormat())
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(&ConvertResponse{Format: req.GetOutputFormat(), Data: out})
+}
+
+// Parse reads a streamed native document
+func (s *Server) Parse(stream grpc.ClientStreamingServer[ParseRequest, ParseResponse]) error {
+	req, data, err := receive(s, stream)
+	if err != nil {
+		return err
+	}
+
+	doc, err := s.parse(data, req.GetFormat())
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(&ParseResponse{Document: doc})
+}
+
+// Render writes a document in a native format
+func (s *Server) Render(_ context.Context, req *RenderRequest) (*RenderResponse, error) {
+	data, err := s.render(req.GetDocument(), req.GetFormat())
+	if err != nil {
+		return nil, err
+	}
+	return &RenderResponse{Format: req.GetFormat(), Data: data}, nil
+}
+
+// Diff compares the node lists of two documents
+func (s *Server) Diff(_ context.Context, req *DiffRequest) (*DiffResponse, error) {
+	d := req.GetBase().GetNodeList().Diff(req.GetTarget().GetNodeList())
+
+	ids := make([]string, 0, len(d.ChangedNodes))
+	for id := range d.ChangedNodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	changes := make([]*NodeChange, 0, len(ids))
+	for _, id := range ids {
+		changes = append(changes, &NodeChange{
+			Id:      id,
+			Added:   d.ChangedNodes[id].Added,
+			Removed: d.ChangedNodes[id].Removed,
+			Count:   int32(d.ChangedNodes[id].DiffCount), //nolint:gosec // Bounded by the number of node fields
+		})
+	}
+
+	return &DiffResponse{
+		AddedNodes:   d.AddedNodes,
+		RemovedNodes: d.RemovedNodes,
+		ChangedNodes: changes,
+		AddedEdges:   d.AddedEdges,
+		RemovedEdges: d.RemovedEdges,
+	}, nil
+}
+
+// Store saves a document in the storage backend. The returned ID is the
+// document ID or, for content addressed backends, the digest of the data.
+func (s *Server) Store(_ context.Context, req *StoreRequest) (*StoreResponse, error) {
+	if s.Storage == nil {
+		return nil, status.Error(codes.Unimplemented, "the service has no storage backend")
+	}
+	doc := req.GetDocument()
+	if doc == nil {
+		return nil, status.Error(codes.InvalidArgument, "no document to store")
+	}
+
+	var err error
+	id := doc.GetMetadata().GetId()
+	switch b := s.Storage.(type) {
+	case *storage.ContentAddressed:
+		id, err = b.StoreDigest(doc, &storage.StoreOptions{})
+	case *storage.OCILayout:
+		desc, derr := b.StoreDescriptor(doc, &storage.StoreOptions{})
+		id, err = desc.Digest.String(), derr
+	default:
+		err = b.Store(doc, &storage.StoreOptions{})
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "storing document: %v", err)
+	}
+	return &StoreResponse{Id: id}, nil
+}
+
+// Retrieve reads a document from the storage backend
+func (s *Server) Retrieve(_ context.Context, req *RetrieveRequest) (*RetrieveResponse, error) {
+	if s.Storage == nil {
+		return nil, status.Error(codes.Unimplemented, "the service has no storage backend")
+	}
+
+	doc, err := s.Storage.Retrieve(req.GetId(), &storage.RetrieveOptions{})
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "document %s not found", req.GetId())
+	}
+	if errors.Is(err, storage.ErrInvalidReference) {
+		return nil, status.Errorf(codes.InvalidArgument, "retrieving document %s: %v", req.GetId(), err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "retrieving document %s: %v", req.GetId(), err)
+	}
+	return &RetrieveResponse{Document: doc}, nil
+}
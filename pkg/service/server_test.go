@@ -0,0 +1,198 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/protobom/protobom/pkg/compression"
+	"github.com/protobom/protobom/pkg/formats"
+	"github.com/protobom/protobom/pkg/sbom"
+	"github.com/protobom/protobom/pkg/storage"
+)
+
+const testSBOM = "../../test/conformance/testdata/cyclonedx/1.5/json/syft-0.96.0_plone-5.2.cdx.json"
+
+func newTestClient(t *testing.T, srv *Server) (ConversionServiceClient, *grpc.ClientConn) {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	gs := grpc.NewServer()
+	srv.Register(gs)
+	go gs.Serve(lis) //nolint:errcheck
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() }) //nolint:errcheck,gosec
+	return NewConversionServiceClient(conn), conn
+}
+
+// parseDocument streams data to the Parse method in small chunks
+func parseDocument(t *testing.T, client ConversionServiceClient, data []byte) *sbom.Document {
+	t.Helper()
+	stream, err := client.Parse(t.Context())
+	require.NoError(t, err)
+	for len(data) > 0 {
+		n := min(len(data), 4096)
+		require.NoError(t, stream.Send(&ParseRequest{Data: data[:n]}))
+		data = data[n:]
+	}
+	res, err := stream.CloseAndRecv()
+	require.NoError(t, err)
+	return res.GetDocument()
+}
+
+func TestServer(t *testing.T) {
+	t.Parallel()
+	data, err := os.ReadFile(testSBOM)
+	require.NoError(t, err)
+
+	client, _ := newTestClient(t, NewServer(storage.NewMemory()))
+	doc := parseDocument(t, client, data)
+	require.NotEmpty(t, doc.GetNodeList().GetNodes())
+
+	// Convert
+	cs, err := client.Convert(t.Context())
+	require.NoError(t, err)
+	require.NoError(t, cs.Send(&ConvertRequest{OutputFormat: string(formats.SPDX23JSON), Data: data[:100]}))
+	require.NoError(t, cs.Send(&ConvertRequest{Data: data[100:]}))
+	converted, err := cs.CloseAndRecv()
+	require.NoError(t, err)
+	require.Equal(t, string(formats.SPDX23JSON), converted.GetFormat())
+	require.Regexp(t, `"spdxVersion":\s*"SPDX-2.3"`, string(converted.GetData()))
+
+	// Render
+	rendered, err := client.Render(t.Context(), &RenderRequest{Document: doc, Format: string(formats.CDX16JSON)})
+	require.NoError(t, err)
+	require.Regexp(t, `"specVersion":\s*"1.6"`, string(rendered.GetData()))
+
+	_, err = client.Render(t.Context(), &RenderRequest{Document: doc})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Diff
+	target := proto.CloneOf(doc)
+	target.NodeList.RemoveNodes([]string{target.GetNodeList().GetNodes()[0].GetId()})
+	diff, err := client.Diff(t.Context(), &DiffRequest{Base: doc, Target: target})
+	require.NoError(t, err)
+	require.Len(t, diff.GetRemovedNodes(), 1)
+	require.Empty(t, diff.GetAddedNodes())
+
+	// Store and retrieve
+	stored, err := client.Store(t.Context(), &StoreRequest{Document: doc})
+	require.NoError(t, err)
+	require.Equal(t, doc.GetMetadata().GetId(), stored.GetId())
+
+	retrieved, err := client.Retrieve(t.Context(), &RetrieveRequest{Id: stored.GetId()})
+	require.NoError(t, err)
+	require.Len(t, retrieved.GetDocument().GetNodeList().GetNodes(), len(doc.GetNodeList().GetNodes()))
+
+	_, err = client.Retrieve(t.Context(), &RetrieveRequest{Id: "missing"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestServerErrors(t *testing.T) {
+	t.Parallel()
+	srv := NewServer(nil)
+	srv.Options.MaxDocumentSize = 10
+	client, _ := newTestClient(t, srv)
+
+	_, err := client.Store(t.Context(), &StoreRequest{Document: sbom.NewDocument()})
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+
+	stream, err := client.Parse(t.Context())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&ParseRequest{Data: []byte("0123456789abcdef")}))
+	_, err = stream.CloseAndRecv()
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	stream, err = client.Parse(t.Context())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&ParseRequest{Data: []byte("garbage")}))
+	_, err = stream.CloseAndRecv()
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServerRetrieveErrors(t *testing.T) {
+	t.Parallel()
+	ca := storage.NewContentAddressed()
+	ca.Options.Path = t.TempDir()
+	client, _ := newTestClient(t, NewServer(ca))
+
+	_, err := client.Retrieve(t.Context(), &RetrieveRequest{Id: "sha256:not-a-digest"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.Retrieve(t.Context(), &RetrieveRequest{Id: "protobom-v1+sha256:" + strings.Repeat("0", 64)})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestServerCompressedLimit(t *testing.T) {
+	t.Parallel()
+	srv := NewServer(nil)
+	srv.Options.MaxDocumentSize = 64 << 10
+	client, _ := newTestClient(t, srv)
+
+	// A small compressed document expanding beyond the size limit
+	var bomb bytes.Buffer
+	zw, err := compression.NewWriter(compression.Gzip, &bomb)
+	require.NoError(t, err)
+	_, err = zw.Write(bytes.Repeat([]byte(" "), 16<<20))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	require.Less(t, bomb.Len(), srv.Options.MaxDocumentSize)
+
+	stream, err := client.Parse(t.Context())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&ParseRequest{Data: bomb.Bytes()}))
+	_, err = stream.CloseAndRecv()
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestGateway(t *testing.T) {
+	t.Parallel()
+	data, err := os.ReadFile(testSBOM)
+	require.NoError(t, err)
+
+	client, conn := newTestClient(t, NewServer(storage.NewMemory()))
+	doc := parseDocument(t, client, data)
+
+	mux, err := NewGateway(t.Context(), conn)
+	require.NoError(t, err)
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	body, err := protojson.Marshal(&StoreRequest{Document: doc})
+	require.NoError(t, err)
+	res, err := http.Post(ts.URL+"/v1/documents", "application/json", bytes.NewReader(body)) //nolint:noctx
+	require.NoError(t, err)
+	defer res.Body.Close() //nolint:errcheck
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	stored := map[string]string{}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&stored))
+	require.Equal(t, doc.GetMetadata().GetId(), stored["id"])
+
+	res2, err := http.Get(ts.URL + "/v1/documents/missing") //nolint:noctx
+	require.NoError(t, err)
+	defer res2.Body.Close() //nolint:errcheck
+	require.Equal(t, http.StatusNotFound, res2.StatusCode)
+}
@@ -0,0 +1,903 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2026 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.7
+// 	protoc        (unknown)
+// source: service.proto
+
+package service
+
+import (
+	sbom "github.com/protobom/protobom/pkg/sbom"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ConvertRequest carries a chunk of the native SBOM to convert.
+type ConvertRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Format of the input document. When empty, it is detected from
+	// the data. Only read from the first message of the stream.
+	InputFormat string `protobuf:"bytes,1,opt,name=input_format,json=inputFormat,proto3" json:"input_format,omitempty"`
+	// Format to convert the document to. Only read from the first
+	// message of the stream.
+	OutputFormat string `protobuf:"bytes,2,opt,name=output_format,json=outputFormat,proto3" json:"output_format,omitempty"`
+	// Chunk of the native document
+	Data          []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConvertRequest) Reset() {
+	*x = ConvertRequest{}
+	mi := &file_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConvertRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertRequest) ProtoMessage() {}
+
+func (x *ConvertRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertRequest.ProtoReflect.Descriptor instead.
+func (*ConvertRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ConvertRequest) GetInputFormat() string {
+	if x != nil {
+		return x.InputFormat
+	}
+	return ""
+}
+
+func (x *ConvertRequest) GetOutputFormat() string {
+	if x != nil {
+		return x.OutputFormat
+	}
+	return ""
+}
+
+func (x *ConvertRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// ConvertResponse holds the converted native SBOM.
+type ConvertResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Format of the returned document
+	Format string `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	// The rendered native document
+	Data          []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConvertResponse) Reset() {
+	*x = ConvertResponse{}
+	mi := &file_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConvertResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertResponse) ProtoMessage() {}
+
+func (x *ConvertResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertResponse.ProtoReflect.Descriptor instead.
+func (*ConvertResponse) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ConvertResponse) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ConvertResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// ParseRequest carries a chunk of the native SBOM to parse.
+type ParseRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Format of the document. When empty, it is detected from the data.
+	// Only read from the first message of the stream.
+	Format string `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	// Chunk of the native document
+	Data          []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParseRequest) Reset() {
+	*x = ParseRequest{}
+	mi := &file_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParseRequest) ProtoMessage() {}
+
+func (x *ParseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParseRequest.ProtoReflect.Descriptor instead.
+func (*ParseRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ParseRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ParseRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// ParseResponse returns the parsed document.
+type ParseResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The document read from the native SBOM
+	Document      *sbom.Document `protobuf:"bytes,1,opt,name=document,proto3" json:"document,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParseResponse) Reset() {
+	*x = ParseResponse{}
+	mi := &file_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParseResponse) ProtoMessage() {}
+
+func (x *ParseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParseResponse.ProtoReflect.Descriptor instead.
+func (*ParseResponse) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ParseResponse) GetDocument() *sbom.Document {
+	if x != nil {
+		return x.Document
+	}
+	return nil
+}
+
+// RenderRequest holds the document to render.
+type RenderRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Document to render
+	Document *sbom.Document `protobuf:"bytes,1,opt,name=document,proto3" json:"document,omitempty"`
+	// Format of the native document to render
+	Format        string `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderRequest) Reset() {
+	*x = RenderRequest{}
+	mi := &file_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderRequest) ProtoMessage() {}
+
+func (x *RenderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderRequest.ProtoReflect.Descriptor instead.
+func (*RenderRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RenderRequest) GetDocument() *sbom.Document {
+	if x != nil {
+		return x.Document
+	}
+	return nil
+}
+
+func (x *RenderRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+// RenderResponse holds the rendered native SBOM.
+type RenderResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Format of the returned document
+	Format string `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	// The rendered native document
+	Data          []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderResponse) Reset() {
+	*x = RenderResponse{}
+	mi := &file_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderResponse) ProtoMessage() {}
+
+func (x *RenderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderResponse.ProtoReflect.Descriptor instead.
+func (*RenderResponse) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RenderResponse) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *RenderResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// DiffRequest holds the documents to compare.
+type DiffRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Document to compare from
+	Base *sbom.Document `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// Document to compare to
+	Target        *sbom.Document `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffRequest) Reset() {
+	*x = DiffRequest{}
+	mi := &file_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffRequest) ProtoMessage() {}
+
+func (x *DiffRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffRequest.ProtoReflect.Descriptor instead.
+func (*DiffRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DiffRequest) GetBase() *sbom.Document {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *DiffRequest) GetTarget() *sbom.Document {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+// NodeChange describes the changes to a node found in both documents.
+type NodeChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID of the changed node
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Node populated with the values added in the target document
+	Added *sbom.Node `protobuf:"bytes,2,opt,name=added,proto3" json:"added,omitempty"`
+	// Node populated with the values removed from the base document
+	Removed *sbom.Node `protobuf:"bytes,3,opt,name=removed,proto3" json:"removed,omitempty"`
+	// Number of fields changed
+	Count         int32 `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NodeChange) Reset() {
+	*x = NodeChange{}
+	mi := &file_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NodeChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeChange) ProtoMessage() {}
+
+func (x *NodeChange) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeChange.ProtoReflect.Descriptor instead.
+func (*NodeChange) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *NodeChange) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *NodeChange) GetAdded() *sbom.Node {
+	if x != nil {
+		return x.Added
+	}
+	return nil
+}
+
+func (x *NodeChange) GetRemoved() *sbom.Node {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
+}
+
+func (x *NodeChange) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// DiffResponse lists the differences found in the target document.
+// Edges relate a single pair of nodes each.
+type DiffResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Nodes only found in the target document
+	AddedNodes []*sbom.Node `protobuf:"bytes,1,rep,name=added_nodes,json=addedNodes,proto3" json:"added_nodes,omitempty"`
+	// Nodes only found in the base document
+	RemovedNodes []*sbom.Node `protobuf:"bytes,2,rep,name=removed_nodes,json=removedNodes,proto3" json:"removed_nodes,omitempty"`
+	// Nodes found in both documents with different data
+	ChangedNodes []*NodeChange `protobuf:"bytes,3,rep,name=changed_nodes,json=changedNodes,proto3" json:"changed_nodes,omitempty"`
+	// Edges only found in the target document
+	AddedEdges []*sbom.Edge `protobuf:"bytes,4,rep,name=added_edges,json=addedEdges,proto3" json:"added_edges,omitempty"`
+	// Edges only found in the base document
+	RemovedEdges  []*sbom.Edge `protobuf:"bytes,5,rep,name=removed_edges,json=removedEdges,proto3" json:"removed_edges,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffResponse) Reset() {
+	*x = DiffResponse{}
+	mi := &file_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffResponse) ProtoMessage() {}
+
+func (x *DiffResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffResponse.ProtoReflect.Descriptor instead.
+func (*DiffResponse) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DiffResponse) GetAddedNodes() []*sbom.Node {
+	if x != nil {
+		return x.AddedNodes
+	}
+	return nil
+}
+
+func (x *DiffResponse) GetRemovedNodes() []*sbom.Node {
+	if x != nil {
+		return x.RemovedNodes
+	}
+	return nil
+}
+
+func (x *DiffResponse) GetChangedNodes() []*NodeChange {
+	if x != nil {
+		return x.ChangedNodes
+	}
+	return nil
+}
+
+func (x *DiffResponse) GetAddedEdges() []*sbom.Edge {
+	if x != nil {
+		return x.AddedEdges
+	}
+	return nil
+}
+
+func (x *DiffResponse) GetRemovedEdges() []*sbom.Edge {
+	if x != nil {
+		return x.RemovedEdges
+	}
+	return nil
+}
+
+// StoreRequest holds the document to store.
+type StoreRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Document to store. Its metadata ID is used to retrieve it.
+	Document      *sbom.Document `protobuf:"bytes,1,opt,name=document,proto3" json:"document,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoreRequest) Reset() {
+	*x = StoreRequest{}
+	mi := &file_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreRequest) ProtoMessage() {}
+
+func (x *StoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreRequest.ProtoReflect.Descriptor instead.
+func (*StoreRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *StoreRequest) GetDocument() *sbom.Document {
+	if x != nil {
+		return x.Document
+	}
+	return nil
+}
+
+// StoreResponse returns the reference to the stored document.
+type StoreResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Identifier to retrieve the document
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoreResponse) Reset() {
+	*x = StoreResponse{}
+	mi := &file_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreResponse) ProtoMessage() {}
+
+func (x *StoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreResponse.ProtoReflect.Descriptor instead.
+func (*StoreResponse) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *StoreResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// RetrieveRequest identifies the document to retrieve.
+type RetrieveRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Identifier of the document
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetrieveRequest) Reset() {
+	*x = RetrieveRequest{}
+	mi := &file_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetrieveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetrieveRequest) ProtoMessage() {}
+
+func (x *RetrieveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetrieveRequest.ProtoReflect.Descriptor instead.
+func (*RetrieveRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RetrieveRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// RetrieveResponse returns the document read from storage.
+type RetrieveResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The retrieved document
+	Document      *sbom.Document `protobuf:"bytes,1,opt,name=document,proto3" json:"document,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetrieveResponse) Reset() {
+	*x = RetrieveResponse{}
+	mi := &file_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetrieveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetrieveResponse) ProtoMessage() {}
+
+func (x *RetrieveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetrieveResponse.ProtoReflect.Descriptor instead.
+func (*RetrieveResponse) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RetrieveResponse) GetDocument() *sbom.Document {
+	if x != nil {
+		return x.Document
+	}
+	return nil
+}
+
+var File_service_proto protoreflect.FileDescriptor
+
+const file_service_proto_rawDesc = "" +
+	"\n" +
+	"\rservice.proto\x12\x11protobom.protobom\x1a\n" +
+	"sbom.proto\"l\n" +
+	"\x0eConvertRequest\x12!\n" +
+	"\finput_format\x18\x01 \x01(\tR\vinputFormat\x12#\n" +
+	"\routput_format\x18\x02 \x01(\tR\foutputFormat\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\"=\n" +
+	"\x0fConvertResponse\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\":\n" +
+	"\fParseRequest\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"H\n" +
+	"\rParseResponse\x127\n" +
+	"\bdocument\x18\x01 \x01(\v2\x1b.protobom.protobom.DocumentR\bdocument\"`\n" +
+	"\rRenderRequest\x127\n" +
+	"\bdocument\x18\x01 \x01(\v2\x1b.protobom.protobom.DocumentR\bdocument\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\"<\n" +
+	"\x0eRenderResponse\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"s\n" +
+	"\vDiffRequest\x12/\n" +
+	"\x04base\x18\x01 \x01(\v2\x1b.protobom.protobom.DocumentR\x04base\x123\n" +
+	"\x06target\x18\x02 \x01(\v2\x1b.protobom.protobom.DocumentR\x06target\"\x94\x01\n" +
+	"\n" +
+	"NodeChange\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12-\n" +
+	"\x05added\x18\x02 \x01(\v2\x17.protobom.protobom.NodeR\x05added\x121\n" +
+	"\aremoved\x18\x03 \x01(\v2\x17.protobom.protobom.NodeR\aremoved\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\x05R\x05count\"\xc2\x02\n" +
+	"\fDiffResponse\x128\n" +
+	"\vadded_nodes\x18\x01 \x03(\v2\x17.protobom.protobom.NodeR\n" +
+	"addedNodes\x12<\n" +
+	"\rremoved_nodes\x18\x02 \x03(\v2\x17.protobom.protobom.NodeR\fremovedNodes\x12B\n" +
+	"\rchanged_nodes\x18\x03 \x03(\v2\x1d.protobom.protobom.NodeChangeR\fchangedNodes\x128\n" +
+	"\vadded_edges\x18\x04 \x03(\v2\x17.protobom.protobom.EdgeR\n" +
+	"addedEdges\x12<\n" +
+	"\rremoved_edges\x18\x05 \x03(\v2\x17.protobom.protobom.EdgeR\fremovedEdges\"G\n" +
+	"\fStoreRequest\x127\n" +
+	"\bdocument\x18\x01 \x01(\v2\x1b.protobom.protobom.DocumentR\bdocument\"\x1f\n" +
+	"\rStoreResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"!\n" +
+	"\x0fRetrieveRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"K\n" +
+	"\x10RetrieveResponse\x127\n" +
+	"\bdocument\x18\x01 \x01(\v2\x1b.protobom.protobom.DocumentR\bdocument2\xee\x03\n" +
+	"\x11ConversionService\x12R\n" +
+	"\aConvert\x12!.protobom.protobom.ConvertRequest\x1a\".protobom.protobom.ConvertResponse(\x01\x12L\n" +
+	"\x05Parse\x12\x1f.protobom.protobom.ParseRequest\x1a .protobom.protobom.ParseResponse(\x01\x12M\n" +
+	"\x06Render\x12 .protobom.protobom.RenderRequest\x1a!.protobom.protobom.RenderResponse\x12G\n" +
+	"\x04Diff\x12\x1e.protobom.protobom.DiffRequest\x1a\x1f.protobom.protobom.DiffResponse\x12J\n" +
+	"\x05Store\x12\x1f.protobom.protobom.StoreRequest\x1a .protobom.protobom.StoreResponse\x12S\n" +
+	"\bRetrieve\x12\".protobom.protobom.RetrieveRequest\x1a#.protobom.protobom.RetrieveResponseB\xb4\x01\n" +
+	"\x15com.protobom.protobomB\fServiceProtoP\x01Z(github.com/protobom/protobom/pkg/service\xa2\x02\x03PPX\xaa\x02\x11Protobom.Protobom\xca\x02\x11Protobom\\Protobom\xe2\x02\x1dProtobom\\Protobom\\GPBMetadata\xea\x02\x12Protobom::Protobomb\x06proto3"
+
+var (
+	file_service_proto_rawDescOnce sync.Once
+	file_service_proto_rawDescData []byte
+)
+
+func file_service_proto_rawDescGZIP() []byte {
+	file_service_proto_rawDescOnce.Do(func() {
+		file_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_service_proto_rawDesc), len(file_service_proto_rawDesc)))
+	})
+	return file_service_proto_rawDescData
+}
+
+var file_service_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_service_proto_goTypes = []any{
+	(*ConvertRequest)(nil),   // 0: protobom.protobom.ConvertRequest
+	(*ConvertResponse)(nil),  // 1: protobom.protobom.ConvertResponse
+	(*ParseRequest)(nil),     // 2: protobom.protobom.ParseRequest
+	(*ParseResponse)(nil),    // 3: protobom.protobom.ParseResponse
+	(*RenderRequest)(nil),    // 4: protobom.protobom.RenderRequest
+	(*RenderResponse)(nil),   // 5: protobom.protobom.RenderResponse
+	(*DiffRequest)(nil),      // 6: protobom.protobom.DiffRequest
+	(*NodeChange)(nil),       // 7: protobom.protobom.NodeChange
+	(*DiffResponse)(nil),     // 8: protobom.protobom.DiffResponse
+	(*StoreRequest)(nil),     // 9: protobom.protobom.StoreRequest
+	(*StoreResponse)(nil),    // 10: protobom.protobom.StoreResponse
+	(*RetrieveRequest)(nil),  // 11: protobom.protobom.RetrieveRequest
+	(*RetrieveResponse)(nil), // 12: protobom.protobom.RetrieveResponse
+	(*sbom.Document)(nil),    // 13: protobom.protobom.Document
+	(*sbom.Node)(nil),        // 14: protobom.protobom.Node
+	(*sbom.Edge)(nil),        // 15: protobom.protobom.Edge
+}
+var file_service_proto_depIdxs = []int32{
+	13, // 0: protobom.protobom.ParseResponse.document:type_name -> protobom.protobom.Document
+	13, // 1: protobom.protobom.RenderRequest.document:type_name -> protobom.protobom.Document
+	13, // 2: protobom.protobom.DiffRequest.base:type_name -> protobom.protobom.Document
+	13, // 3: protobom.protobom.DiffRequest.target:type_name -> protobom.protobom.Document
+	14, // 4: protobom.protobom.NodeChange.added:type_name -> protobom.protobom.Node
+	14, // 5: protobom.protobom.NodeChange.removed:type_name -> protobom.protobom.Node
+	14, // 6: protobom.protobom.DiffResponse.added_nodes:type_name -> protobom.protobom.Node
+	14, // 7: protobom.protobom.DiffResponse.removed_nodes:type_name -> protobom.protobom.Node
+	7,  // 8: protobom.protobom.DiffResponse.changed_nodes:type_name -> protobom.protobom.NodeChange
+	15, // 9: protobom.protobom.DiffResponse.added_edges:type_name -> protobom.protobom.Edge
+	15, // 10: protobom.protobom.DiffResponse.removed_edges:type_name -> protobom.protobom.Edge
+	13, // 11: protobom.protobom.StoreRequest.document:type_name -> protobom.protobom.Document
+	13, // 12: protobom.protobom.RetrieveResponse.document:type_name -> protobom.protobom.Document
+	0,  // 13: protobom.protobom.ConversionService.Convert:input_type -> protobom.protobom.ConvertRequest
+	2,  // 14: protobom.protobom.ConversionService.Parse:input_type -> protobom.protobom.ParseRequest
+	4,  // 15: protobom.protobom.ConversionService.Render:input_type -> protobom.protobom.RenderRequest
+	6,  // 16: protobom.protobom.ConversionService.Diff:input_type -> protobom.protobom.DiffRequest
+	9,  // 17: protobom.protobom.ConversionService.Store:input_type -> protobom.protobom.StoreRequest
+	11, // 18: protobom.protobom.ConversionService.Retrieve:input_type -> protobom.protobom.RetrieveRequest
+	1,  // 19: protobom.protobom.ConversionService.Convert:output_type -> protobom.protobom.ConvertResponse
+	3,  // 20: protobom.protobom.ConversionService.Parse:output_type -> protobom.protobom.ParseResponse
+	5,  // 21: protobom.protobom.ConversionService.Render:output_type -> protobom.protobom.RenderResponse
+	8,  // 22: protobom.protobom.ConversionService.Diff:output_type -> protobom.protobom.DiffResponse
+	10, // 23: protobom.protobom.ConversionService.Store:output_type -> protobom.protobom.StoreResponse
+	12, // 24: protobom.protobom.ConversionService.Retrieve:output_type -> protobom.protobom.RetrieveResponse
+	19, // [19:25] is the sub-list for method output_type
+	13, // [13:19] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_service_proto_init() }
+func file_service_proto_init() {
+	if File_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_service_proto_rawDesc), len(file_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_service_proto_goTypes,
+		DependencyIndexes: file_service_proto_depIdxs,
+		MessageInfos:      file_service_proto_msgTypes,
+	}.Build()
+	File_service_proto = out.File
+	file_service_proto_goTypes = nil
+	file_service_proto_depIdxs = nil
+}
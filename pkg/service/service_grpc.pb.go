@@ -0,0 +1,330 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2026 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: service.proto
+
+package service
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ConversionService_Convert_FullMethodName  = "/protobom.protobom.ConversionService/Convert"
+	ConversionService_Parse_FullMethodName    = "/protobom.protobom.ConversionService/Parse"
+	ConversionService_Render_FullMethodName   = "/protobom.protobom.ConversionService/Render"
+	ConversionService_Diff_FullMethodName     = "/protobom.protobom.ConversionService/Diff"
+	ConversionService_Store_FullMethodName    = "/protobom.protobom.ConversionService/Store"
+	ConversionService_Retrieve_FullMethodName = "/protobom.protobom.ConversionService/Retrieve"
+)
+
+// ConversionServiceClient is the client API for ConversionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ConversionService exposes the protobom readers, writers and storage
+// backends as a network service. Native SBOMs are streamed to the service
+// in chunks and protobom documents are returned.
+type ConversionServiceClient interface {
+	// Convert translates a native SBOM into another format. The native
+	// document is streamed in chunks, the formats are read from the first
+	// message.
+	Convert(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ConvertRequest, ConvertResponse], error)
+	// Parse reads a native SBOM streamed in chunks and returns it as a
+	// protobom document.
+	Parse(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ParseRequest, ParseResponse], error)
+	// Render serializes a protobom document into a native format.
+	Render(ctx context.Context, in *RenderRequest, opts ...grpc.CallOption) (*RenderResponse, error)
+	// Diff compares the nodes and edges of two documents.
+	Diff(ctx context.Context, in *DiffRequest, opts ...grpc.CallOption) (*DiffResponse, error)
+	// Store saves a document in the storage backend of the service.
+	Store(ctx context.Context, in *StoreRequest, opts ...grpc.CallOption) (*StoreResponse, error)
+	// Retrieve reads a document from the storage backend of the service.
+	Retrieve(ctx context.Context, in *RetrieveRequest, opts ...grpc.CallOption) (*RetrieveResponse, error)
+}
+
+type conversionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewConversionServiceClient(cc grpc.ClientConnInterface) ConversionServiceClient {
+	return &conversionServiceClient{cc}
+}
+
+func (c *conversionServiceClient) Convert(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ConvertRequest, ConvertResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ConversionService_ServiceDesc.Streams[0], ConversionService_Convert_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ConvertRequest, ConvertResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ConversionService_ConvertClient = grpc.ClientStreamingClient[ConvertRequest, ConvertResponse]
+
+func (c *conversionServiceClient) Parse(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ParseRequest, ParseResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ConversionService_ServiceDesc.Streams[1], ConversionService_Parse_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ParseRequest, ParseResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ConversionService_ParseClient = grpc.ClientStreamingClient[ParseRequest, ParseResponse]
+
+func (c *conversionServiceClient) Render(ctx context.Context, in *RenderRequest, opts ...grpc.CallOption) (*RenderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenderResponse)
+	err := c.cc.Invoke(ctx, ConversionService_Render_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conversionServiceClient) Diff(ctx context.Context, in *DiffRequest, opts ...grpc.CallOption) (*DiffResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiffResponse)
+	err := c.cc.Invoke(ctx, ConversionService_Diff_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conversionServiceClient) Store(ctx context.Context, in *StoreRequest, opts ...grpc.CallOption) (*StoreResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StoreResponse)
+	err := c.cc.Invoke(ctx, ConversionService_Store_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conversionServiceClient) Retrieve(ctx context.Context, in *RetrieveRequest, opts ...grpc.CallOption) (*RetrieveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RetrieveResponse)
+	err := c.cc.Invoke(ctx, ConversionService_Retrieve_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ConversionServiceServer is the server API for ConversionService service.
+// All implementations must embed UnimplementedConversionServiceServer
+// for forward compatibility.
+//
+// ConversionService exposes the protobom readers, writers and storage
+// backends as a network service. Native SBOMs are streamed to the service
+// in chunks and protobom documents are returned.
+type ConversionServiceServer interface {
+	// Convert translates a native SBOM into another format. The native
+	// document is streamed in chunks, the formats are read from the first
+	// message.
+	Convert(grpc.ClientStreamingServer[ConvertRequest, ConvertResponse]) error
+	// Parse reads a native SBOM streamed in chunks and returns it as a
+	// protobom document.
+	Parse(grpc.ClientStreamingServer[ParseRequest, ParseResponse]) error
+	// Render serializes a protobom document into a native format.
+	Render(context.Context, *RenderRequest) (*RenderResponse, error)
+	// Diff compares the nodes and edges of two documents.
+	Diff(context.Context, *DiffRequest) (*DiffResponse, error)
+	// Store saves a document in the storage backend of the service.
+	Store(context.Context, *StoreRequest) (*StoreResponse, error)
+	// Retrieve reads a document from the storage backend of the service.
+	Retrieve(context.Context, *RetrieveRequest) (*RetrieveResponse, error)
+	mustEmbedUnimplementedConversionServiceServer()
+}
+
+// UnimplementedConversionServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedConversionServiceServer struct{}
+
+func (UnimplementedConversionServiceServer) Convert(grpc.ClientStreamingServer[ConvertRequest, ConvertResponse]) error {
+	return status.Error(codes.Unimplemented, "method Convert not implemented")
+}
+func (UnimplementedConversionServiceServer) Parse(grpc.ClientStreamingServer[ParseRequest, ParseResponse]) error {
+	return status.Error(codes.Unimplemented, "method Parse not implemented")
+}
+func (UnimplementedConversionServiceServer) Render(context.Context, *RenderRequest) (*RenderResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Render not implemented")
+}
+func (UnimplementedConversionServiceServer) Diff(context.Context, *DiffRequest) (*DiffResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Diff not implemented")
+}
+func (UnimplementedConversionServiceServer) Store(context.Context, *StoreRequest) (*StoreResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Store not implemented")
+}
+func (UnimplementedConversionServiceServer) Retrieve(context.Context, *RetrieveRequest) (*RetrieveResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Retrieve not implemented")
+}
+func (UnimplementedConversionServiceServer) mustEmbedUnimplementedConversionServiceServer() {}
+func (UnimplementedConversionServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeConversionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ConversionServiceServer will
+// result in compilation errors.
+type UnsafeConversionServiceServer interface {
+	mustEmbedUnimplementedConversionServiceServer()
+}
+
+func RegisterConversionServiceServer(s grpc.ServiceRegistrar, srv ConversionServiceServer) {
+	// If the following call panics, it indicates UnimplementedConversionServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ConversionService_ServiceDesc, srv)
+}
+
+func _ConversionService_Convert_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ConversionServiceServer).Convert(&grpc.GenericServerStream[ConvertRequest, ConvertResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ConversionService_ConvertServer = grpc.ClientStreamingServer[ConvertRequest, ConvertResponse]
+
+func _ConversionService_Parse_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ConversionServiceServer).Parse(&grpc.GenericServerStream[ParseRequest, ParseResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ConversionService_ParseServer = grpc.ClientStreamingServer[ParseRequest, ParseResponse]
+
+func _ConversionService_Render_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConversionServiceServer).Render(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConversionService_Render_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConversionServiceServer).Render(ctx, req.(*RenderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConversionService_Diff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConversionServiceServer).Diff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConversionService_Diff_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConversionServiceServer).Diff(ctx, req.(*DiffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConversionService_Store_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConversionServiceServer).Store(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConversionService_Store_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConversionServiceServer).Store(ctx, req.(*StoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConversionService_Retrieve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetrieveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConversionServiceServer).Retrieve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConversionService_Retrieve_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConversionServiceServer).Retrieve(ctx, req.(*RetrieveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ConversionService_ServiceDesc is the grpc.ServiceDesc for ConversionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ConversionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "protobom.protobom.ConversionService",
+	HandlerType: (*ConversionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Render",
+			Handler:    _ConversionService_Render_Handler,
+		},
+		{
+			MethodName: "Diff",
+			Handler:    _ConversionService_Diff_Handler,
+		},
+		{
+			MethodName: "Store",
+			Handler:    _ConversionService_Store_Handler,
+		},
+		{
+			MethodName: "Retrieve",
+			Handler:    _ConversionService_Retrieve_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Convert",
+			Handler:       _ConversionService_Convert_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Parse",
+			Handler:       _ConversionService_Parse_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "service.proto",
+}
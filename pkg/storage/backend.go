@@ -6,7 +6,20 @@
 
 package storage
 
-import "github.com/protobom/protobom/pkg/sbom"
+import (
+	"errors"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// ErrNotFound is returned by the storage backends when the requested
+// document does not exist.
+var ErrNotFound = errors.New("document not found")
+
+// ErrInvalidReference is returned by the storage backends when the
+// reference to a document is malformed, eg an empty ID or a digest that
+// cannot be parsed.
+var ErrInvalidReference = errors.New("invalid document reference")
 
 type (
 	Storer interface {
@@ -27,4 +40,23 @@ type (
 	Backend interface {
 		StoreRetriever
 	}
+
+	// Lister is an optional interface implemented by backends that can
+	// enumerate the documents they hold. It returns the metadata of each
+	// stored document.
+	Lister interface {
+		List(*ListOptions) ([]*sbom.Metadata, error)
+	}
+
+	// Deleter is an optional interface implemented by backends that can
+	// remove documents.
+	Deleter interface {
+		Delete(string, *DeleteOptions) error
+	}
+
+	// Searcher is an optional interface implemented by backends that can
+	// look up documents by their metadata.
+	Searcher interface {
+		Search(*SearchFilter, *SearchOptions) ([]*sbom.Metadata, error)
+	}
 )
@@ -0,0 +1,214 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2026 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package storage
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// canonicalScheme identifies the canonical form hashed to compute document
+// digests. It is part of the digest string, changes to the canonical form
+// must use a new scheme so existing digests keep their meaning.
+const canonicalScheme = "protobom-v1"
+
+// canonicalDocument returns a copy of the document in canonical form. The
+// nodes are sorted by ID, the edges sharing source and type are merged and
+// sorted with their destinations and the root elements are sorted. The
+// source data is cleared as it describes how the document was read, not
+// its contents.
+func canonicalDocument(bom *sbom.Document) *sbom.Document {
+	c := proto.CloneOf(bom)
+
+	if c.GetMetadata() != nil {
+		c.Metadata.SourceData = nil
+	}
+
+	nl := c.GetNodeList()
+	if nl == nil {
+		return c
+	}
+
+	slices.SortStableFunc(nl.Nodes, func(a, b *sbom.Node) int {
+		return cmp.Compare(a.GetId(), b.GetId())
+	})
+
+	slices.Sort(nl.RootElements)
+	nl.RootElements = slices.Compact(nl.RootElements)
+
+	slices.SortStableFunc(nl.Edges, func(a, b *sbom.Edge) int {
+		return cmp.Or(
+			cmp.Compare(a.GetFrom(), b.GetFrom()),
+			cmp.Compare(a.GetType(), b.GetType()),
+		)
+	})
+	edges := []*sbom.Edge{}
+	for _, e := range nl.Edges {
+		if len(edges) > 0 {
+			last := edges[len(edges)-1]
+			if last.GetFrom() == e.GetFrom() && last.GetType() == e.GetType() {
+				last.To = append(last.To, e.GetTo()...)
+				continue
+			}
+		}
+		edges = append(edges, e)
+	}
+	for _, e := range edges {
+		slices.Sort(e.To)
+		e.To = slices.Compact(e.To)
+	}
+	nl.Edges = edges
+
+	return c
+}
+
+// canonicalMarshal encodes a message in the protobuf wire format following
+// fixed rules that don't depend on the protobuf library implementation:
+// populated fields are written in field number order, map entries are
+// sorted by key, repeated scalars are packed and unknown fields are dropped.
+// The output can be read back with proto.Unmarshal.
+func canonicalMarshal(m protoreflect.Message) []byte {
+	return appendCanonicalMessage(nil, m)
+}
+
+func appendCanonicalMessage(b []byte, m protoreflect.Message) []byte {
+	fields := m.Descriptor().Fields()
+	fds := make([]protoreflect.FieldDescriptor, 0, fields.Len())
+	for i := range fields.Len() {
+		if m.Has(fields.Get(i)) {
+			fds = append(fds, fields.Get(i))
+		}
+	}
+	slices.SortFunc(fds, func(a, b protoreflect.FieldDescriptor) int {
+		return cmp.Compare(a.Number(), b.Number())
+	})
+
+	for _, fd := range fds {
+		v := m.Get(fd)
+		switch {
+		case fd.IsMap():
+			b = appendCanonicalMap(b, fd, v.Map())
+		case fd.IsList():
+			b = appendCanonicalList(b, fd, v.List())
+		default:
+			b = protowire.AppendTag(b, fd.Number(), wireType(fd.Kind()))
+			b = appendCanonicalValue(b, fd.Kind(), v)
+		}
+	}
+	return b
+}
+
+func appendCanonicalList(b []byte, fd protoreflect.FieldDescriptor, list protoreflect.List) []byte {
+	kind := fd.Kind()
+	if wt := wireType(kind); wt != protowire.BytesType {
+		var packed []byte
+		for i := range list.Len() {
+			packed = appendCanonicalValue(packed, kind, list.Get(i))
+		}
+		b = protowire.AppendTag(b, fd.Number(), protowire.BytesType)
+		return protowire.AppendBytes(b, packed)
+	}
+
+	for i := range list.Len() {
+		b = protowire.AppendTag(b, fd.Number(), protowire.BytesType)
+		b = appendCanonicalValue(b, kind, list.Get(i))
+	}
+	return b
+}
+
+func appendCanonicalMap(b []byte, fd protoreflect.FieldDescriptor, m protoreflect.Map) []byte {
+	keyFd, valFd := fd.MapKey(), fd.MapValue()
+
+	keys := make([]protoreflect.MapKey, 0, m.Len())
+	m.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	slices.SortFunc(keys, func(a, b protoreflect.MapKey) int {
+		switch keyFd.Kind() { //nolint:exhaustive // Other kinds cannot be map keys
+		case protoreflect.StringKind:
+			return cmp.Compare(a.String(), b.String())
+		case protoreflect.BoolKind:
+			return cmp.Compare(protowire.EncodeBool(a.Bool()), protowire.EncodeBool(b.Bool()))
+		case protoreflect.Uint32Kind, protoreflect.Uint64Kind,
+			protoreflect.Fixed32Kind, protoreflect.Fixed64Kind:
+			return cmp.Compare(a.Uint(), b.Uint())
+		default:
+			return cmp.Compare(a.Int(), b.Int())
+		}
+	})
+
+	for _, k := range keys {
+		var entry []byte
+		entry = protowire.AppendTag(entry, keyFd.Number(), wireType(keyFd.Kind()))
+		entry = appendCanonicalValue(entry, keyFd.Kind(), k.Value())
+		entry = protowire.AppendTag(entry, valFd.Number(), wireType(valFd.Kind()))
+		entry = appendCanonicalValue(entry, valFd.Kind(), m.Get(k))
+
+		b = protowire.AppendTag(b, fd.Number(), protowire.BytesType)
+		b = protowire.AppendBytes(b, entry)
+	}
+	return b
+}
+
+// appendCanonicalValue appends a single value without its tag
+func appendCanonicalValue(b []byte, kind protoreflect.Kind, v protoreflect.Value) []byte {
+	switch kind {
+	case protoreflect.BoolKind:
+		return protowire.AppendVarint(b, protowire.EncodeBool(v.Bool()))
+	case protoreflect.EnumKind:
+		return protowire.AppendVarint(b, uint64(v.Enum())) //nolint:gosec // Negative enums are sign extended like int32
+	case protoreflect.Int32Kind, protoreflect.Int64Kind:
+		return protowire.AppendVarint(b, uint64(v.Int())) //nolint:gosec // Negative values are sign extended
+	case protoreflect.Uint32Kind, protoreflect.Uint64Kind:
+		return protowire.AppendVarint(b, v.Uint())
+	case protoreflect.Sint32Kind, protoreflect.Sint64Kind:
+		return protowire.AppendVarint(b, protowire.EncodeZigZag(v.Int()))
+	case protoreflect.Fixed32Kind:
+		return protowire.AppendFixed32(b, uint32(v.Uint())) //nolint:gosec // Value is a uint32
+	case protoreflect.Sfixed32Kind:
+		return protowire.AppendFixed32(b, uint32(v.Int())) //nolint:gosec // Value is an int32
+	case protoreflect.FloatKind:
+		return protowire.AppendFixed32(b, math.Float32bits(float32(v.Float())))
+	case protoreflect.Fixed64Kind:
+		return protowire.AppendFixed64(b, v.Uint())
+	case protoreflect.Sfixed64Kind:
+		return protowire.AppendFixed64(b, uint64(v.Int())) //nolint:gosec // Bit pattern is preserved
+	case protoreflect.DoubleKind:
+		return protowire.AppendFixed64(b, math.Float64bits(v.Float()))
+	case protoreflect.StringKind:
+		return protowire.AppendString(b, v.String())
+	case protoreflect.BytesKind:
+		return protowire.AppendBytes(b, v.Bytes())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return protowire.AppendBytes(b, appendCanonicalMessage(nil, v.Message()))
+	}
+	return b
+}
+
+// wireType returns the wire type used to encode values of a kind. Groups
+// are encoded as length delimited messages.
+func wireType(kind protoreflect.Kind) protowire.Type {
+	switch kind {
+	case protoreflect.Fixed32Kind, protoreflect.Sfixed32Kind, protoreflect.FloatKind:
+		return protowire.Fixed32Type
+	case protoreflect.Fixed64Kind, protoreflect.Sfixed64Kind, protoreflect.DoubleKind:
+		return protowire.Fixed64Type
+	case protoreflect.StringKind, protoreflect.BytesKind,
+		protoreflect.MessageKind, protoreflect.GroupKind:
+		return protowire.BytesType
+	default:
+		return protowire.VarintType
+	}
+}
@@ -0,0 +1,212 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2026 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"sigs.k8s.io/release-utils/helpers"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+var (
+	_ StoreRetriever = (*ContentAddressed)(nil)
+	_ Deleter        = (*ContentAddressed)(nil)
+)
+
+// ErrDigestMismatch is returned when the data of a document read from a
+// content-addressed store does not match the digest it is stored under.
+var ErrDigestMismatch = errors.New("document digest mismatch")
+
+// digestAlgorithm is the prefix of the digests computed by the
+// content-addressed store. It combines the canonical form scheme with the
+// hash function applied to it.
+const digestAlgorithm = canonicalScheme + "+sha256"
+
+// DocumentDigest returns the digest that identifies the document in a
+// content-addressed store. The digest is the SHA-256 hash of the canonical
+// encoding of the document, so documents listing the same nodes and edges
+// in a different order get the same digest. It is returned in the
+// algorithm:hex form, eg protobom-v1+sha256:3a5f...
+func DocumentDigest(bom *sbom.Document) (string, error) {
+	if bom == nil {
+		return "", errors.New("document is nil")
+	}
+	data := canonicalMarshal(canonicalDocument(bom).ProtoReflect())
+	return fmt.Sprintf("%s:%x", digestAlgorithm, sha256.Sum256(data)), nil
+}
+
+// parseDigest checks a digest string and returns its hex-encoded value
+func parseDigest(digest string) (string, error) {
+	algo, value, ok := strings.Cut(digest, ":")
+	if !ok || algo != digestAlgorithm {
+		return "", fmt.Errorf("digest %q, expected %s:<hex>: %w", digest, digestAlgorithm, ErrInvalidReference)
+	}
+	if len(value) != sha256.Size*2 {
+		return "", fmt.Errorf("digest %q has the wrong length: %w", digest, ErrInvalidReference)
+	}
+	if _, err := hex.DecodeString(value); err != nil {
+		return "", fmt.Errorf("digest %q: %w: %w", digest, ErrInvalidReference, err)
+	}
+	return strings.ToLower(value), nil
+}
+
+type ContentAddressedOptions struct {
+	// Path is the path to the directory where the storage
+	// backend will store the document data.
+	Path string
+}
+
+// ContentAddressed is a storage backend that keys documents by their
+// content instead of the ID chosen by the SBOM producer. Documents are
+// written to a directory under the digest of their canonical form, so
+// storing the same document twice results in a single copy and a retrieved
+// document is guaranteed to match the digest requested.
+//
+// The Store method satisfies the Storer interface but discards the digest,
+// use StoreDigest to obtain it.
+type ContentAddressed struct {
+	Options ContentAddressedOptions
+}
+
+func NewContentAddressed() *ContentAddressed {
+	return &ContentAddressed{
+		Options: ContentAddressedOptions{},
+	}
+}
+
+// blobPath returns the path where the document with the hex digest is stored
+func (ca *ContentAddressed) blobPath(value string) string {
+	return filepath.Join(ca.Options.Path, digestAlgorithm, value+documentFileExtension)
+}
+
+// Store implements the Storer interface. It writes the document to the
+// store, keyed by its digest. Use StoreDigest to get the document digest.
+func (ca *ContentAddressed) Store(bom *sbom.Document, opts *StoreOptions) error {
+	_, err := ca.StoreDigest(bom, opts)
+	return err
+}
+
+// StoreDigest writes the document to the store and returns the digest it
+// is stored under. As the content determines the key, storing a document
+// which is already in the store is a no-op and the NoClobber option has
+// no effect.
+func (ca *ContentAddressed) StoreDigest(bom *sbom.Document, _ *StoreOptions) (string, error) {
+	if ca.Options.Path == "" {
+		return "", fmt.Errorf("unable to store document: content-addressed backend data dir not set")
+	}
+
+	if bom == nil {
+		return "", fmt.Errorf("unable to store document: document is nil")
+	}
+
+	digest, err := DocumentDigest(bom)
+	if err != nil {
+		return "", fmt.Errorf("computing document digest: %w", err)
+	}
+
+	path := ca.blobPath(strings.TrimPrefix(digest, digestAlgorithm+":"))
+	if helpers.Exists(path) {
+		return digest, nil
+	}
+
+	data, err := proto.Marshal(bom)
+	if err != nil {
+		return "", fmt.Errorf("marshalling protobom to binary form: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.FileMode(0o755)); err != nil {
+		return "", fmt.Errorf("creating content-addressed backend directory: %w", err)
+	}
+
+	// Write to a temporary file first so a partially written document
+	// is never found under its digest.
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return "", fmt.Errorf("creating temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck // Cleanup fails once the file is renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close() //nolint:errcheck,gosec // Already handling the write error
+		return "", fmt.Errorf("writing data to disk: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("closing temporary file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", fmt.Errorf("moving document into place: %w", err)
+	}
+
+	return digest, nil
+}
+
+// Retrieve implements the Retriever interface. The document is looked up
+// by its digest and its data is verified before returning it. If the data
+// does not match the digest, an error wrapping ErrDigestMismatch is returned.
+func (ca *ContentAddressed) Retrieve(digest string, _ *RetrieveOptions) (*sbom.Document, error) {
+	if ca.Options.Path == "" {
+		return nil, fmt.Errorf("unable to retrieve SBOM data: content-addressed backend data dir not set")
+	}
+
+	value, err := parseDigest(digest)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(ca.blobPath(value))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("document %q: %w", digest, ErrNotFound)
+		}
+		return nil, fmt.Errorf("reading document: %w", err)
+	}
+
+	bom := &sbom.Document{}
+	if err := proto.Unmarshal(data, bom); err != nil {
+		return nil, fmt.Errorf("unmarshalling proto: %w", err)
+	}
+
+	got, err := DocumentDigest(bom)
+	if err != nil {
+		return nil, fmt.Errorf("computing document digest: %w", err)
+	}
+	if got != digestAlgorithm+":"+value {
+		return nil, fmt.Errorf("document %q has digest %s: %w", digest, got, ErrDigestMismatch)
+	}
+	return bom, nil
+}
+
+// Delete implements the Deleter interface. It removes the document stored
+// under the digest.
+func (ca *ContentAddressed) Delete(digest string, _ *DeleteOptions) error {
+	if ca.Options.Path == "" {
+		return fmt.Errorf("unable to delete document: content-addressed backend data dir not set")
+	}
+
+	value, err := parseDigest(digest)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(ca.blobPath(value)); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("document %q: %w", digest, ErrNotFound)
+		}
+		return fmt.Errorf("deleting document: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+func TestContentAddressed(t *testing.T) {
+	t.Parallel()
+	ca := NewContentAddressed()
+	ca.Options.Path = t.TempDir()
+
+	doc := &sbom.Document{
+		Metadata: &sbom.Metadata{Id: "test-document", Name: "test"},
+		NodeList: &sbom.NodeList{
+			Nodes:        []*sbom.Node{{Id: "pkg", Name: "pkg", Version: "1.0"}},
+			RootElements: []string{"pkg"},
+		},
+	}
+
+	digest, err := ca.StoreDigest(doc, nil)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(digest, "protobom-v1+sha256:"))
+
+	expected, err := DocumentDigest(doc)
+	require.NoError(t, err)
+	require.Equal(t, expected, digest)
+
+	// Storing identical content dedupes, even with NoClobber
+	digest2, err := ca.StoreDigest(doc, &StoreOptions{NoClobber: true})
+	require.NoError(t, err)
+	require.Equal(t, digest, digest2)
+	entries, err := os.ReadDir(filepath.Join(ca.Options.Path, digestAlgorithm))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	// Same ID with different content gets a different key
+	changed := &sbom.Document{Metadata: &sbom.Metadata{Id: "test-document", Name: "changed"}}
+	digest3, err := ca.StoreDigest(changed, nil)
+	require.NoError(t, err)
+	require.NotEqual(t, digest, digest3)
+
+	retrieved, err := ca.Retrieve(digest, nil)
+	require.NoError(t, err)
+	require.Equal(t, "test", retrieved.GetMetadata().GetName())
+	require.Len(t, retrieved.GetNodeList().GetNodes(), 1)
+
+	_, err = ca.Retrieve(digestAlgorithm+":"+strings.Repeat("0", 64), nil)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	_, err = ca.Retrieve("../../etc/passwd", nil)
+	require.ErrorIs(t, err, ErrInvalidReference)
+
+	// Tampering with the stored data is detected
+	path := ca.blobPath(strings.TrimPrefix(digest, digestAlgorithm+":"))
+	require.NoError(t, os.WriteFile(path, []byte{}, os.FileMode(0o644)))
+	_, err = ca.Retrieve(digest, nil)
+	require.ErrorIs(t, err, ErrDigestMismatch)
+
+	require.NoError(t, ca.Delete(digest, nil))
+	require.ErrorIs(t, ca.Delete(digest, nil), ErrNotFound)
+}
+
+func TestDocumentDigest(t *testing.T) {
+	t.Parallel()
+	doc := &sbom.Document{
+		Metadata: &sbom.Metadata{Id: "doc", Name: "test"},
+		NodeList: &sbom.NodeList{
+			Nodes: []*sbom.Node{
+				{Id: "a", Name: "a", Hashes: map[int32]string{1: "aa", 3: "bb", 2: "cc"}},
+				{Id: "b", Name: "b"},
+				{Id: "c", Name: "c"},
+			},
+			Edges: []*sbom.Edge{
+				{Type: sbom.Edge_dependsOn, From: "a", To: []string{"b", "c"}},
+				{Type: sbom.Edge_contains, From: "a", To: []string{"c"}},
+			},
+			RootElements: []string{"a", "b"},
+		},
+	}
+	reordered := &sbom.Document{
+		Metadata: &sbom.Metadata{
+			Id: "doc", Name: "test",
+			// Source data describes how the document was read
+			SourceData: &sbom.SourceData{Format: "application/spdx+json", Size: 1234},
+		},
+		NodeList: &sbom.NodeList{
+			Nodes: []*sbom.Node{
+				{Id: "c", Name: "c"},
+				{Id: "a", Name: "a", Hashes: map[int32]string{2: "cc", 1: "aa", 3: "bb"}},
+				{Id: "b", Name: "b"},
+			},
+			Edges: []*sbom.Edge{
+				{Type: sbom.Edge_contains, From: "a", To: []string{"c"}},
+				{Type: sbom.Edge_dependsOn, From: "a", To: []string{"c"}},
+				{Type: sbom.Edge_dependsOn, From: "a", To: []string{"b", "c"}},
+			},
+			RootElements: []string{"b", "a"},
+		},
+	}
+
+	d1, err := DocumentDigest(doc)
+	require.NoError(t, err)
+	d2, err := DocumentDigest(reordered)
+	require.NoError(t, err)
+	require.Equal(t, d1, d2)
+
+	// Computing the digest does not modify the document
+	require.Equal(t, "c", reordered.GetNodeList().GetNodes()[0].GetId())
+	require.NotNil(t, reordered.GetMetadata().GetSourceData())
+
+	// A change in the contents changes the digest
+	reordered.NodeList.Edges[0].To = []string{"b"}
+	d3, err := DocumentDigest(reordered)
+	require.NoError(t, err)
+	require.NotEqual(t, d1, d3)
+
+	_, err = DocumentDigest(nil)
+	require.Error(t, err)
+
+	// Digests must not change between versions of protobom or its
+	// dependencies, a change in the canonical form needs a new scheme.
+	pinned, err := DocumentDigest(&sbom.Document{
+		Metadata: &sbom.Metadata{Id: "doc"},
+		NodeList: &sbom.NodeList{
+			Nodes:        []*sbom.Node{{Id: "a", Name: "a", Hashes: map[int32]string{3: "bb"}}},
+			RootElements: []string{"a"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "protobom-v1+sha256:087e696f17696a3a4773b3a7b73fc9f049c7b521f1b8e5b8525dd5a976bdf5c3", pinned)
+
+	// The canonical encoding is valid protobuf
+	canonical := canonicalDocument(doc)
+	decoded := &sbom.Document{}
+	require.NoError(t, proto.Unmarshal(canonicalMarshal(canonical.ProtoReflect()), decoded))
+	require.True(t, proto.Equal(canonical, decoded))
+}
@@ -6,6 +6,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"google.golang.org/protobuf/proto"
@@ -14,24 +18,52 @@ import (
 	"github.com/protobom/protobom/pkg/sbom"
 )
 
-var _ StoreRetriever = (*FileSystem)(nil)
+var (
+	_ StoreRetriever = (*FileSystem)(nil)
+	_ Lister         = (*FileSystem)(nil)
+	_ Deleter        = (*FileSystem)(nil)
+	_ Searcher       = (*FileSystem)(nil)
+	_ RevisionLister = (*FileSystem)(nil)
+)
+
+const (
+	documentFileExtension = ".protobom"
+	metadataFileExtension = ".metadata"
+	revisionsDirExtension = ".revisions"
+)
 
 type FileSystemOptions struct {
 	// Path is the path top the directory where the storage
 	// backend will store the document data.
 	Path string
+
+	// Revisions enables the document history. When true, storing a
+	// document with an existing ID appends a new revision instead of
+	// replacing it. Previous revisions can be retrieved by setting a
+	// revision selector in the RetrieveOptions.
+	Revisions bool
 }
 
 // FileSystem is the default persistence drive of protobom. It is a simple
 // implementation that writes protobom data to a directory. It is keyed by
 // filename.
+//
+// Next to each document, the backend writes a small file with a copy of the
+// document metadata. These files make it possible to list and search the
+// stored documents without reading all of them.
+//
+// When revisions are enabled, each stored document is also written to a
+// directory holding its history. The latest revision is always kept at the
+// top level so listing and retrieving work the same with or without them.
 type FileSystem struct {
 	Options FileSystemOptions
+	now     func() time.Time
 }
 
 func NewFileSystem() *FileSystem {
 	return &FileSystem{
 		Options: FileSystemOptions{},
+		now:     time.Now,
 	}
 }
 
@@ -39,7 +71,42 @@ func generateDocFileName(documentId string) (string, error) {
 	if documentId == "" {
 		return "", fmt.Errorf("unable to generate filename, document ID not set")
 	}
-	return fmt.Sprintf("%x.protobom", sha256.Sum256([]byte(documentId))), nil
+	return fmt.Sprintf("%x%s", sha256.Sum256([]byte(documentId)), documentFileExtension), nil
+}
+
+// revisionsDirName returns the name of the directory that holds the history
+// of the document stored in docFileName.
+func revisionsDirName(docFileName string) string {
+	return strings.TrimSuffix(docFileName, documentFileExtension) + revisionsDirExtension
+}
+
+// revisionFileName returns the name of the file of a revision. The date is
+// encoded in the filename to avoid depending on file modification times.
+func revisionFileName(r *Revision) string {
+	return fmt.Sprintf("%08d-%d%s", r.Index, r.Date.UnixNano(), documentFileExtension)
+}
+
+// parseRevisionFileName returns the revision encoded in a revision filename
+func parseRevisionFileName(name string) (*Revision, error) {
+	idx, nanos, ok := strings.Cut(strings.TrimSuffix(name, documentFileExtension), "-")
+	if !ok {
+		return nil, fmt.Errorf("invalid revision filename %q", name)
+	}
+	i, err := strconv.Atoi(idx)
+	if err != nil {
+		return nil, fmt.Errorf("parsing revision index: %w", err)
+	}
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parsing revision date: %w", err)
+	}
+	return &Revision{Index: i, Date: time.Unix(0, n).UTC()}, nil
+}
+
+// metadataFileName returns the name of the metadata file that accompanies
+// a document file.
+func metadataFileName(docFileName string) string {
+	return strings.TrimSuffix(docFileName, documentFileExtension) + metadataFileExtension
 }
 
 // Store implements the backend driver Store method. It stores a marshalled protobom
@@ -79,25 +146,158 @@ func (fs *FileSystem) Store(bom *sbom.Document, opts *StoreOptions) error {
 		return err
 	}
 
-	if opts.NoClobber && helpers.Exists(filepath.Join(fs.Options.Path, filename)) {
+	if opts.NoClobber && !fs.Options.Revisions && helpers.Exists(filepath.Join(fs.Options.Path, filename)) {
 		return fmt.Errorf("there is already an entry for the specified document (and NoClobber = true)")
 	}
 
+	if fs.Options.Revisions {
+		if err := fs.storeRevision(filename, out); err != nil {
+			return fmt.Errorf("storing document revision: %w", err)
+		}
+	}
+
 	if err := os.WriteFile(filepath.Join(fs.Options.Path, filename), out, os.FileMode(0o644)); err != nil {
 		return fmt.Errorf("writing data to disk: %w", err)
 	}
 
+	md, err := proto.Marshal(bom.Metadata)
+	if err != nil {
+		return fmt.Errorf("marshalling document metadata: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(fs.Options.Path, metadataFileName(filename)), md, os.FileMode(0o644)); err != nil {
+		return fmt.Errorf("writing metadata to disk: %w", err)
+	}
+
 	return nil
 }
 
+// storeRevision appends the marshalled document to its history
+func (fs *FileSystem) storeRevision(docFileName string, data []byte) error {
+	dir := filepath.Join(fs.Options.Path, revisionsDirName(docFileName))
+	if err := os.MkdirAll(dir, os.FileMode(0o755)); err != nil {
+		return fmt.Errorf("creating revisions directory: %w", err)
+	}
+
+	history, err := fs.readRevisions(docFileName)
+	if err != nil {
+		return err
+	}
+
+	// Documents stored before revisions were enabled have no history yet,
+	// record the existing document as their first revision.
+	if len(history) == 0 {
+		first, err := fs.seedRevision(docFileName)
+		if err != nil {
+			return err
+		}
+		if first != nil {
+			history = append(history, first)
+		}
+	}
+
+	now := time.Now
+	if fs.now != nil {
+		now = fs.now
+	}
+
+	r := &Revision{Index: len(history), Date: now().UTC()}
+	if len(history) > 0 {
+		r.Index = history[len(history)-1].Index + 1
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, revisionFileName(r)), data, os.FileMode(0o644)); err != nil {
+		return fmt.Errorf("writing revision to disk: %w", err)
+	}
+	return nil
+}
+
+// seedRevision copies the document stored in docFileName, if any, to its
+// history as the first revision, dated by the file modification time.
+func (fs *FileSystem) seedRevision(docFileName string) (*Revision, error) {
+	path := filepath.Join(fs.Options.Path, docFileName)
+	info, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil //nolint:nilnil // No document to seed the history
+		}
+		return nil, fmt.Errorf("checking existing document: %w", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading existing document: %w", err)
+	}
+
+	r := &Revision{Index: 0, Date: info.ModTime().UTC()}
+	dir := filepath.Join(fs.Options.Path, revisionsDirName(docFileName))
+	if err := os.WriteFile(filepath.Join(dir, revisionFileName(r)), data, os.FileMode(0o644)); err != nil {
+		return nil, fmt.Errorf("writing first revision to disk: %w", err)
+	}
+	return r, nil
+}
+
+// readRevisions returns the history of the document stored in docFileName
+// sorted by index. If the document has no history, the list is empty.
+func (fs *FileSystem) readRevisions(docFileName string) ([]*Revision, error) {
+	entries, err := os.ReadDir(filepath.Join(fs.Options.Path, revisionsDirName(docFileName)))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []*Revision{}, nil
+		}
+		return nil, fmt.Errorf("reading revisions directory: %w", err)
+	}
+
+	ret := []*Revision{}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), documentFileExtension) {
+			continue
+		}
+		r, err := parseRevisionFileName(e.Name())
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, r)
+	}
+
+	slices.SortFunc(ret, func(a, b *Revision) int {
+		return a.Index - b.Index
+	})
+	return ret, nil
+}
+
+// Revisions implements the RevisionLister interface. It returns the history
+// of the document, sorted from the first to the latest revision.
+func (fs *FileSystem) Revisions(id string) ([]*Revision, error) {
+	if fs.Options.Path == "" {
+		return nil, fmt.Errorf("unable to list revisions: filesystem backend data dir not set")
+	}
+
+	filename, err := generateDocFileName(id)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := fs.readRevisions(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(history) == 0 && !helpers.Exists(filepath.Join(fs.Options.Path, filename)) {
+		return nil, fmt.Errorf("document %q: %w", id, ErrNotFound)
+	}
+	return history, nil
+}
+
 // Retrieve implements the storage backend Retrieve interface. It looks for a
-// protobom document in a directory
-func (fs *FileSystem) Retrieve(id string, _ *RetrieveOptions) (*sbom.Document, error) {
+// protobom document in a directory. If the options define a revision
+// selector, the document is read from the history.
+func (fs *FileSystem) Retrieve(id string, opts *RetrieveOptions) (*sbom.Document, error) {
 	if fs.Options.Path == "" {
 		return nil, fmt.Errorf("unable to retrieve SBOM data: filesystem backend data dir not set")
 	}
 	if id == "" {
-		return nil, fmt.Errorf("unable to retrieve SBOM data: no identifier defined")
+		return nil, fmt.Errorf("unable to retrieve SBOM data: no identifier defined: %w", ErrInvalidReference)
 	}
 
 	filename, err := generateDocFileName(id)
@@ -105,14 +305,141 @@ func (fs *FileSystem) Retrieve(id string, _ *RetrieveOptions) (*sbom.Document, e
 		return nil, err
 	}
 
-	data, err := os.ReadFile(filepath.Join(fs.Options.Path, filename))
+	path := filepath.Join(fs.Options.Path, filename)
+	if opts != nil && opts.Revision != nil && !opts.Revision.isLatest() {
+		history, err := fs.readRevisions(filename)
+		if err != nil {
+			return nil, err
+		}
+
+		r := selectRevision(history, opts.Revision)
+		if r == nil {
+			return nil, fmt.Errorf("revision of document %q: %w", id, ErrNotFound)
+		}
+		path = filepath.Join(fs.Options.Path, revisionsDirName(filename), revisionFileName(r))
+	}
+
+	bom, err := fs.readDocument(path)
 	if err != nil {
-		logrus.Fatal(fmt.Errorf("reading protobom data from disk: %w", err))
+		return nil, fmt.Errorf("document %q: %w", id, err)
+	}
+	return bom, nil
+}
+
+// readDocument reads and unmarshals a protobom document from a file
+func (fs *FileSystem) readDocument(path string) (*sbom.Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("reading protobom data from disk: %w", err)
 	}
 	bom := &sbom.Document{}
 	if err := proto.Unmarshal(data, bom); err != nil {
-		logrus.Fatal(fmt.Errorf("unmarshaling protobom data: %w", err))
+		return nil, fmt.Errorf("unmarshaling protobom data: %w", err)
 	}
 
 	return bom, nil
 }
+
+// readMetadata returns the metadata of the document stored in docFileName.
+// It reads the metadata file written along the document. If not found, the
+// metadata is read from the document itself.
+func (fs *FileSystem) readMetadata(docFileName string) (*sbom.Metadata, error) {
+	data, err := os.ReadFile(filepath.Join(fs.Options.Path, metadataFileName(docFileName)))
+	switch {
+	case err == nil:
+		md := &sbom.Metadata{}
+		if err := proto.Unmarshal(data, md); err != nil {
+			return nil, fmt.Errorf("unmarshaling document metadata: %w", err)
+		}
+		return md, nil
+	case errors.Is(err, os.ErrNotExist):
+		logrus.Debugf("metadata file for %s not found, reading document", docFileName)
+		doc, err := fs.readDocument(filepath.Join(fs.Options.Path, docFileName))
+		if err != nil {
+			return nil, err
+		}
+		return doc.GetMetadata(), nil
+	default:
+		return nil, fmt.Errorf("reading document metadata: %w", err)
+	}
+}
+
+// List implements the Lister interface. It returns the metadata of all the
+// documents stored in the data directory sorted by their ID.
+func (fs *FileSystem) List(_ *ListOptions) ([]*sbom.Metadata, error) {
+	if fs.Options.Path == "" {
+		return nil, fmt.Errorf("unable to list documents: filesystem backend data dir not set")
+	}
+
+	entries, err := os.ReadDir(fs.Options.Path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []*sbom.Metadata{}, nil
+		}
+		return nil, fmt.Errorf("reading filesystem backend directory: %w", err)
+	}
+
+	ret := []*sbom.Metadata{}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), documentFileExtension) {
+			continue
+		}
+
+		md, err := fs.readMetadata(e.Name())
+		if err != nil {
+			return nil, fmt.Errorf("reading metadata of %s: %w", e.Name(), err)
+		}
+		if md == nil {
+			continue
+		}
+		ret = append(ret, md)
+	}
+
+	slices.SortFunc(ret, func(a, b *sbom.Metadata) int {
+		return strings.Compare(a.GetId(), b.GetId())
+	})
+	return ret, nil
+}
+
+// Search implements the Searcher interface. It returns the metadata of the
+// stored documents matching the filter, sorted by their ID.
+func (fs *FileSystem) Search(filter *SearchFilter, _ *SearchOptions) ([]*sbom.Metadata, error) {
+	list, err := fs.List(nil)
+	if err != nil {
+		return nil, err
+	}
+	return filterMetadata(list, filter), nil
+}
+
+// Delete implements the Deleter interface. It removes the document and its
+// metadata from the data directory.
+func (fs *FileSystem) Delete(id string, _ *DeleteOptions) error {
+	if fs.Options.Path == "" {
+		return fmt.Errorf("unable to delete document: filesystem backend data dir not set")
+	}
+
+	filename, err := generateDocFileName(id)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(filepath.Join(fs.Options.Path, filename)); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("document %q: %w", id, ErrNotFound)
+		}
+		return fmt.Errorf("deleting document: %w", err)
+	}
+
+	if err := os.Remove(filepath.Join(fs.Options.Path, metadataFileName(filename))); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("deleting document metadata: %w", err)
+	}
+
+	if err := os.RemoveAll(filepath.Join(fs.Options.Path, revisionsDirName(filename))); err != nil {
+		return fmt.Errorf("deleting document revisions: %w", err)
+	}
+
+	return nil
+}
@@ -1,11 +1,15 @@
 package storage
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/protobom/protobom/pkg/formats"
 	"github.com/protobom/protobom/pkg/sbom"
 )
 
@@ -48,3 +52,161 @@ func TestFileSystem(t *testing.T) {
 		})
 	}
 }
+
+func TestFileSystemListSearchDelete(t *testing.T) {
+	t.Parallel()
+	fs := NewFileSystem()
+	fs.Options.Path = t.TempDir()
+
+	docs := []*sbom.Document{
+		{Metadata: &sbom.Metadata{
+			Id: "doc-b", Name: "Second SBOM",
+			Date:       timestamppb.New(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)),
+			Tools:      []*sbom.Tool{{Name: "syft"}},
+			SourceData: &sbom.SourceData{Format: string(formats.CDX15JSON)},
+		}},
+		{Metadata: &sbom.Metadata{
+			Id: "doc-a", Name: "First SBOM",
+			Date:       timestamppb.New(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+			Tools:      []*sbom.Tool{{Name: "bom"}},
+			SourceData: &sbom.SourceData{Format: string(formats.SPDX23JSON)},
+		}},
+	}
+	for _, d := range docs {
+		require.NoError(t, fs.Store(d, nil))
+	}
+
+	list, err := fs.List(nil)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, "doc-a", list[0].Id)
+	require.Equal(t, "doc-b", list[1].Id)
+
+	// Documents stored without the metadata file are still listed
+	filename, err := generateDocFileName("doc-a")
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(filepath.Join(fs.Options.Path, metadataFileName(filename))))
+	list, err = fs.List(nil)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+
+	res, err := fs.Search(&SearchFilter{Tool: "SYFT"}, nil)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	require.Equal(t, "doc-b", res[0].Id)
+
+	res, err = fs.Search(&SearchFilter{Format: formats.SPDX23JSON}, nil)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	require.Equal(t, "doc-a", res[0].Id)
+
+	require.NoError(t, fs.Delete("doc-b", nil))
+	require.ErrorIs(t, fs.Delete("doc-b", nil), ErrNotFound)
+	_, err = fs.Retrieve("doc-b", nil)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	list, err = fs.List(nil)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+}
+
+func TestFileSystemRevisions(t *testing.T) {
+	t.Parallel()
+	fs := NewFileSystem()
+	fs.Options.Path = t.TempDir()
+	fs.Options.Revisions = true
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := start
+	fs.now = func() time.Time { return clock }
+
+	for i, version := range []string{"1.0", "1.1", "2.0"} {
+		clock = start.Add(time.Duration(i) * time.Hour)
+		require.NoError(t, fs.Store(&sbom.Document{
+			Metadata: &sbom.Metadata{Id: "product", Version: version},
+		}, &StoreOptions{NoClobber: true}))
+	}
+
+	history, err := fs.Revisions("product")
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	for i, r := range history {
+		require.Equal(t, i, r.Index)
+		require.True(t, start.Add(time.Duration(i)*time.Hour).Equal(r.Date))
+	}
+
+	for _, tc := range []struct {
+		name     string
+		sel      *RevisionSelector
+		expected string
+		mustErr  bool
+	}{
+		{"no selector", nil, "2.0", false},
+		{"zero value", &RevisionSelector{}, "2.0", false},
+		{"latest", LatestRevision(), "2.0", false},
+		{"first", RevisionByIndex(0), "1.0", false},
+		{"negative index", RevisionByIndex(-2), "1.1", false},
+		{"index out of range", RevisionByIndex(3), "", true},
+		{"at time", RevisionAt(start.Add(90 * time.Minute)), "1.1", false},
+		{"exact time", RevisionAt(start.Add(2 * time.Hour)), "2.0", false},
+		{"before history", RevisionAt(start.Add(-time.Hour)), "", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			doc, err := fs.Retrieve("product", &RetrieveOptions{Revision: tc.sel})
+			if tc.mustErr {
+				require.ErrorIs(t, err, ErrNotFound)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, doc.GetMetadata().GetVersion())
+		})
+	}
+
+	// Listing only returns the latest revision
+	list, err := fs.List(nil)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, "2.0", list[0].GetVersion())
+}
+
+func TestFileSystemRevisionsSeed(t *testing.T) {
+	t.Parallel()
+	fs := NewFileSystem()
+	fs.Options.Path = t.TempDir()
+
+	// A document stored before enabling revisions becomes the first one
+	require.NoError(t, fs.Store(&sbom.Document{Metadata: &sbom.Metadata{Id: "product", Version: "1.0"}}, nil))
+	fs.Options.Revisions = true
+	require.NoError(t, fs.Store(&sbom.Document{Metadata: &sbom.Metadata{Id: "product", Version: "2.0"}}, nil))
+
+	history, err := fs.Revisions("product")
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, []int{0, 1}, []int{history[0].Index, history[1].Index})
+
+	doc, err := fs.Retrieve("product", &RetrieveOptions{Revision: RevisionByIndex(0)})
+	require.NoError(t, err)
+	require.Equal(t, "1.0", doc.GetMetadata().GetVersion())
+
+	doc, err = fs.Retrieve("product", nil)
+	require.NoError(t, err)
+	require.Equal(t, "2.0", doc.GetMetadata().GetVersion())
+}
+
+func TestFileSystemRevisionsDelete(t *testing.T) {
+	t.Parallel()
+	fs := NewFileSystem()
+	fs.Options.Path = t.TempDir()
+	fs.Options.Revisions = true
+
+	doc := &sbom.Document{Metadata: &sbom.Metadata{Id: "product"}}
+	require.NoError(t, fs.Store(doc, nil))
+	require.NoError(t, fs.Store(doc, nil))
+	require.NoError(t, fs.Delete("product", nil))
+
+	_, err := fs.Revisions("product")
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = fs.Retrieve("product", &RetrieveOptions{Revision: RevisionByIndex(0)})
+	require.ErrorIs(t, err, ErrNotFound)
+}
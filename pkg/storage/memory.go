@@ -0,0 +1,134 @@
+package storage
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+var (
+	_ StoreRetriever = (*Memory)(nil)
+	_ Lister         = (*Memory)(nil)
+	_ Deleter        = (*Memory)(nil)
+	_ Searcher       = (*Memory)(nil)
+)
+
+// Memory is a storage backend that keeps the documents in memory. It is safe
+// for concurrent use and is useful for tests and short lived caches.
+//
+// Documents are deep copied when stored and when retrieved, so callers can
+// modify the documents they pass or receive without affecting the copies
+// held by the backend.
+type Memory struct {
+	mtx       sync.RWMutex
+	documents map[string]*sbom.Document
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		documents: map[string]*sbom.Document{},
+	}
+}
+
+// Store implements the Storer interface. It saves a copy of the document
+// keyed by its ID.
+func (m *Memory) Store(bom *sbom.Document, opts *StoreOptions) error {
+	if opts == nil {
+		opts = &StoreOptions{}
+	}
+
+	if bom.GetMetadata().GetId() == "" {
+		return fmt.Errorf("unable to persist document: no document id set")
+	}
+
+	doc, ok := proto.Clone(bom).(*sbom.Document)
+	if !ok {
+		return fmt.Errorf("unable to copy document")
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.documents == nil {
+		m.documents = map[string]*sbom.Document{}
+	}
+
+	if _, ok := m.documents[doc.Metadata.Id]; ok && opts.NoClobber {
+		return fmt.Errorf("there is already an entry for the specified document (and NoClobber = true)")
+	}
+
+	m.documents[doc.Metadata.Id] = doc
+	return nil
+}
+
+// Retrieve implements the Retriever interface. It returns a copy of the
+// stored document. The memory backend does not keep a history, selecting a
+// revision other than the latest is an error.
+func (m *Memory) Retrieve(id string, opts *RetrieveOptions) (*sbom.Document, error) {
+	if opts != nil && !opts.Revision.isLatest() {
+		return nil, fmt.Errorf("unable to retrieve SBOM data: the memory backend does not keep document revisions")
+	}
+
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	doc, ok := m.documents[id]
+	if !ok {
+		return nil, fmt.Errorf("document %q: %w", id, ErrNotFound)
+	}
+
+	ret, ok := proto.Clone(doc).(*sbom.Document)
+	if !ok {
+		return nil, fmt.Errorf("unable to copy document")
+	}
+	return ret, nil
+}
+
+// List implements the Lister interface. It returns a copy of the metadata
+// of the stored documents sorted by ID.
+func (m *Memory) List(_ *ListOptions) ([]*sbom.Metadata, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	ret := make([]*sbom.Metadata, 0, len(m.documents))
+	for _, doc := range m.documents {
+		md, ok := proto.Clone(doc.GetMetadata()).(*sbom.Metadata)
+		if !ok {
+			return nil, fmt.Errorf("unable to copy document metadata")
+		}
+		ret = append(ret, md)
+	}
+
+	slices.SortFunc(ret, func(a, b *sbom.Metadata) int {
+		return strings.Compare(a.GetId(), b.GetId())
+	})
+	return ret, nil
+}
+
+// Search implements the Searcher interface. It returns the metadata of the
+// documents matching the filter.
+func (m *Memory) Search(filter *SearchFilter, _ *SearchOptions) ([]*sbom.Metadata, error) {
+	list, err := m.List(nil)
+	if err != nil {
+		return nil, err
+	}
+	return filterMetadata(list, filter), nil
+}
+
+// Delete implements the Deleter interface. It removes the document from
+// memory.
+func (m *Memory) Delete(id string, _ *DeleteOptions) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if _, ok := m.documents[id]; !ok {
+		return fmt.Errorf("document %q: %w", id, ErrNotFound)
+	}
+	delete(m.documents, id)
+	return nil
+}
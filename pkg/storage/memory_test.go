@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+func TestMemory(t *testing.T) {
+	t.Parallel()
+	m := NewMemory()
+
+	doc := &sbom.Document{
+		Metadata: &sbom.Metadata{Id: "doc-b", Name: "original"},
+		NodeList: &sbom.NodeList{Nodes: []*sbom.Node{{Id: "pkg"}}},
+	}
+	require.NoError(t, m.Store(doc, nil))
+	require.NoError(t, m.Store(&sbom.Document{Metadata: &sbom.Metadata{Id: "doc-a"}}, nil))
+	require.Error(t, m.Store(&sbom.Document{}, nil))
+
+	// Changes to the stored document do not affect the copy in memory
+	doc.Metadata.Name = "changed"
+	doc.NodeList.Nodes[0].Name = "changed"
+
+	retrieved, err := m.Retrieve("doc-b", nil)
+	require.NoError(t, err)
+	require.Equal(t, "original", retrieved.GetMetadata().GetName())
+	require.Empty(t, retrieved.GetNodeList().GetNodes()[0].GetName())
+
+	// Nor do changes to retrieved documents
+	retrieved.Metadata.Name = "changed"
+	retrieved, err = m.Retrieve("doc-b", nil)
+	require.NoError(t, err)
+	require.Equal(t, "original", retrieved.GetMetadata().GetName())
+
+	require.Error(t, m.Store(doc, &StoreOptions{NoClobber: true}))
+	require.NoError(t, m.Store(doc, nil))
+	retrieved, err = m.Retrieve("doc-b", nil)
+	require.NoError(t, err)
+	require.Equal(t, "changed", retrieved.GetMetadata().GetName())
+
+	// The latest revision is the only one available
+	_, err = m.Retrieve("doc-b", &RetrieveOptions{Revision: LatestRevision()})
+	require.NoError(t, err)
+	_, err = m.Retrieve("doc-b", &RetrieveOptions{Revision: RevisionByIndex(0)})
+	require.Error(t, err)
+	_, err = m.Retrieve("doc-b", &RetrieveOptions{Revision: RevisionAt(time.Now())})
+	require.Error(t, err)
+
+	list, err := m.List(nil)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, "doc-a", list[0].GetId())
+
+	res, err := m.Search(&SearchFilter{Name: "CHANGED"}, nil)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+
+	require.NoError(t, m.Delete("doc-b", nil))
+	require.ErrorIs(t, m.Delete("doc-b", nil), ErrNotFound)
+	_, err = m.Retrieve("doc-b", nil)
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestMemoryConcurrency(t *testing.T) {
+	t.Parallel()
+	m := NewMemory()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 150)
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id := fmt.Sprintf("doc-%d", i%10)
+			errs <- m.Store(&sbom.Document{Metadata: &sbom.Metadata{Id: id}}, nil)
+			_, err := m.Retrieve(id, nil)
+			errs <- err
+			_, err = m.List(nil)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	list, err := m.List(nil)
+	require.NoError(t, err)
+	require.Len(t, list, 10)
+}
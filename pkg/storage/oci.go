@@ -0,0 +1,499 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2026 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/protobom/protobom/pkg/formats"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+var _ StoreRetriever = (*OCILayout)(nil)
+
+const (
+	// MediaTypeProtobom is the media type of the protobom documents stored
+	// in OCI artifacts. It is used as the artifact type and the media type
+	// of the layer holding the document.
+	MediaTypeProtobom = "application/vnd.protobom.document.v1+protobuf"
+
+	// AnnotationDocumentID is the annotation that records the ID of the
+	// SBOM stored in an artifact. It is set in the manifest and in its
+	// descriptor in the layout index.
+	AnnotationDocumentID = "dev.protobom.document.id"
+
+	// AnnotationDocumentFormat is the annotation that records the format
+	// of the document stored in an artifact, including its version. It is
+	// set in the descriptor of the manifest in the layout index.
+	AnnotationDocumentFormat = "dev.protobom.document.format"
+)
+
+// OCIStoreOptions are the backend options understood by the OCI layout
+// backend. Pass them in StoreOptions.BackendOptions.
+type OCIStoreOptions struct {
+	// Subject is the descriptor of the artifact described by the SBOM,
+	// usually a container image. When set, the stored artifact is attached
+	// to it as a referrer.
+	Subject *ocispec.Descriptor
+
+	// Annotations are added to the manifest of the stored artifact
+	Annotations map[string]string
+}
+
+type OCILayoutOptions struct {
+	// Path is the path to the directory of the OCI image layout.
+	Path string
+}
+
+// OCILayout is a storage backend that reads and writes SBOMs as artifacts
+// in an OCI image layout on disk. Protobom documents are stored with the
+// MediaTypeProtobom artifact type, rendered documents in native formats
+// can be stored too with StoreNative using the media types of their format.
+//
+// Artifacts are indexed by the ID of the SBOM and their format, including its
+// version, so a document can be stored once as protobom and once in each
+// native format and version.
+// They can also be looked up by the digest of their manifest.
+type OCILayout struct {
+	Options OCILayoutOptions
+	mtx     sync.Mutex
+}
+
+func NewOCILayout() *OCILayout {
+	return &OCILayout{
+		Options: OCILayoutOptions{},
+	}
+}
+
+// artifactTypeFromFormat returns the artifact type used for documents in
+// the specified format. It is the format media type without parameters.
+func artifactTypeFromFormat(format formats.Format) string {
+	t, _, _ := strings.Cut(string(format), ";")
+	return strings.TrimSpace(t)
+}
+
+// init creates the layout directory structure if it does not exist
+func (o *OCILayout) init() error {
+	if o.Options.Path == "" {
+		return fmt.Errorf("OCI layout backend path not set")
+	}
+
+	if err := os.MkdirAll(filepath.Join(o.Options.Path, ocispec.ImageBlobsDir, digest.SHA256.String()), os.FileMode(0o755)); err != nil {
+		return fmt.Errorf("creating OCI layout directory: %w", err)
+	}
+
+	layoutFile := filepath.Join(o.Options.Path, ocispec.ImageLayoutFile)
+	if _, err := os.Stat(layoutFile); err == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(ocispec.ImageLayout{Version: ocispec.ImageLayoutVersion})
+	if err != nil {
+		return fmt.Errorf("marshalling image layout: %w", err)
+	}
+	if err := os.WriteFile(layoutFile, data, os.FileMode(0o644)); err != nil {
+		return fmt.Errorf("writing image layout file: %w", err)
+	}
+	return nil
+}
+
+// blobPath returns the path to a blob in the layout
+func (o *OCILayout) blobPath(d digest.Digest) string {
+	return filepath.Join(o.Options.Path, ocispec.ImageBlobsDir, d.Algorithm().String(), d.Encoded())
+}
+
+// writeBlob writes data to the layout blobs and returns its descriptor
+func (o *OCILayout) writeBlob(mediaType string, data []byte) (ocispec.Descriptor, error) {
+	desc := ocispec.Descriptor{
+		MediaType: mediaType,
+		Digest:    digest.FromBytes(data),
+		Size:      int64(len(data)),
+	}
+
+	path := o.blobPath(desc.Digest)
+	if _, err := os.Stat(path); err == nil {
+		return desc, nil
+	}
+
+	if err := os.WriteFile(path, data, os.FileMode(0o644)); err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("writing blob: %w", err)
+	}
+	return desc, nil
+}
+
+// readBlob reads the blob of a descriptor, verifying its size and digest
+func (o *OCILayout) readBlob(desc ocispec.Descriptor) ([]byte, error) {
+	if err := desc.Digest.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid blob digest: %w", err)
+	}
+
+	data, err := os.ReadFile(o.blobPath(desc.Digest))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("blob %s: %w", desc.Digest, ErrNotFound)
+		}
+		return nil, fmt.Errorf("reading blob: %w", err)
+	}
+
+	if int64(len(data)) != desc.Size {
+		return nil, fmt.Errorf("blob %s size mismatch: expected %d got %d", desc.Digest, desc.Size, len(data))
+	}
+
+	verifier := desc.Digest.Verifier()
+	if _, err := verifier.Write(data); err != nil {
+		return nil, fmt.Errorf("verifying blob: %w", err)
+	}
+	if !verifier.Verified() {
+		return nil, fmt.Errorf("blob %s: %w", desc.Digest, ErrDigestMismatch)
+	}
+	return data, nil
+}
+
+// readIndex reads the layout index. If the layout has no index, an empty
+// one is returned.
+func (o *OCILayout) readIndex() (*ocispec.Index, error) {
+	data, err := os.ReadFile(filepath.Join(o.Options.Path, ocispec.ImageIndexFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &ocispec.Index{
+				Versioned: specs.Versioned{SchemaVersion: 2},
+				MediaType: ocispec.MediaTypeImageIndex,
+				Manifests: []ocispec.Descriptor{},
+			}, nil
+		}
+		return nil, fmt.Errorf("reading layout index: %w", err)
+	}
+
+	index := &ocispec.Index{}
+	if err := json.Unmarshal(data, index); err != nil {
+		return nil, fmt.Errorf("parsing layout index: %w", err)
+	}
+	return index, nil
+}
+
+// writeIndex writes the layout index to disk
+func (o *OCILayout) writeIndex(index *ocispec.Index) error {
+	data, err := json.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("marshalling layout index: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(o.Options.Path, ocispec.ImageIndexFile), data, os.FileMode(0o644)); err != nil {
+		return fmt.Errorf("writing layout index: %w", err)
+	}
+	return nil
+}
+
+// findManifest looks in the index for the manifest of an artifact. The
+// reference can be the digest of the manifest or the ID of the document.
+// When looking up by ID, the format must match too.
+func findManifest(index *ocispec.Index, ref, format string) (ocispec.Descriptor, bool) {
+	if d, err := digest.Parse(ref); err == nil {
+		for _, desc := range index.Manifests {
+			if desc.Digest == d {
+				return desc, true
+			}
+		}
+		return ocispec.Descriptor{}, false
+	}
+
+	for _, desc := range index.Manifests {
+		if isArtifact(desc, ref, format) {
+			return desc, true
+		}
+	}
+	return ocispec.Descriptor{}, false
+}
+
+// checkReference returns an error wrapping ErrInvalidReference when the
+// reference is empty or looks like a digest but cannot be parsed as one.
+func checkReference(ref string) error {
+	if ref == "" {
+		return fmt.Errorf("no reference defined: %w", ErrInvalidReference)
+	}
+	algo, _, ok := strings.Cut(ref, ":")
+	if !ok || !digest.Algorithm(algo).Available() {
+		return nil
+	}
+	if _, err := digest.Parse(ref); err != nil {
+		return fmt.Errorf("digest %q: %w: %w", ref, ErrInvalidReference, err)
+	}
+	return nil
+}
+
+// isArtifact returns true if the index descriptor is the artifact of the
+// document id in the format.
+func isArtifact(desc ocispec.Descriptor, id, format string) bool {
+	return desc.Annotations[AnnotationDocumentID] == id && desc.Annotations[AnnotationDocumentFormat] == format
+}
+
+// storeArtifact writes the data as the single layer of a new artifact and
+// records its manifest in the layout index. The format is the media type of
+// the layer, the artifact type is derived from it. It returns the descriptor
+// of the manifest.
+func (o *OCILayout) storeArtifact(id string, data []byte, format string, opts *StoreOptions) (ocispec.Descriptor, error) {
+	if opts == nil {
+		opts = &StoreOptions{}
+	}
+
+	if id == "" {
+		return ocispec.Descriptor{}, fmt.Errorf("unable to persist document: no document id set")
+	}
+
+	ociOpts := &OCIStoreOptions{}
+	if opts.BackendOptions != nil {
+		var ok bool
+		ociOpts, ok = opts.BackendOptions.(*OCIStoreOptions)
+		if !ok {
+			return ocispec.Descriptor{}, fmt.Errorf("backend options are not OCIStoreOptions")
+		}
+	}
+
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+
+	if err := o.init(); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+
+	index, err := o.readIndex()
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+
+	if _, ok := findManifest(index, id, format); ok && opts.NoClobber {
+		return ocispec.Descriptor{}, fmt.Errorf("there is already an entry for the specified document (and NoClobber = true)")
+	}
+
+	layer, err := o.writeBlob(format, data)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+
+	config, err := o.writeBlob(ocispec.MediaTypeEmptyJSON, ocispec.DescriptorEmptyJSON.Data)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+
+	annotations := map[string]string{}
+	for k, v := range ociOpts.Annotations {
+		annotations[k] = v
+	}
+	annotations[AnnotationDocumentID] = id
+
+	artifactType := artifactTypeFromFormat(formats.Format(format))
+	manifest := ocispec.Manifest{
+		Versioned:    specs.Versioned{SchemaVersion: 2},
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: artifactType,
+		Config:       config,
+		Layers:       []ocispec.Descriptor{layer},
+		Subject:      ociOpts.Subject,
+		Annotations:  annotations,
+	}
+
+	manifestData, err := json.Marshal(manifest)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("marshalling manifest: %w", err)
+	}
+
+	desc, err := o.writeBlob(ocispec.MediaTypeImageManifest, manifestData)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	desc.ArtifactType = artifactType
+	desc.Annotations = map[string]string{AnnotationDocumentID: id, AnnotationDocumentFormat: format}
+
+	// Replace any previous version of the artifact in the index. Its
+	// blobs are left in the layout as other manifests may share them.
+	index.Manifests = slices.DeleteFunc(index.Manifests, func(d ocispec.Descriptor) bool {
+		return isArtifact(d, id, format)
+	})
+	index.Manifests = append(index.Manifests, desc)
+
+	if err := o.writeIndex(index); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	return desc, nil
+}
+
+// retrieveArtifact looks up an artifact and returns its manifest and the
+// data in its layer.
+func (o *OCILayout) retrieveArtifact(ref, format string) (*ocispec.Manifest, []byte, error) {
+	if o.Options.Path == "" {
+		return nil, nil, fmt.Errorf("OCI layout backend path not set")
+	}
+
+	if err := checkReference(ref); err != nil {
+		return nil, nil, err
+	}
+
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+
+	index, err := o.readIndex()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	desc, ok := findManifest(index, ref, format)
+	if !ok {
+		return nil, nil, fmt.Errorf("artifact %q: %w", ref, ErrNotFound)
+	}
+
+	data, err := o.readBlob(desc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading manifest: %w", err)
+	}
+
+	manifest := &ocispec.Manifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, nil, fmt.Errorf("parsing manifest: %w", err)
+	}
+
+	if len(manifest.Layers) != 1 {
+		return nil, nil, fmt.Errorf("artifact %q has %d layers, expected one", ref, len(manifest.Layers))
+	}
+
+	layer, err := o.readBlob(manifest.Layers[0])
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading artifact layer: %w", err)
+	}
+	return manifest, layer, nil
+}
+
+// Store implements the Storer interface. It writes the protobom document as
+// an artifact in the layout. To attach the document to a subject, pass an
+// OCIStoreOptions in the BackendOptions.
+func (o *OCILayout) Store(bom *sbom.Document, opts *StoreOptions) error {
+	_, err := o.StoreDescriptor(bom, opts)
+	return err
+}
+
+// StoreDescriptor writes the protobom document as an artifact and returns
+// the descriptor of its manifest.
+func (o *OCILayout) StoreDescriptor(bom *sbom.Document, opts *StoreOptions) (ocispec.Descriptor, error) {
+	if bom.GetMetadata().GetId() == "" {
+		return ocispec.Descriptor{}, fmt.Errorf("unable to persist document: no document id set")
+	}
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(bom)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("marshalling protobom to binary form: %w", err)
+	}
+
+	return o.storeArtifact(bom.GetMetadata().GetId(), data, MediaTypeProtobom, opts)
+}
+
+// StoreNative writes a document rendered in a native format as an artifact
+// and returns the descriptor of its manifest. The format is the media type
+// of the layer, the artifact type is the format without its parameters.
+// Each version of a format is stored in its own artifact.
+func (o *OCILayout) StoreNative(id string, data []byte, format formats.Format, opts *StoreOptions) (ocispec.Descriptor, error) {
+	if format == "" {
+		return ocispec.Descriptor{}, fmt.Errorf("unable to persist document: no format specified")
+	}
+	return o.storeArtifact(id, data, string(format), opts)
+}
+
+// Retrieve implements the Retriever interface. The protobom document can be
+// looked up by its ID or by the digest of its artifact manifest. Storing a
+// document replaces its artifact in the index, selecting a revision other
+// than the latest is an error.
+func (o *OCILayout) Retrieve(ref string, opts *RetrieveOptions) (*sbom.Document, error) {
+	if opts != nil && !opts.Revision.isLatest() {
+		return nil, fmt.Errorf("unable to retrieve SBOM data: the oci backend does not keep document revisions")
+	}
+
+	manifest, data, err := o.retrieveArtifact(ref, MediaTypeProtobom)
+	if err != nil {
+		return nil, err
+	}
+
+	if manifest.ArtifactType != MediaTypeProtobom {
+		return nil, fmt.Errorf("artifact %q is not a protobom document (%s)", ref, manifest.ArtifactType)
+	}
+
+	bom := &sbom.Document{}
+	if err := proto.Unmarshal(data, bom); err != nil {
+		return nil, fmt.Errorf("unmarshalling proto: %w", err)
+	}
+	return bom, nil
+}
+
+// RetrieveNative returns the data of a document stored in a native format
+// along with the format recorded in the artifact. The document can be looked
+// up by its ID and format, including its version, or by the digest of its
+// artifact manifest, in which case the format argument is ignored.
+func (o *OCILayout) RetrieveNative(ref string, format formats.Format) ([]byte, formats.Format, error) {
+	manifest, data, err := o.retrieveArtifact(ref, string(format))
+	if err != nil {
+		return nil, "", err
+	}
+	return data, formats.Format(manifest.Layers[0].MediaType), nil
+}
+
+// Referrers returns the descriptors of the artifacts in the layout that are
+// attached to the subject digest. If artifactType is not empty, only the
+// referrers of that type are returned.
+func (o *OCILayout) Referrers(subject digest.Digest, artifactType string) ([]ocispec.Descriptor, error) {
+	if o.Options.Path == "" {
+		return nil, fmt.Errorf("OCI layout backend path not set")
+	}
+
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+
+	index, err := o.readIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	ret := []ocispec.Descriptor{}
+	for _, desc := range index.Manifests {
+		if desc.MediaType != ocispec.MediaTypeImageManifest {
+			continue
+		}
+		if artifactType != "" && desc.ArtifactType != artifactType {
+			continue
+		}
+
+		data, err := o.readBlob(desc)
+		if err != nil {
+			return nil, fmt.Errorf("reading manifest: %w", err)
+		}
+
+		manifest := &ocispec.Manifest{}
+		if err := json.Unmarshal(data, manifest); err != nil {
+			return nil, fmt.Errorf("parsing manifest: %w", err)
+		}
+
+		if manifest.Subject == nil || manifest.Subject.Digest != subject {
+			continue
+		}
+
+		ret = append(ret, ocispec.Descriptor{
+			MediaType:    desc.MediaType,
+			Digest:       desc.Digest,
+			Size:         desc.Size,
+			ArtifactType: manifest.ArtifactType,
+			Annotations:  manifest.Annotations,
+		})
+	}
+	return ret, nil
+}
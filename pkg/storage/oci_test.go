@@ -0,0 +1,146 @@
+package storage
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/formats"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+func TestOCILayout(t *testing.T) {
+	t.Parallel()
+	o := NewOCILayout()
+	o.Options.Path = t.TempDir()
+
+	doc := &sbom.Document{
+		Metadata: &sbom.Metadata{Id: "urn:uuid:test-document", Name: "test"},
+		NodeList: &sbom.NodeList{Nodes: []*sbom.Node{{Id: "pkg"}}},
+	}
+
+	desc, err := o.StoreDescriptor(doc, nil)
+	require.NoError(t, err)
+	require.Equal(t, ocispec.MediaTypeImageManifest, desc.MediaType)
+	require.Equal(t, MediaTypeProtobom, desc.ArtifactType)
+
+	// The layout has the required files
+	require.FileExists(t, filepath.Join(o.Options.Path, ocispec.ImageLayoutFile))
+	data, err := os.ReadFile(filepath.Join(o.Options.Path, ocispec.ImageIndexFile))
+	require.NoError(t, err)
+	index := &ocispec.Index{}
+	require.NoError(t, json.Unmarshal(data, index))
+	require.Len(t, index.Manifests, 1)
+
+	// Retrieve by ID and by manifest digest
+	for _, ref := range []string{"urn:uuid:test-document", desc.Digest.String()} {
+		retrieved, err := o.Retrieve(ref, nil)
+		require.NoError(t, err)
+		require.Equal(t, "test", retrieved.GetMetadata().GetName())
+	}
+
+	_, err = o.Retrieve("nope", nil)
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = o.Retrieve("sha256:nope", nil)
+	require.ErrorIs(t, err, ErrInvalidReference)
+
+	// The latest revision is the only one available
+	_, err = o.Retrieve("urn:uuid:test-document", &RetrieveOptions{Revision: LatestRevision()})
+	require.NoError(t, err)
+	_, err = o.Retrieve("urn:uuid:test-document", &RetrieveOptions{Revision: RevisionByIndex(0)})
+	require.Error(t, err)
+	_, err = o.Retrieve("urn:uuid:test-document", &RetrieveOptions{Revision: RevisionAt(time.Now())})
+	require.Error(t, err)
+
+	require.Error(t, o.Store(doc, &StoreOptions{NoClobber: true}))
+	require.NoError(t, o.Store(doc, nil))
+
+	// Native documents are stored next to the protobom
+	native := []byte(`{"bomFormat":"CycloneDX","specVersion":"1.5"}`)
+	nativeDesc, err := o.StoreNative("urn:uuid:test-document", native, formats.CDX15JSON, nil)
+	require.NoError(t, err)
+	require.Equal(t, "application/vnd.cyclonedx+json", nativeDesc.ArtifactType)
+
+	got, format, err := o.RetrieveNative("urn:uuid:test-document", formats.CDX15JSON)
+	require.NoError(t, err)
+	require.Equal(t, native, got)
+	require.Equal(t, formats.CDX15JSON, format)
+
+	_, _, err = o.RetrieveNative("urn:uuid:test-document", formats.SPDX23JSON)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// Versions of a format don't replace each other
+	_, _, err = o.RetrieveNative("urn:uuid:test-document", formats.CDX16JSON)
+	require.ErrorIs(t, err, ErrNotFound)
+	native16 := []byte(`{"bomFormat":"CycloneDX","specVersion":"1.6"}`)
+	desc16, err := o.StoreNative("urn:uuid:test-document", native16, formats.CDX16JSON, nil)
+	require.NoError(t, err)
+	require.Equal(t, "application/vnd.cyclonedx+json", desc16.ArtifactType)
+
+	got, _, err = o.RetrieveNative("urn:uuid:test-document", formats.CDX15JSON)
+	require.NoError(t, err)
+	require.Equal(t, native, got)
+	got, format, err = o.RetrieveNative("urn:uuid:test-document", formats.CDX16JSON)
+	require.NoError(t, err)
+	require.Equal(t, native16, got)
+	require.Equal(t, formats.CDX16JSON, format)
+
+	index, err = o.readIndex()
+	require.NoError(t, err)
+	require.Len(t, index.Manifests, 3)
+
+	// Tampered blobs are detected
+	require.NoError(t, os.WriteFile(o.blobPath(nativeDesc.Digest), []byte("{}"), os.FileMode(0o644)))
+	_, _, err = o.RetrieveNative(nativeDesc.Digest.String(), "")
+	require.Error(t, err)
+}
+
+func TestOCILayoutReferrers(t *testing.T) {
+	t.Parallel()
+	o := NewOCILayout()
+	o.Options.Path = t.TempDir()
+
+	image := digest.FromString("image")
+	subject := &ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    image,
+		Size:      100,
+	}
+
+	desc, err := o.StoreDescriptor(&sbom.Document{Metadata: &sbom.Metadata{Id: "image-sbom"}}, &StoreOptions{
+		BackendOptions: &OCIStoreOptions{
+			Subject:     subject,
+			Annotations: map[string]string{ocispec.AnnotationCreated: "2024-01-01T00:00:00Z"},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = o.StoreNative("image-sbom", []byte("{}"), formats.SPDX23JSON, &StoreOptions{
+		BackendOptions: &OCIStoreOptions{Subject: subject},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, o.Store(&sbom.Document{Metadata: &sbom.Metadata{Id: "unrelated"}}, nil))
+
+	refs, err := o.Referrers(image, "")
+	require.NoError(t, err)
+	require.Len(t, refs, 2)
+
+	refs, err = o.Referrers(image, MediaTypeProtobom)
+	require.NoError(t, err)
+	require.Len(t, refs, 1)
+	require.Equal(t, desc.Digest, refs[0].Digest)
+	require.Equal(t, "2024-01-01T00:00:00Z", refs[0].Annotations[ocispec.AnnotationCreated])
+
+	refs, err = o.Referrers(digest.FromString("other"), "")
+	require.NoError(t, err)
+	require.Empty(t, refs)
+
+	require.Error(t, o.Store(&sbom.Document{Metadata: &sbom.Metadata{Id: "x"}}, &StoreOptions{BackendOptions: "wrong"}))
+}
@@ -11,7 +11,9 @@ type StoreOptions struct {
 	// modules implementing the storage backend interface
 	BackendOptions any
 
-	// NoClobber ensures documents with the same ID are never overwritten
+	// NoClobber ensures documents with the same ID are never overwritten.
+	// Backends keeping a revision history never overwrite documents, they
+	// append a new revision instead.
 	NoClobber bool
 }
 
@@ -19,4 +21,26 @@ type RetrieveOptions struct {
 	// BackendOptions is a field to pipe system-specific options to the
 	// modules implementing the storage backend interface
 	BackendOptions any
+
+	// Revision selects the revision of the document to retrieve from
+	// backends that keep a history. When nil, the latest is returned.
+	Revision *RevisionSelector
+}
+
+type ListOptions struct {
+	// BackendOptions is a field to pipe system-specific options to the
+	// modules implementing the storage backend interface
+	BackendOptions any
+}
+
+type DeleteOptions struct {
+	// BackendOptions is a field to pipe system-specific options to the
+	// modules implementing the storage backend interface
+	BackendOptions any
+}
+
+type SearchOptions struct {
+	// BackendOptions is a field to pipe system-specific options to the
+	// modules implementing the storage backend interface
+	BackendOptions any
 }
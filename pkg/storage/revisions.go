@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"time"
+)
+
+// RevisionSelector chooses which revision of a document is returned by the
+// backends that keep a history of the documents they store. A nil selector
+// and the zero value both mean the latest revision.
+type RevisionSelector struct {
+	// Index selects a revision by its position in the history, starting
+	// at zero for the first revision stored. Negative numbers count back
+	// from the latest revision, -1 being the latest. When nil, the latest
+	// revision is selected.
+	Index *int
+
+	// Time selects the latest revision stored at or before it. When set,
+	// Index is ignored.
+	Time time.Time
+}
+
+// LatestRevision returns a selector that picks the most recent revision.
+func LatestRevision() *RevisionSelector {
+	return &RevisionSelector{}
+}
+
+// RevisionByIndex returns a selector that picks a revision by its position
+// in the document history.
+func RevisionByIndex(i int) *RevisionSelector {
+	return &RevisionSelector{Index: &i}
+}
+
+// RevisionAt returns a selector that picks the revision that was current
+// at the specified time.
+func RevisionAt(t time.Time) *RevisionSelector {
+	return &RevisionSelector{Time: t}
+}
+
+// isLatest returns true if the selector always picks the latest revision
+func (sel *RevisionSelector) isLatest() bool {
+	return sel == nil || (sel.Time.IsZero() && (sel.Index == nil || *sel.Index == -1))
+}
+
+// Revision describes an entry in the history of a stored document.
+type Revision struct {
+	// Index is the position of the revision in the history, the first
+	// one stored is revision zero.
+	Index int
+
+	// Date records when the revision was stored
+	Date time.Time
+}
+
+// RevisionLister is an optional interface implemented by backends that keep
+// the history of the documents they store.
+type RevisionLister interface {
+	Revisions(string) ([]*Revision, error)
+}
+
+// selectRevision returns the revision in the history (sorted by index)
+// chosen by the selector or nil if none matches.
+func selectRevision(history []*Revision, sel *RevisionSelector) *Revision {
+	if len(history) == 0 {
+		return nil
+	}
+
+	if sel.isLatest() {
+		return history[len(history)-1]
+	}
+
+	if !sel.Time.IsZero() {
+		var ret *Revision
+		for _, r := range history {
+			if r.Date.After(sel.Time) {
+				break
+			}
+			ret = r
+		}
+		return ret
+	}
+
+	i := *sel.Index
+	if i < 0 {
+		i += len(history)
+	}
+	if i < 0 || i >= len(history) {
+		return nil
+	}
+	return history[i]
+}
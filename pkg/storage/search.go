@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"strings"
+	"time"
+
+	"github.com/protobom/protobom/pkg/formats"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// SearchFilter captures the criteria to look up stored documents by their
+// metadata. Empty fields are ignored, a document must match all the fields
+// set in the filter.
+type SearchFilter struct {
+	// Name matches documents whose name contains the string, regardless
+	// of case.
+	Name string
+
+	// After and Before limit the results to documents dated in the range.
+	// Both ends are inclusive. Documents without a date never match when
+	// either end is set.
+	After  time.Time
+	Before time.Time
+
+	// Tool matches documents generated by a tool with the name, regardless
+	// of case.
+	Tool string
+
+	// Format matches the format the document was originally parsed from as
+	// recorded in its SourceData.
+	Format formats.Format
+}
+
+// Matches returns true if the document metadata matches the filter.
+func (f *SearchFilter) Matches(md *sbom.Metadata) bool {
+	if f == nil {
+		return true
+	}
+	if md == nil {
+		return false
+	}
+
+	if f.Name != "" && !strings.Contains(strings.ToLower(md.GetName()), strings.ToLower(f.Name)) {
+		return false
+	}
+
+	if !f.After.IsZero() || !f.Before.IsZero() {
+		if md.GetDate() == nil {
+			return false
+		}
+		date := md.GetDate().AsTime()
+		if !f.After.IsZero() && date.Before(f.After) {
+			return false
+		}
+		if !f.Before.IsZero() && date.After(f.Before) {
+			return false
+		}
+	}
+
+	if f.Tool != "" {
+		found := false
+		for _, t := range md.GetTools() {
+			if strings.EqualFold(t.GetName(), f.Tool) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if f.Format != "" && formats.Format(md.GetSourceData().GetFormat()) != f.Format {
+		return false
+	}
+
+	return true
+}
+
+// filterMetadata returns the entries in the list that match the filter
+func filterMetadata(list []*sbom.Metadata, f *SearchFilter) []*sbom.Metadata {
+	ret := []*sbom.Metadata{}
+	for _, md := range list {
+		if f.Matches(md) {
+			ret = append(ret, md)
+		}
+	}
+	return ret
+}
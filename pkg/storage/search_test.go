@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/protobom/protobom/pkg/formats"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+func TestSearchFilterMatches(t *testing.T) {
+	t.Parallel()
+	md := &sbom.Metadata{
+		Id:         "test",
+		Name:       "My Product SBOM",
+		Date:       timestamppb.New(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)),
+		Tools:      []*sbom.Tool{{Name: "protobom"}, {Name: "syft"}},
+		SourceData: &sbom.SourceData{Format: string(formats.SPDX23JSON)},
+	}
+
+	for _, tc := range []struct {
+		name     string
+		filter   *SearchFilter
+		md       *sbom.Metadata
+		expected bool
+	}{
+		{"nil filter", nil, md, true},
+		{"empty filter", &SearchFilter{}, md, true},
+		{"nil metadata", &SearchFilter{}, nil, false},
+		{"name", &SearchFilter{Name: "product"}, md, true},
+		{"name no match", &SearchFilter{Name: "other"}, md, false},
+		{"after", &SearchFilter{After: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}, md, true},
+		{"after no match", &SearchFilter{After: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)}, md, false},
+		{"before", &SearchFilter{Before: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}, md, true},
+		{"before no match", &SearchFilter{Before: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)}, md, false},
+		{"date without date", &SearchFilter{Before: time.Now()}, &sbom.Metadata{}, false},
+		{"tool", &SearchFilter{Tool: "Syft"}, md, true},
+		{"tool no match", &SearchFilter{Tool: "bom"}, md, false},
+		{"format", &SearchFilter{Format: formats.SPDX23JSON}, md, true},
+		{"format no match", &SearchFilter{Format: formats.CDX15JSON}, md, false},
+		{"all", &SearchFilter{Name: "sbom", Tool: "protobom", Format: formats.SPDX23JSON}, md, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tc.expected, tc.filter.Matches(tc.md))
+		})
+	}
+}
@@ -0,0 +1,646 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2026 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+var (
+	_ StoreRetriever = (*SQL)(nil)
+	_ Lister         = (*SQL)(nil)
+	_ Deleter        = (*SQL)(nil)
+	_ Searcher       = (*SQL)(nil)
+)
+
+// SQLDialect captures the differences in the SQL syntax of the supported
+// database engines.
+type SQLDialect string
+
+const (
+	// DialectSQLite is the dialect used by SQLite databases. It is the
+	// default dialect of the SQL backend.
+	DialectSQLite = SQLDialect("sqlite")
+
+	// DialectPostgres is the dialect of PostgreSQL databases.
+	DialectPostgres = SQLDialect("postgres")
+)
+
+type SQLOptions struct {
+	// Dialect selects the SQL flavor spoken by the database. If not set,
+	// the backend uses the SQLite dialect.
+	Dialect SQLDialect
+}
+
+// SQL is a storage backend that persists protobom documents in a relational
+// database through the standard library database/sql package. Callers are
+// expected to open the database using the driver of their choice and pass
+// it to NewSQL. For local use, a pure-Go SQLite driver works well.
+//
+// The documents are stored in a normalized schema: the metadata of each
+// document is kept in the documents table while the nodes, edges, root
+// elements, identifiers and hashes are stored in their own tables, all keyed
+// by the document ID (Metadata.Id). This makes it possible to query data
+// across documents, for example to find all the documents that contain a
+// package URL.
+type SQL struct {
+	Options SQLOptions
+	db      *sql.DB
+}
+
+// NewSQL returns a new SQL storage backend that stores data in db. Before
+// using it, the database schema needs to be created by calling Init.
+func NewSQL(db *sql.DB) *SQL {
+	return &SQL{
+		Options: SQLOptions{
+			Dialect: DialectSQLite,
+		},
+		db: db,
+	}
+}
+
+// blobType returns the name of the binary data column type
+func (s *SQL) blobType() string {
+	if s.Options.Dialect == DialectPostgres {
+		return "BYTEA"
+	}
+	return "BLOB"
+}
+
+// rebind rewrites the ? placeholders in query to the dialect's syntax
+func (s *SQL) rebind(query string) string {
+	if s.Options.Dialect != DialectPostgres {
+		return query
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Init creates the tables used by the backend if they don't exist.
+func (s *SQL) Init() error {
+	if s.db == nil {
+		return fmt.Errorf("sql backend has no database")
+	}
+
+	blob := s.blobType()
+	stmts := []string{
+		`CREATE TABLE IF NOT EXISTS documents (
+			id TEXT NOT NULL PRIMARY KEY,
+			name TEXT NOT NULL,
+			version TEXT NOT NULL,
+			date BIGINT,
+			metadata ` + blob + `
+		)`,
+		`CREATE TABLE IF NOT EXISTS nodes (
+			document_id TEXT NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+			id TEXT NOT NULL,
+			position INTEGER NOT NULL,
+			type INTEGER NOT NULL,
+			name TEXT NOT NULL,
+			version TEXT NOT NULL,
+			data ` + blob + `,
+			PRIMARY KEY (document_id, id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS root_elements (
+			document_id TEXT NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+			node_id TEXT NOT NULL,
+			position INTEGER NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS edges (
+			document_id TEXT NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+			edge_index INTEGER NOT NULL,
+			type INTEGER NOT NULL,
+			from_id TEXT NOT NULL,
+			PRIMARY KEY (document_id, edge_index)
+		)`,
+		`CREATE TABLE IF NOT EXISTS edge_targets (
+			document_id TEXT NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+			edge_index INTEGER NOT NULL,
+			to_id TEXT NOT NULL,
+			position INTEGER NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS identifiers (
+			document_id TEXT NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+			node_id TEXT NOT NULL,
+			type INTEGER NOT NULL,
+			value TEXT NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS hashes (
+			document_id TEXT NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+			node_id TEXT NOT NULL,
+			algorithm INTEGER NOT NULL,
+			value TEXT NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_nodes_name ON nodes (name, version)`,
+		`CREATE INDEX IF NOT EXISTS idx_edge_targets_document ON edge_targets (document_id, edge_index)`,
+		`CREATE INDEX IF NOT EXISTS idx_identifiers_value ON identifiers (type, value)`,
+		`CREATE INDEX IF NOT EXISTS idx_hashes_value ON hashes (algorithm, value)`,
+	}
+
+	for _, stmt := range stmts {
+		if _, err := s.db.Exec(stmt); err != nil {
+			return fmt.Errorf("creating database schema: %w", err)
+		}
+	}
+	return nil
+}
+
+// documentTables lists the tables holding document data. The documents
+// table must be last when deleting to honor the foreign keys.
+var documentTables = []string{"hashes", "identifiers", "edge_targets", "edges", "root_elements", "nodes", "documents"}
+
+// deleteDocument removes all rows of a document from the database. We don't
+// rely on ON DELETE CASCADE as not all databases enforce foreign keys.
+func (s *SQL) deleteDocument(tx *sql.Tx, id string) error {
+	for _, table := range documentTables {
+		column := "document_id"
+		if table == "documents" {
+			column = "id"
+		}
+		if _, err := tx.Exec(
+			s.rebind(fmt.Sprintf("DELETE FROM %s WHERE %s = ?", table, column)), id, //nolint:gosec // table names are constants
+		); err != nil {
+			return fmt.Errorf("deleting document rows from %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// Store implements the backend driver Store method. It writes the document
+// into the database tables, replacing any previous version unless the
+// NoClobber option is set.
+func (s *SQL) Store(bom *sbom.Document, opts *StoreOptions) error {
+	if opts == nil {
+		opts = &StoreOptions{}
+	}
+
+	if s.db == nil {
+		return fmt.Errorf("sql backend has no database")
+	}
+
+	if bom == nil || bom.Metadata == nil || bom.Metadata.Id == "" {
+		return fmt.Errorf("unable to persist document: no document id set")
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("starting transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if err := s.storeTx(tx, bom, opts); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	return nil
+}
+
+// storeTx writes the document to the database within a transaction
+func (s *SQL) storeTx(tx *sql.Tx, bom *sbom.Document, opts *StoreOptions) error {
+	id := bom.Metadata.Id
+
+	var count int
+	if err := tx.QueryRow(
+		s.rebind("SELECT COUNT(*) FROM documents WHERE id = ?"), id,
+	).Scan(&count); err != nil {
+		return fmt.Errorf("checking for existing document: %w", err)
+	}
+
+	if count > 0 {
+		if opts.NoClobber {
+			return fmt.Errorf("there is already an entry for the specified document (and NoClobber = true)")
+		}
+		if err := s.deleteDocument(tx, id); err != nil {
+			return err
+		}
+	}
+
+	var date any
+	if bom.Metadata.Date != nil {
+		date = bom.Metadata.Date.AsTime().Unix()
+	}
+
+	if _, err := tx.Exec(
+		s.rebind("INSERT INTO documents (id, name, version, date, metadata) VALUES (?, ?, ?, ?, ?)"),
+		id, bom.Metadata.Name, bom.Metadata.Version, date, bom.Metadata,
+	); err != nil {
+		return fmt.Errorf("inserting document: %w", err)
+	}
+
+	nodeList := bom.GetNodeList()
+	if nodeList == nil {
+		return nil
+	}
+
+	for i, n := range dedupeNodes(nodeList.Nodes) {
+		if _, err := tx.Exec(
+			s.rebind("INSERT INTO nodes (document_id, id, position, type, name, version, data) VALUES (?, ?, ?, ?, ?, ?, ?)"),
+			id, n.Id, i, int32(n.Type), n.Name, n.Version, n,
+		); err != nil {
+			return fmt.Errorf("inserting node %s: %w", n.Id, err)
+		}
+
+		for t, v := range n.Identifiers {
+			if _, err := tx.Exec(
+				s.rebind("INSERT INTO identifiers (document_id, node_id, type, value) VALUES (?, ?, ?, ?)"),
+				id, n.Id, t, identifierValue(sbom.SoftwareIdentifierType(t), v),
+			); err != nil {
+				return fmt.Errorf("inserting identifier of node %s: %w", n.Id, err)
+			}
+		}
+
+		for algo, v := range n.Hashes {
+			if _, err := tx.Exec(
+				s.rebind("INSERT INTO hashes (document_id, node_id, algorithm, value) VALUES (?, ?, ?, ?)"),
+				id, n.Id, algo, v,
+			); err != nil {
+				return fmt.Errorf("inserting hash of node %s: %w", n.Id, err)
+			}
+		}
+	}
+
+	for i, r := range nodeList.RootElements {
+		if _, err := tx.Exec(
+			s.rebind("INSERT INTO root_elements (document_id, node_id, position) VALUES (?, ?, ?)"),
+			id, r, i,
+		); err != nil {
+			return fmt.Errorf("inserting root element %s: %w", r, err)
+		}
+	}
+
+	for i, e := range nodeList.Edges {
+		if _, err := tx.Exec(
+			s.rebind("INSERT INTO edges (document_id, edge_index, type, from_id) VALUES (?, ?, ?, ?)"),
+			id, i, int32(e.Type), e.From,
+		); err != nil {
+			return fmt.Errorf("inserting edge from %s: %w", e.From, err)
+		}
+		for j, to := range e.To {
+			if _, err := tx.Exec(
+				s.rebind("INSERT INTO edge_targets (document_id, edge_index, to_id, position) VALUES (?, ?, ?, ?)"),
+				id, i, to, j,
+			); err != nil {
+				return fmt.Errorf("inserting edge from %s: %w", e.From, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// dedupeNodes returns the nodes with those sharing an ID merged into the
+// first one, as nodes are keyed by their ID in the database. The merged
+// nodes are copies, the nodes in the document are not modified.
+func dedupeNodes(nodes []*sbom.Node) []*sbom.Node {
+	ret := make([]*sbom.Node, 0, len(nodes))
+	positions := map[string]int{}
+	merged := map[string]struct{}{}
+	for _, n := range nodes {
+		i, ok := positions[n.Id]
+		if !ok {
+			positions[n.Id] = len(ret)
+			ret = append(ret, n)
+			continue
+		}
+		if _, ok := merged[n.Id]; !ok {
+			ret[i] = proto.CloneOf(ret[i])
+			merged[n.Id] = struct{}{}
+		}
+		ret[i].Augment(n)
+	}
+	return ret
+}
+
+// identifierValue returns the value of an identifier as stored in the
+// identifiers table. Package URLs are normalized so they can be queried
+// regardless of how they were written.
+func identifierValue(t sbom.SoftwareIdentifierType, v string) string {
+	if t == sbom.SoftwareIdentifierType_PURL {
+		return string(sbom.PackageURL(v).Normalize())
+	}
+	return v
+}
+
+// Retrieve implements the storage backend Retrieve interface. It rebuilds
+// the protobom document from the database tables. The SQL backend does not
+// keep a history, selecting a revision other than the latest is an error.
+func (s *SQL) Retrieve(id string, opts *RetrieveOptions) (*sbom.Document, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("sql backend has no database")
+	}
+	if id == "" {
+		return nil, fmt.Errorf("unable to retrieve SBOM data: no identifier defined: %w", ErrInvalidReference)
+	}
+	if opts != nil && !opts.Revision.isLatest() {
+		return nil, fmt.Errorf("unable to retrieve SBOM data: the sql backend does not keep document revisions")
+	}
+
+	doc := &sbom.Document{
+		Metadata: &sbom.Metadata{},
+		NodeList: sbom.NewNodeList(),
+	}
+
+	err := s.db.QueryRow(
+		s.rebind("SELECT metadata FROM documents WHERE id = ?"), id,
+	).Scan(doc.Metadata)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("document %q: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading document metadata: %w", err)
+	}
+
+	if err := s.queryRows(
+		"SELECT data FROM nodes WHERE document_id = ? ORDER BY position",
+		[]any{id},
+		func(rows *sql.Rows) error {
+			n := &sbom.Node{}
+			if err := rows.Scan(n); err != nil {
+				return err
+			}
+			doc.NodeList.Nodes = append(doc.NodeList.Nodes, n)
+			return nil
+		},
+	); err != nil {
+		return nil, fmt.Errorf("reading nodes: %w", err)
+	}
+
+	if err := s.queryRows(
+		"SELECT node_id FROM root_elements WHERE document_id = ? ORDER BY position",
+		[]any{id},
+		func(rows *sql.Rows) error {
+			var r string
+			if err := rows.Scan(&r); err != nil {
+				return err
+			}
+			doc.NodeList.RootElements = append(doc.NodeList.RootElements, r)
+			return nil
+		},
+	); err != nil {
+		return nil, fmt.Errorf("reading root elements: %w", err)
+	}
+
+	edges := map[int]*sbom.Edge{}
+	if err := s.queryRows(
+		"SELECT edge_index, type, from_id FROM edges WHERE document_id = ? ORDER BY edge_index",
+		[]any{id},
+		func(rows *sql.Rows) error {
+			var (
+				i    int
+				t    int32
+				from string
+			)
+			if err := rows.Scan(&i, &t, &from); err != nil {
+				return err
+			}
+			e := &sbom.Edge{Type: sbom.Edge_Type(t), From: from, To: []string{}}
+			edges[i] = e
+			doc.NodeList.Edges = append(doc.NodeList.Edges, e)
+			return nil
+		},
+	); err != nil {
+		return nil, fmt.Errorf("reading edges: %w", err)
+	}
+
+	if err := s.queryRows(
+		"SELECT edge_index, to_id FROM edge_targets WHERE document_id = ? ORDER BY edge_index, position",
+		[]any{id},
+		func(rows *sql.Rows) error {
+			var (
+				i  int
+				to string
+			)
+			if err := rows.Scan(&i, &to); err != nil {
+				return err
+			}
+			e, ok := edges[i]
+			if !ok {
+				return fmt.Errorf("edge target %s has no edge", to)
+			}
+			e.To = append(e.To, to)
+			return nil
+		},
+	); err != nil {
+		return nil, fmt.Errorf("reading edge targets: %w", err)
+	}
+
+	return doc, nil
+}
+
+// List implements the Lister interface. It returns the metadata of all the
+// documents in the database sorted by their ID.
+func (s *SQL) List(_ *ListOptions) ([]*sbom.Metadata, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("sql backend has no database")
+	}
+
+	ret := []*sbom.Metadata{}
+	if err := s.queryRows(
+		"SELECT metadata FROM documents ORDER BY id", nil,
+		func(rows *sql.Rows) error {
+			md := &sbom.Metadata{}
+			if err := rows.Scan(md); err != nil {
+				return err
+			}
+			ret = append(ret, md)
+			return nil
+		},
+	); err != nil {
+		return nil, fmt.Errorf("listing documents: %w", err)
+	}
+	return ret, nil
+}
+
+// Search implements the Searcher interface. It returns the metadata of the
+// documents matching the filter sorted by their ID.
+func (s *SQL) Search(filter *SearchFilter, _ *SearchOptions) ([]*sbom.Metadata, error) {
+	list, err := s.List(nil)
+	if err != nil {
+		return nil, err
+	}
+	return filterMetadata(list, filter), nil
+}
+
+// Delete implements the Deleter interface. It removes all the data of the
+// document from the database.
+func (s *SQL) Delete(id string, _ *DeleteOptions) error {
+	if s.db == nil {
+		return fmt.Errorf("sql backend has no database")
+	}
+	if id == "" {
+		return fmt.Errorf("unable to delete document: no identifier defined")
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("starting transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	var count int
+	if err := tx.QueryRow(
+		s.rebind("SELECT COUNT(*) FROM documents WHERE id = ?"), id,
+	).Scan(&count); err != nil {
+		return fmt.Errorf("checking for existing document: %w", err)
+	}
+	if count == 0 {
+		return fmt.Errorf("document %q: %w", id, ErrNotFound)
+	}
+
+	if err := s.deleteDocument(tx, id); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	return nil
+}
+
+// queryRows runs a query and calls fn for each of the returned rows
+func (s *SQL) queryRows(query string, args []any, fn func(*sql.Rows) error) error {
+	rows, err := s.db.Query(s.rebind(query), args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close() //nolint:errcheck
+
+	for rows.Next() {
+		if err := fn(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// NodeQuery defines the criteria to look for nodes across all the documents
+// stored in the SQL backend. All the defined fields must match.
+type NodeQuery struct {
+	// Name and Version match the node's fields exactly
+	Name    string
+	Version string
+
+	// Purl matches nodes with the package URL identifier. Purls are
+	// compared in their canonical form (see sbom.PackageURL.Normalize).
+	Purl sbom.PackageURL
+
+	// IdentifierType and IdentifierValue match any software identifier
+	IdentifierType  sbom.SoftwareIdentifierType
+	IdentifierValue string
+
+	// HashAlgorithm and HashValue match a node hash
+	HashAlgorithm sbom.HashAlgorithm
+	HashValue     string
+}
+
+// NodeMatch is a node found by QueryNodes along with the ID of the document
+// where it was found.
+type NodeMatch struct {
+	DocumentID string
+	Node       *sbom.Node
+}
+
+// QueryNodes looks for nodes matching the query in all the documents stored
+// in the database.
+func (s *SQL) QueryNodes(q *NodeQuery) ([]NodeMatch, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("sql backend has no database")
+	}
+	if q == nil {
+		return nil, fmt.Errorf("no node query specified")
+	}
+
+	conditions := []string{}
+	args := []any{}
+	if q.Name != "" {
+		conditions = append(conditions, "n.name = ?")
+		args = append(args, q.Name)
+	}
+	if q.Version != "" {
+		conditions = append(conditions, "n.version = ?")
+		args = append(args, q.Version)
+	}
+	identifier := func(t sbom.SoftwareIdentifierType, v string) {
+		conditions = append(conditions,
+			"EXISTS (SELECT 1 FROM identifiers i WHERE i.document_id = n.document_id AND i.node_id = n.id AND i.type = ? AND i.value = ?)",
+		)
+		args = append(args, int32(t), identifierValue(t, v))
+	}
+	if q.Purl != "" {
+		identifier(sbom.SoftwareIdentifierType_PURL, string(q.Purl))
+	}
+	if q.IdentifierValue != "" {
+		identifier(q.IdentifierType, q.IdentifierValue)
+	}
+	if q.HashValue != "" {
+		conditions = append(conditions,
+			"EXISTS (SELECT 1 FROM hashes h WHERE h.document_id = n.document_id AND h.node_id = n.id AND h.algorithm = ? AND h.value = ?)",
+		)
+		args = append(args, int32(q.HashAlgorithm), q.HashValue)
+	}
+
+	if len(conditions) == 0 {
+		return nil, fmt.Errorf("node query has no criteria")
+	}
+
+	ret := []NodeMatch{}
+	err := s.queryRows(
+		"SELECT n.document_id, n.data FROM nodes n WHERE "+strings.Join(conditions, " AND ")+
+			" ORDER BY n.document_id, n.position",
+		args,
+		func(rows *sql.Rows) error {
+			m := NodeMatch{Node: &sbom.Node{}}
+			if err := rows.Scan(&m.DocumentID, m.Node); err != nil {
+				return err
+			}
+			ret = append(ret, m)
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying nodes: %w", err)
+	}
+	return ret, nil
+}
+
+// DocumentsWithPurl returns the IDs of all the stored documents that have a
+// node with the specified package URL.
+func (s *SQL) DocumentsWithPurl(purl sbom.PackageURL) ([]string, error) {
+	matches, err := s.QueryNodes(&NodeQuery{Purl: purl})
+	if err != nil {
+		return nil, err
+	}
+
+	ret := []string{}
+	for _, m := range matches {
+		if len(ret) > 0 && ret[len(ret)-1] == m.DocumentID {
+			continue
+		}
+		ret = append(ret, m.DocumentID)
+	}
+	return ret, nil
+}
@@ -0,0 +1,175 @@
+package storage
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	_ "modernc.org/sqlite"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+func newTestSQL(t *testing.T) *SQL {
+	t.Helper()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "protobom.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() }) //nolint:errcheck
+	s := NewSQL(db)
+	require.NoError(t, s.Init())
+	return s
+}
+
+func testSQLDocument(id, purl string) *sbom.Document {
+	doc := sbom.NewDocument()
+	doc.Metadata.Id = id
+	doc.Metadata.Name = "test " + id
+	doc.Metadata.Date = timestamppb.New(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	doc.Metadata.Tools = []*sbom.Tool{{Name: "protobom", Version: "1.0"}}
+
+	root := sbom.NewNode()
+	root.Id = "root"
+	root.Name = "app"
+	root.Version = "1.0"
+
+	lib := sbom.NewNode()
+	lib.Id = "lib"
+	lib.Name = "lib"
+	lib.Version = "2.0"
+	lib.Identifiers[int32(sbom.SoftwareIdentifierType_PURL)] = purl
+	lib.AddHash(sbom.HashAlgorithm_SHA256, "abcdef")
+
+	doc.NodeList.AddRootNode(root)
+	doc.NodeList.AddNode(lib)
+	doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_dependsOn, From: "root", To: []string{"lib"}})
+	return doc
+}
+
+func TestSQLStoreRetrieve(t *testing.T) {
+	t.Parallel()
+	s := newTestSQL(t)
+	doc := testSQLDocument("doc1", "pkg:golang/example.com/lib@2.0")
+
+	require.NoError(t, s.Store(doc, nil))
+	got, err := s.Retrieve("doc1", nil)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(doc, got))
+
+	// Storing again replaces the document
+	doc.Metadata.Name = "updated"
+	require.NoError(t, s.Store(doc, nil))
+	got, err = s.Retrieve("doc1", nil)
+	require.NoError(t, err)
+	require.Equal(t, "updated", got.Metadata.Name)
+	require.Len(t, got.NodeList.Nodes, 2)
+
+	// Unless NoClobber is set
+	require.Error(t, s.Store(doc, &StoreOptions{NoClobber: true}))
+
+	_, err = s.Retrieve("nope", nil)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.Error(t, s.Store(sbom.NewDocument(), nil))
+}
+
+func TestSQLStoreEdgeCases(t *testing.T) {
+	t.Parallel()
+	s := newTestSQL(t)
+	doc := testSQLDocument("doc1", "pkg:golang/example.com/lib@2.0")
+
+	// Edges without targets are kept
+	doc.NodeList.Edges = append(doc.NodeList.Edges, &sbom.Edge{Type: sbom.Edge_contains, From: "lib", To: []string{}})
+
+	// Nodes sharing an ID are merged into the first one
+	dup := sbom.NewNode()
+	dup.Id = "lib"
+	dup.Description = "duplicated lib node"
+	doc.NodeList.AddNode(dup)
+
+	require.NoError(t, s.Store(doc, nil))
+	require.Len(t, doc.NodeList.Nodes, 3)
+	require.Empty(t, doc.NodeList.Nodes[1].Description)
+
+	got, err := s.Retrieve("doc1", nil)
+	require.NoError(t, err)
+	require.Len(t, got.NodeList.Nodes, 2)
+	require.Equal(t, "lib", got.NodeList.Nodes[1].Name)
+	require.Equal(t, "duplicated lib node", got.NodeList.Nodes[1].Description)
+	require.Len(t, got.NodeList.Edges, 2)
+	require.True(t, proto.Equal(doc.NodeList.Edges[1], got.NodeList.Edges[1]))
+
+	// The latest revision is the only one available
+	_, err = s.Retrieve("doc1", &RetrieveOptions{Revision: LatestRevision()})
+	require.NoError(t, err)
+	_, err = s.Retrieve("doc1", &RetrieveOptions{Revision: RevisionAt(time.Now())})
+	require.Error(t, err)
+}
+
+func TestSQLQueryNodes(t *testing.T) {
+	t.Parallel()
+	s := newTestSQL(t)
+	require.NoError(t, s.Store(testSQLDocument("doc1", "pkg:golang/example.com/lib@2.0"), nil))
+	require.NoError(t, s.Store(testSQLDocument("doc2", "pkg:golang/example.com/lib@2.0"), nil))
+	require.NoError(t, s.Store(testSQLDocument("doc3", "pkg:golang/example.com/lib@3.0"), nil))
+
+	require.NoError(t, s.Store(testSQLDocument("doc4", "pkg:deb/debian/lib@2.0?distro=bookworm&arch=amd64"), nil))
+
+	ids, err := s.DocumentsWithPurl("pkg:golang/example.com/lib@2.0")
+	require.NoError(t, err)
+	require.Equal(t, []string{"doc1", "doc2"}, ids)
+
+	// Purls are normalized when stored and queried
+	ids, err = s.DocumentsWithPurl("pkg:DEB/Debian/lib@2.0?arch=amd64&distro=bookworm")
+	require.NoError(t, err)
+	require.Equal(t, []string{"doc4"}, ids)
+
+	matches, err := s.QueryNodes(&NodeQuery{Name: "lib", Version: "2.0"})
+	require.NoError(t, err)
+	require.Len(t, matches, 4)
+	require.Equal(t, "lib", matches[0].Node.Id)
+
+	matches, err = s.QueryNodes(&NodeQuery{
+		HashAlgorithm: sbom.HashAlgorithm_SHA256, HashValue: "abcdef",
+		Purl: "pkg:golang/example.com/lib@3.0",
+	})
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	require.Equal(t, "doc3", matches[0].DocumentID)
+
+	_, err = s.QueryNodes(&NodeQuery{})
+	require.Error(t, err)
+}
+
+func TestSQLRebind(t *testing.T) {
+	t.Parallel()
+	s := NewSQL(nil)
+	require.Equal(t, "a = ? AND b = ?", s.rebind("a = ? AND b = ?"))
+	s.Options.Dialect = DialectPostgres
+	require.Equal(t, "a = $1 AND b = $2", s.rebind("a = ? AND b = ?"))
+}
+
+func TestSQLListSearchDelete(t *testing.T) {
+	t.Parallel()
+	s := newTestSQL(t)
+	require.NoError(t, s.Store(testSQLDocument("doc2", "pkg:generic/lib@1"), nil))
+	require.NoError(t, s.Store(testSQLDocument("doc1", "pkg:generic/lib@1"), nil))
+
+	list, err := s.List(nil)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, "doc1", list[0].Id)
+
+	res, err := s.Search(&SearchFilter{Name: "doc2"}, nil)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+
+	require.NoError(t, s.Delete("doc2", nil))
+	require.ErrorIs(t, s.Delete("doc2", nil), ErrNotFound)
+	ids, err := s.DocumentsWithPurl("pkg:generic/lib@1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"doc1"}, ids)
+}
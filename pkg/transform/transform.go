@@ -0,0 +1,45 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2026 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+// Package transform defines the transformers that the reader and writer run
+// on protobom documents. Transformers registered in the reader run on each
+// document after it is unserialized, those registered in the writer run
+// before the document is serialized. They are the place to enrich, rewrite
+// or redact SBOM data without changing the format drivers.
+package transform
+
+import (
+	"fmt"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// Transformer modifies a document in place
+type Transformer interface {
+	Transform(*sbom.Document) error
+}
+
+// Func adapts a function to the Transformer interface
+type Func func(*sbom.Document) error
+
+// Transform calls f(doc)
+func (f Func) Transform(doc *sbom.Document) error {
+	return f(doc)
+}
+
+// Run applies the transformers to the document in order. It stops at the
+// first transformer returning an error.
+func Run(doc *sbom.Document, transformers ...Transformer) error {
+	for i, t := range transformers {
+		if t == nil {
+			continue
+		}
+		if err := t.Transform(doc); err != nil {
+			return fmt.Errorf("running transformer #%d (%T): %w", i+1, t, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package transform
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+	doc := sbom.NewDocument()
+	calls := []int{}
+	step := func(i int) Transformer {
+		return Func(func(*sbom.Document) error {
+			calls = append(calls, i)
+			return nil
+		})
+	}
+
+	require.NoError(t, Run(doc))
+	require.NoError(t, Run(doc, step(1), nil, step(2)))
+	require.Equal(t, []int{1, 2}, calls)
+
+	calls = []int{}
+	failing := Func(func(*sbom.Document) error { return errors.New("boom") })
+	err := Run(doc, step(1), failing, step(3))
+	require.ErrorContains(t, err, "transformer #2")
+	require.ErrorContains(t, err, "boom")
+	require.Equal(t, []int{1}, calls)
+}
@@ -2,12 +2,17 @@ package writer
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 
+	"github.com/protobom/protobom/pkg/compression"
 	"github.com/protobom/protobom/pkg/datasink"
+	"github.com/protobom/protobom/pkg/dsse"
 	"github.com/protobom/protobom/pkg/formats"
 	"github.com/protobom/protobom/pkg/mod"
 	"github.com/protobom/protobom/pkg/native"
 	"github.com/protobom/protobom/pkg/storage"
+	"github.com/protobom/protobom/pkg/transform"
 )
 
 type WriterOption func(*Writer)
@@ -72,6 +77,37 @@ func WithoutMod(m mod.Mod) WriterOption {
 	}
 }
 
+// WithCompression sets the compression applied to the documents written
+func WithCompression(c compression.Compression) WriterOption {
+	return func(w *Writer) {
+		w.Options.Compression = c
+	}
+}
+
+// WithInTotoStatement makes the writer wrap the rendered documents in an
+// in-toto attestation statement.
+func WithInTotoStatement(wrap bool) WriterOption {
+	return func(w *Writer) {
+		w.Options.InTotoStatement = wrap
+	}
+}
+
+// WithSigner adds a signer to sign the documents written. Signed documents
+// are written in a DSSE envelope.
+func WithSigner(s dsse.Signer) WriterOption {
+	return func(w *Writer) {
+		w.Options.Signers = append(w.Options.Signers, s)
+	}
+}
+
+// WithTransformer adds a transformer to run on the documents before they
+// are written. The transformers run in the order they are added.
+func WithTransformer(t transform.Transformer) WriterOption {
+	return func(w *Writer) {
+		w.Options.Transformers = append(w.Options.Transformers, t)
+	}
+}
+
 func WithListener(l datasink.Listener) WriterOption {
 	return func(w *Writer) {
 		w.Options.Listeners = append(w.Options.Listeners, l)
@@ -84,7 +120,49 @@ type Options struct {
 	RenderOptions    *native.RenderOptions
 	SerializeOptions *native.SerializeOptions
 	StoreOptions     *storage.StoreOptions
-	formatOptions    map[string]interface{}
+
+	// Compression is the compression applied to the rendered documents.
+	// When writing to a file without setting it, the compression is
+	// inferred from the file extension (.gz or .zst).
+	Compression compression.Compression
+
+	// InTotoStatement wraps the rendered document in an in-toto statement.
+	// The subjects are the hashes of the document root nodes and the
+	// predicate type is derived from the format, which must be JSON.
+	InTotoStatement bool
+
+	// Signers sign the rendered document. When set, the document is
+	// written in a DSSE envelope with a signature from each signer.
+	Signers []dsse.Signer
+
+	// Transformers run in order on a copy of the document before it is
+	// serialized. The document passed to the writer is not modified.
+	Transformers  []transform.Transformer
+	formatOptions map[string]interface{}
+}
+
+// copy returns a copy of the options that can be modified without changing
+// the original, the slices, maps and nested options are cloned.
+func (o *Options) copy() *Options {
+	c := *o
+	c.Listeners = slices.Clone(o.Listeners)
+	c.Signers = slices.Clone(o.Signers)
+	c.Transformers = slices.Clone(o.Transformers)
+	c.formatOptions = maps.Clone(o.formatOptions)
+	if o.RenderOptions != nil {
+		ro := *o.RenderOptions
+		c.RenderOptions = &ro
+	}
+	if o.SerializeOptions != nil {
+		so := *o.SerializeOptions
+		so.Mods = maps.Clone(o.SerializeOptions.Mods)
+		c.SerializeOptions = &so
+	}
+	if o.StoreOptions != nil {
+		so := *o.StoreOptions
+		c.StoreOptions = &so
+	}
+	return &c
 }
 
 // argToOptsKeyVal returns a key value to access the options dictionary by using
@@ -1,17 +1,26 @@
 package writer
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"sync"
 
+	"google.golang.org/protobuf/proto"
+
+	"github.com/protobom/protobom/pkg/compression"
+	"github.com/protobom/protobom/pkg/dsse"
 	"github.com/protobom/protobom/pkg/formats"
+	"github.com/protobom/protobom/pkg/intoto"
 	"github.com/protobom/protobom/pkg/native"
 	drivers "github.com/protobom/protobom/pkg/native/serializers"
 	"github.com/protobom/protobom/pkg/sbom"
 	"github.com/protobom/protobom/pkg/storage"
+	"github.com/protobom/protobom/pkg/transform"
 )
 
 type Writer struct {
@@ -32,11 +41,13 @@ var (
 	}
 )
 
+// New returns a writer configured with the options passed. Each writer gets
+// its own copy of the default options, so options never leak across writers.
 func New(opts ...WriterOption) *Writer {
 	ensureSerializersInitialized()
 	w := &Writer{
 		Storage: storage.NewFileSystem(),
-		Options: defaultOptions,
+		Options: defaultOptions.copy(),
 	}
 
 	for _, opt := range opts {
@@ -113,6 +124,18 @@ func (w *Writer) WriteStreamWithOptions(bom *sbom.Document, wr io.Writer, o *Opt
 		so = defaultOptions.SerializeOptions
 	}
 
+	// Transformers work on a copy to leave the caller's document untouched
+	if len(o.Transformers) > 0 {
+		doc, ok := proto.Clone(bom).(*sbom.Document)
+		if !ok {
+			return fmt.Errorf("unable to copy document")
+		}
+		if err := transform.Run(doc, o.Transformers...); err != nil {
+			return fmt.Errorf("transforming document: %w", err)
+		}
+		bom = doc
+	}
+
 	nativeDoc, err := serializer.Serialize(bom, so, o.GetFormatOptions(serializer))
 	if err != nil {
 		return fmt.Errorf("serializing SBOM to native format: %w", err)
@@ -123,27 +146,103 @@ func (w *Writer) WriteStreamWithOptions(bom *sbom.Document, wr io.Writer, o *Opt
 		ro = defaultOptions.RenderOptions
 	}
 
+	// Compress the output if needed, listeners get the uncompressed data
+	out, err := compression.NewWriter(o.Compression, wr)
+	if err != nil {
+		return fmt.Errorf("creating compressed stream: %w", err)
+	}
+
 	// Build the listening chain of all the I/O sinks
-	sinks := []io.Writer{wr}
+	sinks := []io.Writer{out}
 	for _, l := range o.Listeners {
 		sinks = append(sinks, l)
 	}
 	stream := io.MultiWriter(sinks...)
 
-	if err := serializer.Render(nativeDoc, stream, ro, o.GetFormatOptions(serializer)); err != nil {
+	// When signing, the document is rendered to a buffer to sign it
+	var payload bytes.Buffer
+	var dst io.Writer = stream
+	if len(o.Signers) > 0 {
+		dst = &payload
+	}
+
+	if o.InTotoStatement {
+		if err := writeStatement(bom, format, serializer, nativeDoc, dst, ro, o); err != nil {
+			return err
+		}
+	} else if err := serializer.Render(nativeDoc, dst, ro, o.GetFormatOptions(serializer)); err != nil {
 		return fmt.Errorf("writing rendered document to string: %w", err)
 	}
 
+	if len(o.Signers) > 0 {
+		payloadType := string(format)
+		if o.InTotoStatement {
+			payloadType = dsse.PayloadTypeInToto
+		}
+		envelope, err := dsse.Sign(payloadType, payload.Bytes(), o.Signers...)
+		if err != nil {
+			return fmt.Errorf("signing document: %w", err)
+		}
+		if err := encodeJSON(stream, envelope, ro); err != nil {
+			return fmt.Errorf("writing DSSE envelope: %w", err)
+		}
+	}
+
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("flushing compressed stream: %w", err)
+	}
+
 	return nil
 }
 
+// writeStatement renders the native document and writes it to wr wrapped
+// in an in-toto statement.
+func writeStatement(
+	bom *sbom.Document, format formats.Format, serializer native.Serializer,
+	nativeDoc interface{}, wr io.Writer, ro *native.RenderOptions, o *Options,
+) error {
+	var rendered bytes.Buffer
+	if err := serializer.Render(nativeDoc, &rendered, ro, o.GetFormatOptions(serializer)); err != nil {
+		return fmt.Errorf("writing rendered document to string: %w", err)
+	}
+
+	statement, err := intoto.NewStatement(bom, format, rendered.Bytes())
+	if err != nil {
+		return fmt.Errorf("wrapping document in in-toto statement: %w", err)
+	}
+
+	if err := encodeJSON(wr, statement, ro); err != nil {
+		return fmt.Errorf("writing in-toto statement: %w", err)
+	}
+	return nil
+}
+
+// encodeJSON writes v to wr indented as set in the render options
+func encodeJSON(wr io.Writer, v any, ro *native.RenderOptions) error {
+	enc := json.NewEncoder(wr)
+	enc.SetEscapeHTML(false)
+	if ro.Indent > 0 {
+		enc.SetIndent("", strings.Repeat(" ", ro.Indent))
+	}
+	return enc.Encode(v)
+}
+
 func (w *Writer) WriteStream(bom *sbom.Document, wr io.Writer) error {
 	return w.WriteStreamWithOptions(bom, wr, w.Options)
 }
 
 // WriteFile takes an sbom.Document and writes it to the file at the specified
-// path. If the file exists it will be truncated.
+// path. If the file exists it will be truncated. If the options don't define
+// a compression, it is inferred from the file extension.
 func (w *Writer) WriteFileWithOptions(bom *sbom.Document, path string, o *Options) error {
+	if o.Compression == compression.None {
+		if c := compression.FromFilename(path); c != compression.None {
+			fileOpts := *o
+			fileOpts.Compression = c
+			o = &fileOpts
+		}
+	}
+
 	f, err := os.Create(path)
 	if err != nil {
 		return err
@@ -160,7 +259,7 @@ func (w *Writer) WriteFile(bom *sbom.Document, path string) error {
 
 // Store persists a protobom document to disk using the default options
 func (w *Writer) Store(bom *sbom.Document) error {
-	return w.StoreWithOptions(bom, defaultOptions)
+	return w.StoreWithOptions(bom, w.Options)
 }
 
 // StoreWithOptions stores a protobom document using the configured storage
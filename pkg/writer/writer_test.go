@@ -2,17 +2,29 @@ package writer_test
 
 import (
 	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"path"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/protobom/protobom/pkg/compression"
+	"github.com/protobom/protobom/pkg/dsse"
 	"github.com/protobom/protobom/pkg/formats"
+	"github.com/protobom/protobom/pkg/intoto"
 	"github.com/protobom/protobom/pkg/native"
 	"github.com/protobom/protobom/pkg/native/nativefakes"
+	"github.com/protobom/protobom/pkg/native/serializers"
 	"github.com/protobom/protobom/pkg/sbom"
 	"github.com/protobom/protobom/pkg/storage"
+	"github.com/protobom/protobom/pkg/transform"
 	"github.com/protobom/protobom/pkg/writer"
 )
 
@@ -484,3 +496,259 @@ func TestStore(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteCompressed(t *testing.T) {
+	t.Parallel()
+	format := formats.Format("application/x-test-compression")
+	content := []byte(`{"test": "compressed output"}`)
+	writer.RegisterSerializer(format, &nativefakes.FakeSerializer{
+		RenderStub: func(_ interface{}, w io.Writer, _ *native.RenderOptions, _ interface{}) error {
+			_, err := w.Write(content)
+			return err
+		},
+	})
+
+	for _, tc := range []struct {
+		name     string
+		filename string
+		option   compression.Compression
+		expected compression.Compression
+	}{
+		{"gzip option", "sbom.json", compression.Gzip, compression.Gzip},
+		{"zstd option", "sbom.json", compression.Zstd, compression.Zstd},
+		{"gzip extension", "sbom.json.gz", compression.None, compression.Gzip},
+		{"zstd extension", "sbom.json.zst", compression.None, compression.Zstd},
+		{"uncompressed", "sbom.json", compression.None, compression.None},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			p := path.Join(t.TempDir(), tc.filename)
+			w := &writer.Writer{Options: &writer.Options{}}
+			require.NoError(t, w.WriteFileWithOptions(&sbom.Document{}, p, &writer.Options{
+				Format:      format,
+				Compression: tc.option,
+			}))
+
+			f, err := os.Open(p)
+			require.NoError(t, err)
+			defer f.Close() //nolint:errcheck
+
+			c, err := compression.DetectReader(f)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, c)
+
+			zr, err := compression.NewReader(c, f)
+			require.NoError(t, err)
+			data, err := io.ReadAll(zr)
+			require.NoError(t, err)
+			require.Equal(t, content, data)
+		})
+	}
+}
+
+func TestWriteInTotoStatement(t *testing.T) {
+	t.Parallel()
+	// Use a format of our own as other tests replace the CycloneDX serializers
+	format := formats.Format("application/vnd.cyclonedx+json;version=1.6-intoto")
+	writer.RegisterSerializer(format, serializers.NewCDX("1.6", formats.JSON))
+	doc := sbom.NewDocument()
+	doc.Metadata.Id = "urn:uuid:7c1aa2b1-7d5f-4e5a-9d1c-0a5e6c0c8f11"
+	doc.NodeList.AddRootNode(&sbom.Node{
+		Id:   "image",
+		Name: "registry.example.com/app",
+		Hashes: map[int32]string{
+			int32(sbom.HashAlgorithm_SHA256): "ABCDEF0123",
+		},
+	})
+
+	var buf bytes.Buffer
+	w := &writer.Writer{Options: &writer.Options{}}
+	require.NoError(t, w.WriteStreamWithOptions(doc, &buf, &writer.Options{
+		Format:          format,
+		InTotoStatement: true,
+	}))
+
+	statement := &intoto.Statement{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), statement))
+	require.Equal(t, intoto.StatementType, statement.Type)
+	require.Equal(t, intoto.PredicateTypeCycloneDX, statement.PredicateType)
+	require.Equal(t, []intoto.Subject{
+		{Name: "registry.example.com/app", Digest: map[string]string{"sha256": "abcdef0123"}},
+	}, statement.Subject)
+	require.Regexp(t, `"specVersion":\s*"1.6"`, string(statement.Predicate))
+
+	// Documents without root hashes cannot be attested
+	require.ErrorIs(t, w.WriteStreamWithOptions(sbom.NewDocument(), &buf, &writer.Options{
+		Format:          format,
+		InTotoStatement: true,
+	}), intoto.ErrNoSubjects)
+}
+
+func TestWriteSigned(t *testing.T) {
+	t.Parallel()
+	format := formats.Format("application/vnd.cyclonedx+json;version=1.6-signed")
+	writer.RegisterSerializer(format, serializers.NewCDX("1.6", formats.JSON))
+	doc := sbom.NewDocument()
+	doc.NodeList.AddRootNode(&sbom.Node{
+		Id:     "image",
+		Name:   "registry.example.com/app",
+		Hashes: map[int32]string{int32(sbom.HashAlgorithm_SHA256): "abcdef0123"},
+	})
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := dsse.NewSigner(key)
+	require.NoError(t, err)
+	verifier, err := dsse.NewVerifier(signer.Public())
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name        string
+		inToto      bool
+		payloadType string
+	}{
+		{"document", false, string(format)},
+		{"statement", true, dsse.PayloadTypeInToto},
+	} {
+		var buf bytes.Buffer
+		w := &writer.Writer{Options: &writer.Options{}}
+		require.NoError(t, w.WriteStreamWithOptions(doc, &buf, &writer.Options{
+			Format:          format,
+			InTotoStatement: tc.inToto,
+			Signers:         []dsse.Signer{signer},
+		}), tc.name)
+
+		envelope, err := dsse.ParseEnvelope(buf.Bytes())
+		require.NoError(t, err, tc.name)
+		require.Equal(t, tc.payloadType, envelope.PayloadType, tc.name)
+		_, err = envelope.Verify(verifier)
+		require.NoError(t, err, tc.name)
+		require.Regexp(t, `"specVersion":\s*"1.6"`, string(envelope.Payload), tc.name)
+	}
+}
+
+func TestWriteTransformed(t *testing.T) {
+	t.Parallel()
+	format := formats.Format("application/vnd.cyclonedx+json;version=1.6-transformed")
+	writer.RegisterSerializer(format, serializers.NewCDX("1.6", formats.JSON))
+	doc := sbom.NewDocument()
+	doc.NodeList.AddRootNode(&sbom.Node{Id: "app", Name: "app", Version: "1.0.0"})
+	doc.NodeList.AddNode(&sbom.Node{Id: "secret", Name: "internal-tool"})
+	require.NoError(t, doc.NodeList.RelateNodeAtID(&sbom.Node{Id: "lib", Name: "lib"}, "app", sbom.Edge_dependsOn))
+
+	order := []string{}
+	redact := transform.Func(func(d *sbom.Document) error {
+		order = append(order, "redact")
+		d.NodeList.RemoveNodes([]string{"secret"})
+		return nil
+	})
+	rename := transform.Func(func(d *sbom.Document) error {
+		order = append(order, "rename")
+		for _, n := range d.NodeList.GetNodes() {
+			n.Name = "example/" + n.Name
+		}
+		return nil
+	})
+
+	var buf bytes.Buffer
+	w := &writer.Writer{Options: &writer.Options{}}
+	require.NoError(t, w.WriteStreamWithOptions(doc, &buf, &writer.Options{
+		Format:       format,
+		Transformers: []transform.Transformer{redact, rename},
+	}))
+	require.Equal(t, []string{"redact", "rename"}, order)
+	require.Regexp(t, `"name":\s*"example/app"`, buf.String())
+	require.NotContains(t, buf.String(), "internal-tool")
+
+	// The document passed to the writer is not modified
+	require.Len(t, doc.GetNodeList().GetNodes(), 3)
+	require.Equal(t, "app", doc.GetNodeList().GetNodeByID("app").GetName())
+
+	// Errors abort the write
+	failing := transform.Func(func(*sbom.Document) error { return errors.New("boom") })
+	buf.Reset()
+	err := w.WriteStreamWithOptions(doc, &buf, &writer.Options{
+		Format:       format,
+		Transformers: []transform.Transformer{failing},
+	})
+	require.ErrorContains(t, err, "boom")
+	require.Empty(t, buf.String())
+}
+
+func TestNewOptionsIsolated(t *testing.T) {
+	t.Parallel()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := dsse.NewSigner(key)
+	require.NoError(t, err)
+
+	signed := writer.New(writer.WithSigner(signer), writer.WithSigner(signer))
+	require.Len(t, signed.Options.Signers, 2)
+
+	// Options set in a writer must not leak into writers created later
+	format := formats.Format("application/spdx+json;version=2.3-isolated")
+	writer.RegisterSerializer(format, serializers.NewSPDX23())
+	w := writer.New(writer.WithFormat(format))
+	require.Empty(t, w.Options.Signers)
+
+	doc := sbom.NewDocument()
+	doc.NodeList.AddRootNode(&sbom.Node{Id: "app", Name: "app"})
+	var buf bytes.Buffer
+	require.NoError(t, w.WriteStream(doc, &buf))
+	require.Regexp(t, `^\{\s*"spdxVersion":\s*"SPDX-2.3"`, buf.String())
+}
+
+func TestWithTransformerIsolated(t *testing.T) {
+	t.Parallel()
+	calls := 0
+	count := transform.Func(func(*sbom.Document) error {
+		calls++
+		return nil
+	})
+	writer.New(writer.WithTransformer(count))
+	writer.New(writer.WithTransformer(count))
+
+	format := formats.Format("application/spdx+json;version=2.3-transformers")
+	writer.RegisterSerializer(format, serializers.NewSPDX23())
+	w := writer.New(writer.WithFormat(format))
+	require.Empty(t, w.Options.Transformers)
+
+	doc := sbom.NewDocument()
+	doc.NodeList.AddRootNode(&sbom.Node{Id: "app", Name: "app"})
+	require.NoError(t, w.WriteStream(doc, io.Discard))
+	require.Zero(t, calls)
+}
+
+func TestWithCompressionIsolated(t *testing.T) {
+	t.Parallel()
+	gz := writer.New(writer.WithCompression(compression.Gzip))
+	require.Equal(t, compression.Gzip, gz.Options.Compression)
+
+	format := formats.Format("application/spdx+json;version=2.3-uncompressed")
+	writer.RegisterSerializer(format, serializers.NewSPDX23())
+	w := writer.New(writer.WithFormat(format))
+	require.Equal(t, compression.None, w.Options.Compression)
+
+	var buf bytes.Buffer
+	require.NoError(t, w.WriteStream(sbom.NewDocument(), &buf))
+	c, err := compression.DetectReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, compression.None, c)
+}
+
+func TestWithInTotoStatementIsolated(t *testing.T) {
+	t.Parallel()
+	wrapped := writer.New(writer.WithInTotoStatement(true))
+	require.True(t, wrapped.Options.InTotoStatement)
+
+	format := formats.Format("application/vnd.cyclonedx+json;version=1.6-unwrapped")
+	writer.RegisterSerializer(format, serializers.NewCDX("1.6", formats.JSON))
+	w := writer.New(writer.WithFormat(format))
+	require.False(t, w.Options.InTotoStatement)
+
+	// Documents without root hashes can be written when not wrapped
+	var buf bytes.Buffer
+	require.NoError(t, w.WriteStream(sbom.NewDocument(), &buf))
+	require.NotContains(t, buf.String(), intoto.StatementType)
+	require.Regexp(t, `"specVersion":\s*"1.6"`, buf.String())
+}